@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/zoncoen/scenarigo/internal/generator"
+)
+
+var generateCmd = &cobra.Command{
+	Use:   "generate [flags] FILE...",
+	Short: "generate test scenario skeletons",
+	Long: `Generates test scenario skeletons from an OpenAPI 3 document or proto files.
+A scenario file is generated for each operation of the OpenAPI document or each RPC method of the proto files.
+The generated scenarios have request templates and placeholder expect blocks to be edited.`,
+	Args:          cobra.MinimumNArgs(1),
+	RunE:          generate,
+	SilenceErrors: true,
+	SilenceUsage:  true,
+}
+
+var (
+	generateOutputDir   string
+	generateImportPaths []string
+	generateOverwrite   bool
+)
+
+func init() {
+	generateCmd.Flags().StringVarP(&generateOutputDir, "output", "o", ".", "output directory")
+	generateCmd.Flags().StringArrayVarP(&generateImportPaths, "import-path", "I", nil, "import path to find proto files")
+	generateCmd.Flags().BoolVar(&generateOverwrite, "overwrite", false, "overwrite existing files")
+	rootCmd.AddCommand(generateCmd)
+}
+
+func generate(cmd *cobra.Command, args []string) error {
+	var (
+		files []*generator.File
+		err   error
+	)
+	protos := 0
+	for _, arg := range args {
+		if strings.EqualFold(filepath.Ext(arg), ".proto") {
+			protos++
+		}
+	}
+	switch {
+	case protos == len(args):
+		files, err = generator.FromProto(cmd.Context(), generator.ProtoOption{
+			Imports:   generateImportPaths,
+			Files:     args,
+			OutputDir: generateOutputDir,
+		})
+	case protos > 0:
+		return errors.New("can't mix an OpenAPI document and proto files")
+	case len(args) > 1:
+		return errors.New("only one OpenAPI document can be specified")
+	default:
+		files, err = generator.FromOpenAPI(args[0])
+	}
+	if err != nil {
+		return fmt.Errorf("failed to generate scenarios: %w", err)
+	}
+	paths, err := generator.Write(generateOutputDir, files, generateOverwrite)
+	for _, p := range paths {
+		fmt.Fprintln(cmd.OutOrStdout(), p)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write scenarios: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/spf13/cobra"
+)
+
+func TestGenerate(t *testing.T) {
+	tests := map[string]struct {
+		args    []string
+		imports []string
+		expect  []string
+	}{
+		"openapi": {
+			args:   []string{"../../../internal/generator/testdata/petstore.yaml"},
+			expect: []string{"listPets.yaml", "post_pets.yaml", "getPet.yaml"},
+		},
+		"proto": {
+			args:    []string{"test/test.proto"},
+			imports: []string{"../../../testdata/proto"},
+			expect:  []string{"Test_Echo.yaml"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			generateOutputDir = dir
+			generateImportPaths = test.imports
+			generateOverwrite = false
+			cmd := &cobra.Command{}
+			cmd.SetContext(context.Background())
+			var buf bytes.Buffer
+			cmd.SetOut(&buf)
+			if err := generate(cmd, test.args); err != nil {
+				t.Fatal(err)
+			}
+			var expect strings.Builder
+			for _, f := range test.expect {
+				expect.WriteString(filepath.Join(dir, f) + "\n")
+			}
+			if got := buf.String(); got != expect.String() {
+				dmp := diffmatchpatch.New()
+				diffs := dmp.DiffMain(expect.String(), got, false)
+				t.Errorf("stdout differs:\n%s", dmp.DiffPrettyText(diffs))
+			}
+		})
+	}
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		tests := map[string][]string{
+			"mixed":             {"openapi.yaml", "test.proto"},
+			"multiple openapis": {"a.yaml", "b.yaml"},
+			"not found":         {"not-found.yaml"},
+		}
+		for name, args := range tests {
+			t.Run(name, func(t *testing.T) {
+				generateOutputDir = t.TempDir()
+				generateImportPaths = nil
+				cmd := &cobra.Command{}
+				cmd.SetContext(context.Background())
+				cmd.SetOut(&bytes.Buffer{})
+				if err := generate(cmd, args); err == nil {
+					t.Fatal("no error")
+				}
+			})
+		}
+	})
+}
@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"fmt"
+	"io"
+	"os"
 
 	"github.com/fatih/color"
 	"github.com/pkg/errors"
@@ -15,10 +17,23 @@ import (
 // ErrTestFailed is the error returned when the test failed.
 var ErrTestFailed = errors.New("test failed")
 
-var verbose bool
+var (
+	verbose     bool
+	tags        []string
+	skipTags    []string
+	maxParallel int
+	events      string
+
+	updateSnapshots bool
+)
 
 func init() {
 	runCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "print verbose log")
+	runCmd.Flags().StringSliceVar(&tags, "tags", nil, `run only scenarios and steps which match any of the tag expressions (e.g. "smoke", "api&!slow")`)
+	runCmd.Flags().IntVar(&maxParallel, "parallel", 0, "maximum number of scenarios to run in parallel (default 1)")
+	runCmd.Flags().StringSliceVar(&skipTags, "skip-tags", nil, "skip scenarios and steps which match any of the tag expressions")
+	runCmd.Flags().StringVar(&events, "events", "", `write test events to the file in JSON Lines format ("-" means stdout instead of the text output)`)
+	runCmd.Flags().BoolVar(&updateSnapshots, "update-snapshots", false, "overwrite the snapshots of assert.snapshot by the actual values")
 	rootCmd.AddCommand(runCmd)
 }
 
@@ -32,6 +47,9 @@ var runCmd = &cobra.Command{
 }
 
 func run(cmd *cobra.Command, args []string) error {
+	if maxParallel < 0 {
+		return fmt.Errorf("--parallel must not be negative but got %d", maxParallel)
+	}
 	opts := []func(*scenarigo.Runner) error{}
 	cfg, err := config.Load()
 	if err != nil {
@@ -46,14 +64,36 @@ func run(cmd *cobra.Command, args []string) error {
 	if len(args) > 0 {
 		opts = append(opts, scenarigo.WithScenarios(args...))
 	}
+	if len(tags) > 0 {
+		opts = append(opts, scenarigo.WithTags(tags...))
+	}
+	if len(skipTags) > 0 {
+		opts = append(opts, scenarigo.WithSkipTags(skipTags...))
+	}
+	if updateSnapshots {
+		opts = append(opts, scenarigo.WithUpdateSnapshots(true))
+	}
 	r, err := scenarigo.NewRunner(opts...)
 	if err != nil {
 		return err
 	}
 
-	reporterOpts := []reporter.Option{
-		reporter.WithWriter(cmd.OutOrStdout()),
+	out := cmd.OutOrStdout()
+	reporterOpts := []reporter.Option{}
+	switch events {
+	case "":
+	case "-":
+		reporterOpts = append(reporterOpts, reporter.WithEventWriter(out))
+		out = io.Discard
+	default:
+		f, err := os.Create(events)
+		if err != nil {
+			return fmt.Errorf("failed to create events file: %w", err)
+		}
+		defer f.Close()
+		reporterOpts = append(reporterOpts, reporter.WithEventWriter(f))
 	}
+	reporterOpts = append(reporterOpts, reporter.WithWriter(out))
 	if (cfg != nil && cfg.Output.Verbose) || verbose {
 		reporterOpts = append(reporterOpts, reporter.WithVerboseLog())
 	}
@@ -70,6 +110,14 @@ func run(cmd *cobra.Command, args []string) error {
 		reporterOpts = append(reporterOpts, reporter.WithTestSummary())
 	}
 
+	parallel := maxParallel
+	if parallel == 0 && cfg != nil {
+		parallel = cfg.Parallel
+	}
+	if parallel > 0 {
+		reporterOpts = append(reporterOpts, reporter.WithMaxParallel(parallel))
+	}
+
 	var reportErr error
 	success := reporter.Run(
 		func(rptr reporter.Reporter) {
@@ -81,6 +129,11 @@ func run(cmd *cobra.Command, args []string) error {
 	if reportErr != nil {
 		return fmt.Errorf("failed to create test reports: %w", reportErr)
 	}
+	if cov := r.OpenAPICoverage(); cov != nil {
+		if err := cov.WriteSummary(out); err != nil {
+			return fmt.Errorf("failed to print OpenAPI coverage: %w", err)
+		}
+	}
 	if !success {
 		return ErrTestFailed
 	}
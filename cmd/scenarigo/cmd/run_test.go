@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"io"
 	"net/http"
@@ -14,6 +15,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/zoncoen/scenarigo/cmd/scenarigo/cmd/config"
 	"github.com/zoncoen/scenarigo/internal/testutil"
+	"github.com/zoncoen/scenarigo/reporter"
 )
 
 func TestRun(t *testing.T) {
@@ -34,6 +36,8 @@ func TestRun(t *testing.T) {
 	tests := map[string]struct {
 		args          []string
 		config        string
+		tags          []string
+		skipTags      []string
 		expectError   string
 		expectOutput  string
 		expectReports []string
@@ -90,6 +94,15 @@ ok  	scenarios/pass.yaml	0.000s
 			args:   []string{"testdata/scenarios/pass.yaml"},
 			expectOutput: strings.TrimPrefix(`
 ok  	scenarios/pass.yaml	0.000s
+`, "\n"),
+		},
+		"skip by tags": {
+			args:     []string{},
+			config:   "./testdata/scenarigo.yaml",
+			skipTags: []string{"!smoke"},
+			expectOutput: strings.TrimPrefix(`
+ok  	scenarios/fail.yaml	0.000s
+ok  	scenarios/pass.yaml	0.000s
 `, "\n"),
 		},
 		"plugin not found": {
@@ -218,6 +231,7 @@ ok  	scenarios/pass.yaml	0.000s
 			var buf bytes.Buffer
 			cmd.SetOut(&buf)
 			config.ConfigPath = test.config
+			tags, skipTags = test.tags, test.skipTags
 			err := run(cmd, test.args)
 			if test.expectError != "" {
 				if err == nil {
@@ -242,3 +256,42 @@ ok  	scenarios/pass.yaml	0.000s
 		})
 	}
 }
+
+func TestRun_Events(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/json")
+		_, _ = io.Copy(w, r.Body)
+	}))
+	defer srv.Close()
+	t.Setenv("TEST_ADDR", srv.URL)
+
+	cmd := &cobra.Command{}
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	config.ConfigPath = ""
+	tags, skipTags = nil, nil
+	events = "-"
+	defer func() { events = "" }()
+	if err := run(cmd, []string{"testdata/scenarios/pass.yaml"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	results := map[string]reporter.EventAction{}
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var e reporter.Event
+		if err := dec.Decode(&e); err != nil {
+			t.Fatalf("stdout must be JSON Lines: %s", err)
+		}
+		switch e.Action {
+		case reporter.EventActionPass, reporter.EventActionFail, reporter.EventActionSkip:
+			results[e.Test] = e.Action
+		default:
+		}
+	}
+	for _, test := range []string{"", "testdata/scenarios/pass.yaml"} {
+		if got, expect := results[test], reporter.EventActionPass; got != expect {
+			t.Errorf("%q: expect %q but got %q", test, expect, got)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/zoncoen/scenarigo/cmd/scenarigo/cmd/config"
+	"github.com/zoncoen/scenarigo/plugin"
+	"github.com/zoncoen/scenarigo/schema"
+	"github.com/zoncoen/scenarigo/schema/jsonschema"
+)
+
+const (
+	schemaTargetScenario = "scenario"
+	schemaTargetConfig   = "config"
+)
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema [scenario|config]",
+	Short: "print the JSON Schema of scenario or configuration files",
+	Long: `Prints the JSON Schema of scenario files (default) or configuration files.
+The schema can be used by editors such as yaml-language-server to complete and validate the files.
+If the configuration file specifies plugins, the schemas of protocols provided by the plugins are also included.`,
+	Args:          cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+	ValidArgs:     []string{schemaTargetScenario, schemaTargetConfig},
+	RunE:          printSchema,
+	SilenceErrors: true,
+	SilenceUsage:  true,
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+}
+
+func printSchema(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if cfg != nil {
+		// open plugins to register the protocols provided by them
+		pluginDir := cfg.Root
+		if cfg.PluginDirectory != "" {
+			pluginDir = filepath.Join(cfg.Root, cfg.PluginDirectory)
+		}
+		for _, item := range cfg.Plugins.ToSlice() {
+			if _, err := plugin.Open(filepath.Join(pluginDir, item.Key)); err != nil {
+				return fmt.Errorf("failed to open plugin %s: %w", item.Key, err)
+			}
+		}
+	}
+
+	var s *jsonschema.Schema
+	target := schemaTargetScenario
+	if len(args) > 0 {
+		target = args[0]
+	}
+	switch target {
+	case schemaTargetScenario:
+		s = schema.ScenarioJSONSchema()
+	case schemaTargetConfig:
+		s = schema.ConfigJSONSchema()
+	default:
+		return fmt.Errorf("unknown target %q", target)
+	}
+
+	enc := json.NewEncoder(cmd.OutOrStdout())
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(s); err != nil {
+		return fmt.Errorf("failed to encode JSON Schema: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/zoncoen/scenarigo/cmd/scenarigo/cmd/config"
+	"github.com/zoncoen/scenarigo/schema/jsonschema"
+)
+
+func TestPrintSchema(t *testing.T) {
+	tests := map[string]struct {
+		args  []string
+		title string
+	}{
+		"default": {
+			title: "scenarigo scenario",
+		},
+		"scenario": {
+			args:  []string{"scenario"},
+			title: "scenarigo scenario",
+		},
+		"config": {
+			args:  []string{"config"},
+			title: "scenarigo configuration",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			var buf bytes.Buffer
+			cmd.SetOut(&buf)
+			config.ConfigPath = "./testdata/scenarigo.yaml"
+			if err := printSchema(cmd, test.args); err != nil {
+				t.Fatal(err)
+			}
+			var got map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatalf("failed to unmarshal JSON: %s", err)
+			}
+			if got, expect := got["$schema"], jsonschema.Draft; got != expect {
+				t.Errorf("expect %q but got %q", expect, got)
+			}
+			if got, expect := got["title"], test.title; got != expect {
+				t.Errorf("expect %q but got %q", expect, got)
+			}
+		})
+	}
+	t.Run("unknown target", func(t *testing.T) {
+		cmd := &cobra.Command{}
+		cmd.SetOut(&bytes.Buffer{})
+		config.ConfigPath = "./testdata/scenarigo.yaml"
+		if err := printSchema(cmd, []string{"unknown"}); err == nil {
+			t.Fatal("no error")
+		}
+	})
+}
@@ -10,6 +10,7 @@ import (
 
 type assertions struct {
 	ctx context.Context
+	c   *Context
 }
 
 // ExtractByKey implements query.KeyExtractor interface.
@@ -43,6 +44,8 @@ func (a *assertions) ExtractByKey(key string) (interface{}, bool) {
 		return assert.LessOrEqual, true
 	case "length":
 		return assert.Length, true
+	case "snapshot":
+		return &snapshotAssertion{c: a.c}, true
 	}
 	return nil, false
 }
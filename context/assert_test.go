@@ -21,7 +21,7 @@ func TestAssertions(t *testing.T) {
 			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 			defer cancel()
 			return assert.MustBuild(ctx, i, assert.FromTemplate(map[string]interface{}{
-				"assert": &assertions{ctx},
+				"assert": &assertions{ctx: ctx},
 			})).Assert(v)
 		}
 	}
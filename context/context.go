@@ -3,10 +3,13 @@ package context
 
 import (
 	"context"
+	"net/http"
 	"path/filepath"
 	"testing"
 
 	"github.com/goccy/go-yaml/ast"
+	"github.com/zoncoen/scenarigo/internal/snapshot"
+	"github.com/zoncoen/scenarigo/internal/tagutil"
 	"github.com/zoncoen/scenarigo/reporter"
 )
 
@@ -21,6 +24,10 @@ type (
 	keyResponse         struct{}
 	keyYAMLNode         struct{}
 	keyEnabledColor     struct{}
+	keyTagFilter        struct{}
+	keyOpenAPI          struct{}
+	keyCookieJar        struct{}
+	keySnapshotFile     struct{}
 )
 
 // Context represents a scenarigo context.
@@ -280,6 +287,96 @@ func (c *Context) EnabledColor() bool {
 	return false
 }
 
+// WithTagFilter returns a copy of c with the filter to select steps by tags.
+func (c *Context) WithTagFilter(f *tagutil.Filter) *Context {
+	return newContext(
+		context.WithValue(c.ctx, keyTagFilter{}, f),
+		c.reqCtx,
+		c.reporter,
+	)
+}
+
+// TagFilter returns the filter to select steps by tags.
+// It returns nil if the filter is not set.
+func (c *Context) TagFilter() *tagutil.Filter {
+	f, ok := c.ctx.Value(keyTagFilter{}).(*tagutil.Filter)
+	if ok {
+		return f
+	}
+	return nil
+}
+
+// OpenAPIDocument is the interface that validates HTTP responses against an OpenAPI document.
+// The context doesn't depend on the implementation to avoid importing it by all packages.
+type OpenAPIDocument interface {
+	ValidateResponse(ctx context.Context, req *http.Request, status int, header http.Header, body []byte) error
+}
+
+// WithOpenAPI returns a copy of c with the OpenAPI document to validate HTTP responses.
+func (c *Context) WithOpenAPI(doc OpenAPIDocument) *Context {
+	return newContext(
+		context.WithValue(c.ctx, keyOpenAPI{}, doc),
+		c.reqCtx,
+		c.reporter,
+	)
+}
+
+// OpenAPI returns the OpenAPI document to validate HTTP responses.
+// It returns nil if the document is not set.
+func (c *Context) OpenAPI() OpenAPIDocument {
+	doc, ok := c.ctx.Value(keyOpenAPI{}).(OpenAPIDocument)
+	if ok {
+		return doc
+	}
+	return nil
+}
+
+// CookieJar is the interface that manages the cookies shared across the HTTP requests.
+// The context doesn't depend on the implementation to avoid importing it by all packages.
+type CookieJar interface {
+	http.CookieJar
+	// ExtractByKey returns the value of the cookie called key regardless of the URL which has set it.
+	ExtractByKey(key string) (interface{}, bool)
+}
+
+// WithCookieJar returns a copy of c with the cookie jar shared across the HTTP requests.
+func (c *Context) WithCookieJar(jar CookieJar) *Context {
+	return newContext(
+		context.WithValue(c.ctx, keyCookieJar{}, jar),
+		c.reqCtx,
+		c.reporter,
+	)
+}
+
+// CookieJar returns the cookie jar shared across the HTTP requests.
+// It returns nil if the cookie jar is not set.
+func (c *Context) CookieJar() CookieJar {
+	jar, ok := c.ctx.Value(keyCookieJar{}).(CookieJar)
+	if ok {
+		return jar
+	}
+	return nil
+}
+
+// WithSnapshotFile returns a copy of c with the snapshot file of the scenario file.
+func (c *Context) WithSnapshotFile(f *snapshot.File) *Context {
+	return newContext(
+		context.WithValue(c.ctx, keySnapshotFile{}, f),
+		c.reqCtx,
+		c.reporter,
+	)
+}
+
+// SnapshotFile returns the snapshot file of the scenario file.
+// It returns nil if the snapshot file is not set.
+func (c *Context) SnapshotFile() *snapshot.File {
+	f, ok := c.ctx.Value(keySnapshotFile{}).(*snapshot.File)
+	if ok {
+		return f
+	}
+	return nil
+}
+
 // Run runs f as a subtest of c called name.
 func (c *Context) Run(name string, f func(*Context)) bool {
 	return c.Reporter().Run(name, func(r reporter.Reporter) { f(c.WithReporter(r)) })
@@ -1,6 +1,8 @@
 package context_test
 
 import (
+	gocontext "context"
+	"net/http"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -34,6 +36,23 @@ func TestContext(t *testing.T) {
 			t.Fatal("failed to get enabledColor")
 		}
 	})
+	t.Run("openAPI", func(t *testing.T) {
+		ctx := context.FromT(t)
+		if ctx.OpenAPI() != nil {
+			t.Fatal("unexpected OpenAPI document")
+		}
+		doc := &testOpenAPIDocument{}
+		ctx = ctx.WithOpenAPI(doc)
+		if ctx.OpenAPI() != doc {
+			t.Fatal("failed to get OpenAPI document")
+		}
+	})
+}
+
+type testOpenAPIDocument struct{}
+
+func (d *testOpenAPIDocument) ValidateResponse(_ gocontext.Context, _ *http.Request, _ int, _ http.Header, _ []byte) error {
+	return nil
 }
 
 func TestRunWithRetry(t *testing.T) {
@@ -10,6 +10,7 @@ const (
 	nameResponse = "response"
 	nameEnv      = "env"
 	nameAssert   = "assert"
+	nameCookies  = "cookies"
 )
 
 // ExtractByKey implements query.KeyExtractor interface.
@@ -47,10 +48,15 @@ func (c *Context) ExtractByKey(key string) (interface{}, bool) {
 		if v != nil {
 			return v, true
 		}
+	case nameCookies:
+		v := c.CookieJar()
+		if v != nil {
+			return v, true
+		}
 	case nameEnv:
 		return env, true
 	case nameAssert:
-		return &assertions{c.RequestContext()}, true
+		return &assertions{ctx: c.RequestContext(), c: c}, true
 	}
 	return nil, false
 }
@@ -1,11 +1,14 @@
 package context
 
 import (
+	"net/http"
+	"net/url"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/zoncoen/query-go"
 	"github.com/zoncoen/scenarigo/internal/queryutil"
+	"github.com/zoncoen/scenarigo/protocol/http/cookie"
 	"github.com/zoncoen/scenarigo/reporter"
 )
 
@@ -68,6 +71,17 @@ func TestContext_ExtractKey(t *testing.T) {
 			query:  "response.foo",
 			expect: "bar",
 		},
+		"cookies": {
+			ctx: func(ctx *Context) *Context {
+				jar := cookie.NewJar()
+				jar.SetCookies(&url.URL{Scheme: "http", Host: "example.com"}, []*http.Cookie{
+					{Name: "session", Value: "xxx"},
+				})
+				return ctx.WithCookieJar(jar)
+			},
+			query:  "cookies.session",
+			expect: "xxx",
+		},
 		"env": {
 			query:  "env.TEST_PORT",
 			expect: "5000",
@@ -0,0 +1,80 @@
+package context
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/zoncoen/scenarigo/assert"
+	"github.com/zoncoen/scenarigo/internal/snapshot"
+)
+
+// snapshotAssertion is an assertion to compare a value with the snapshot.
+// It is used as "{{assert.snapshot}}", "{{assert.snapshot("name")}}", or a left arrow function which takes the options.
+type snapshotAssertion struct {
+	c      *Context
+	name   string
+	ignore []*snapshot.Path
+}
+
+type snapshotOption struct {
+	Name string `yaml:"name,omitempty"`
+	// Ignore is the query paths of the values not to compare such as IDs and timestamps.
+	Ignore []string `yaml:"ignore,omitempty"`
+}
+
+// Assert implements assert.Assertion interface.
+func (a *snapshotAssertion) Assert(v interface{}) error {
+	var f *snapshot.File
+	if a.c != nil {
+		f = a.c.SnapshotFile()
+	}
+	if f == nil {
+		return errors.New("snapshot is not available for the scenario which is not loaded from a file")
+	}
+	name := f.Name(a.name)
+	updated, err := f.Match(name, v, a.ignore)
+	if err != nil {
+		return err
+	}
+	if updated {
+		a.c.Reporter().Logf("snapshot %q is updated: %s", name, f.Path())
+	}
+	return nil
+}
+
+// Call returns the assertion to compare a value with the snapshot which has the name.
+func (a *snapshotAssertion) Call(name string) assert.Assertion {
+	return &snapshotAssertion{
+		c:    a.c,
+		name: name,
+	}
+}
+
+// Exec implements template.Func interface.
+func (a *snapshotAssertion) Exec(arg interface{}) (interface{}, error) {
+	opt, ok := arg.(*snapshotOption)
+	if !ok {
+		return nil, errors.New("argument must be a snapshot option")
+	}
+	ignore := make([]*snapshot.Path, 0, len(opt.Ignore))
+	for _, s := range opt.Ignore {
+		p, err := snapshot.ParsePath(s)
+		if err != nil {
+			return nil, err
+		}
+		ignore = append(ignore, p)
+	}
+	return &snapshotAssertion{
+		c:      a.c,
+		name:   opt.Name,
+		ignore: ignore,
+	}, nil
+}
+
+// UnmarshalArg implements template.Func interface.
+func (a *snapshotAssertion) UnmarshalArg(unmarshal func(interface{}) error) (interface{}, error) {
+	var opt snapshotOption
+	if err := unmarshal(&opt); err != nil {
+		return nil, err
+	}
+	return &opt, nil
+}
@@ -0,0 +1,102 @@
+package context
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/zoncoen/scenarigo/assert"
+	"github.com/zoncoen/scenarigo/internal/snapshot"
+)
+
+func TestSnapshotAssertion(t *testing.T) {
+	scenarioPath := filepath.Join(t.TempDir(), "user.yaml")
+	expect := yaml.MapSlice{
+		{Key: "default", Value: "{{assert.snapshot}}"},
+		{Key: "named", Value: `{{assert.snapshot("user")}}`},
+		{
+			Key: "options",
+			Value: yaml.MapSlice{
+				{
+					Key: "{{assert.snapshot <-}}",
+					Value: yaml.MapSlice{
+						{Key: "name", Value: "ignored"},
+						{Key: "ignore", Value: []interface{}{".id"}},
+					},
+				},
+			},
+		},
+	}
+	user := map[string]interface{}{
+		"id":   "1",
+		"name": "alice",
+	}
+	v := map[string]interface{}{
+		"default": "foo",
+		"named":   user,
+		"options": user,
+	}
+	run := func(update bool, v interface{}) error {
+		var err error
+		ctx := FromT(t)
+		ctx = ctx.WithSnapshotFile(snapshot.New(scenarioPath, update).Sub("get user"))
+		ctx.Run("get user", func(ctx *Context) {
+			assertion, buildErr := assert.Build(ctx.RequestContext(), expect, assert.FromTemplate(ctx))
+			if buildErr != nil {
+				err = buildErr
+				return
+			}
+			err = assertion.Assert(v)
+		})
+		return err
+	}
+
+	if err := run(false, v); err == nil {
+		t.Fatal("no error")
+	} else if got, expect := err.Error(), `snapshot "get_user" not found`; !strings.Contains(got, expect) {
+		t.Fatalf("%q doesn't contain %q", got, expect)
+	}
+
+	if err := run(true, v); err != nil {
+		t.Fatalf("failed to update snapshots: %s", err)
+	}
+	b, err := os.ReadFile(snapshot.FilePath(scenarioPath))
+	if err != nil {
+		t.Fatalf("failed to read snapshot file: %s", err)
+	}
+	if got, expect := string(b), `get_user: foo
+get_user/user:
+  id: "1"
+  name: alice
+get_user/ignored:
+  id: <ignored>
+  name: alice
+`; got != expect {
+		t.Errorf("expect %q but got %q", expect, got)
+	}
+
+	user["id"] = "2"
+	if err := run(false, v); err == nil {
+		t.Fatal("no error")
+	} else if got, expect := err.Error(), `.named: snapshot "get_user/user" differs`; !strings.Contains(got, expect) {
+		t.Errorf("%q doesn't contain %q", got, expect)
+	}
+	user["id"] = "1"
+	if err := run(false, v); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	t.Run("not available", func(t *testing.T) {
+		ctx := FromT(t)
+		assertion, err := assert.Build(ctx.RequestContext(), "{{assert.snapshot}}", assert.FromTemplate(ctx))
+		if err != nil {
+			t.Fatalf("failed to build assertion: %s", err)
+		}
+		if err := assertion.Assert("foo"); err == nil {
+			t.Fatal("no error")
+		}
+	})
+}
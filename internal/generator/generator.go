@@ -0,0 +1,99 @@
+// Package generator provides functions to generate scenario skeletons from API definitions.
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/zoncoen/scenarigo/schema"
+)
+
+const schemaVersion = "scenario/v1"
+
+// File represents a generated scenario file.
+type File struct {
+	// Name is the file name relative to the output directory.
+	Name     string
+	Scenario *schema.Scenario
+}
+
+// Marshal returns the YAML encoding of the scenario.
+func (f *File) Marshal() ([]byte, error) {
+	var b bytes.Buffer
+	enc := yaml.NewEncoder(&b, yaml.Indent(2), yaml.IndentSequence(false))
+	if err := enc.Encode(f.Scenario); err != nil {
+		return nil, fmt.Errorf("failed to marshal %s: %w", f.Name, err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to marshal %s: %w", f.Name, err)
+	}
+	return b.Bytes(), nil
+}
+
+// Write writes the files into dir.
+// It fails if a file already exists unless overwrite is true.
+// The generated scenarios are loaded by the same way as "scenarigo run" before writing to ensure that they are valid.
+func Write(dir string, files []*File, overwrite bool) ([]string, error) {
+	paths := make([]string, 0, len(files))
+	for _, f := range files {
+		path := filepath.Join(dir, f.Name)
+		if !overwrite {
+			if _, err := os.Stat(path); err == nil {
+				return paths, fmt.Errorf("%s already exists", path)
+			}
+		}
+		b, err := f.Marshal()
+		if err != nil {
+			return paths, err
+		}
+		if _, err := schema.LoadScenariosFromReader(bytes.NewReader(b)); err != nil {
+			return paths, fmt.Errorf("generated scenario %s is invalid: %w", f.Name, err)
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil { //nolint:gosec
+			return paths, fmt.Errorf("failed to create directory: %w", err)
+		}
+		if err := os.WriteFile(path, b, 0o644); err != nil { //nolint:gosec
+			return paths, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// identifier converts s into a name that can be used as a variable name in templates and file names.
+func identifier(s string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range s {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+			underscore = false
+			continue
+		}
+		if !underscore && b.Len() > 0 {
+			b.WriteRune('_')
+			underscore = true
+		}
+	}
+	id := strings.TrimSuffix(b.String(), "_")
+	if id != "" && unicode.IsDigit(rune(id[0])) {
+		id = "_" + id
+	}
+	return id
+}
+
+// uniqueName returns name with a numeric suffix if it is already used.
+func uniqueName(used map[string]bool, name string) string {
+	n := name
+	for i := 2; used[n]; i++ {
+		n = fmt.Sprintf("%s_%d", name, i)
+	}
+	used[n] = true
+	return n
+}
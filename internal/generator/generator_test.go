@@ -0,0 +1,199 @@
+package generator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+
+	"github.com/zoncoen/scenarigo/protocol/grpc"
+	"github.com/zoncoen/scenarigo/protocol/http"
+	"github.com/zoncoen/scenarigo/schema"
+)
+
+func TestFromOpenAPI(t *testing.T) {
+	files, err := FromOpenAPI("testdata/petstore.yaml")
+	if err != nil {
+		t.Fatalf("failed to generate: %s", err)
+	}
+	expect := map[string]string{
+		"listPets.yaml": `
+schemaVersion: scenario/v1
+title: listPets
+vars:
+  baseURL: https://petstore.example.com/v1
+steps:
+- title: GET /pets
+  protocol: http
+  request:
+    method: GET
+    url: "{{vars.baseURL}}/pets"
+    query:
+      limit: 10
+  expect:
+    code: "200"
+`,
+		"post_pets.yaml": `
+schemaVersion: scenario/v1
+title: POST /pets
+description: Create a pet
+vars:
+  baseURL: https://petstore.example.com/v1
+steps:
+- title: POST /pets
+  protocol: http
+  request:
+    method: POST
+    url: "{{vars.baseURL}}/pets"
+    header:
+      X-Request-Id: ""
+      Content-Type: application/json
+    body:
+      kind: dog
+      name: ""
+      tags:
+      - ""
+  expect:
+    code: "201"
+`,
+		"getPet.yaml": `
+schemaVersion: scenario/v1
+title: getPet
+vars:
+  baseURL: https://petstore.example.com/v1
+  pet_id: "1"
+steps:
+- title: GET /pets/{pet-id}
+  protocol: http
+  request:
+    method: GET
+    url: "{{vars.baseURL}}/pets/{{vars.pet_id}}"
+  expect:
+    code: "200"
+`,
+	}
+	assertFiles(t, files, expect)
+}
+
+func TestFromProto(t *testing.T) {
+	files, err := FromProto(context.Background(), ProtoOption{
+		Imports:   []string{"../../testdata/proto"},
+		Files:     []string{"test/stream.proto"},
+		OutputDir: "../../testdata",
+	})
+	if err != nil {
+		t.Fatalf("failed to generate: %s", err)
+	}
+	scenario := func(method, msgKey, msg string) string {
+		return `
+schemaVersion: scenario/v1
+title: /scenarigo.testdata.test.StreamTest/` + method + `
+vars:
+  target: localhost:50051
+steps:
+- title: ` + method + `
+  protocol: grpc
+  request:
+    target: "{{vars.target}}"
+    service: scenarigo.testdata.test.StreamTest
+    method: ` + method + `
+    ` + msgKey + `:
+` + msg + `
+    options:
+      proto:
+        imports:
+        - proto
+        files:
+        - test/stream.proto
+      auth:
+        insecure: true
+  expect:
+    code: OK
+`
+	}
+	message := `      messageId: ""
+      messageBody: ""`
+	messages := `    - messageId: ""
+      messageBody: ""`
+	expect := map[string]string{
+		"StreamTest_ServerStreamEcho.yaml": scenario("ServerStreamEcho", "message", message),
+		"StreamTest_ClientStreamEcho.yaml": scenario("ClientStreamEcho", "messages", messages),
+		"StreamTest_BidiStreamEcho.yaml":   scenario("BidiStreamEcho", "messages", messages),
+	}
+	assertFiles(t, files, expect)
+}
+
+func TestWrite(t *testing.T) {
+	http.Register()
+	grpc.Register()
+
+	files, err := FromOpenAPI("testdata/petstore.yaml")
+	if err != nil {
+		t.Fatalf("failed to generate: %s", err)
+	}
+	dir := t.TempDir()
+	paths, err := Write(dir, files, false)
+	if err != nil {
+		t.Fatalf("failed to write: %s", err)
+	}
+	if got, expect := len(paths), len(files); got != expect {
+		t.Fatalf("expect %d files but got %d", expect, got)
+	}
+	for _, p := range paths {
+		scns, err := schema.LoadScenarios(p)
+		if err != nil {
+			t.Fatalf("failed to load %s: %s", p, err)
+		}
+		if got := len(scns); got != 1 {
+			t.Fatalf("expect 1 scenario but got %d", got)
+		}
+	}
+
+	t.Run("already exists", func(t *testing.T) {
+		if _, err := Write(dir, files, false); err == nil {
+			t.Fatal("no error")
+		}
+		if _, err := Write(dir, files, true); err != nil {
+			t.Fatalf("failed to overwrite: %s", err)
+		}
+	})
+}
+
+func assertFiles(t *testing.T, files []*File, expect map[string]string) {
+	t.Helper()
+	if got, expect := len(files), len(expect); got != expect {
+		t.Fatalf("expect %d files but got %d", expect, got)
+	}
+	for _, f := range files {
+		e, ok := expect[f.Name]
+		if !ok {
+			t.Errorf("unexpected file %s", f.Name)
+			continue
+		}
+		b, err := f.Marshal()
+		if err != nil {
+			t.Fatalf("failed to marshal: %s", err)
+		}
+		if got, e := string(b), strings.TrimPrefix(e, "\n"); got != e {
+			dmp := diffmatchpatch.New()
+			diffs := dmp.DiffMain(e, got, false)
+			t.Errorf("%s differs:\n%s", f.Name, dmp.DiffPrettyText(diffs))
+		}
+	}
+}
+
+func TestIdentifier(t *testing.T) {
+	tests := map[string]string{
+		"getPet":           "getPet",
+		"get_/pets/{id}":   "get_pets_id",
+		"pet-id":           "pet_id",
+		"1st":              "_1st",
+		"/scenarigo.Test/": "scenarigo_Test",
+	}
+	for in, expect := range tests {
+		if got := identifier(in); got != expect {
+			t.Errorf("%q: expect %q but got %q", in, expect, got)
+		}
+	}
+}
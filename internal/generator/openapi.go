@@ -0,0 +1,275 @@
+package generator
+
+import (
+	"fmt"
+	"math"
+	nethttp "net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/goccy/go-yaml"
+
+	"github.com/zoncoen/scenarigo/protocol/http"
+	"github.com/zoncoen/scenarigo/schema"
+)
+
+const (
+	baseURLVar      = "baseURL"
+	defaultBaseURL  = "http://localhost:8080"
+	jsonContentType = "application/json"
+)
+
+// FromOpenAPI generates a scenario for each operation of the OpenAPI 3 document.
+func FromOpenAPI(path string) ([]*File, error) {
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	doc, err := loader.LoadFromFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OpenAPI document: %w", err)
+	}
+	if err := doc.Validate(loader.Context); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI document: %w", err)
+	}
+
+	baseURL := defaultBaseURL
+	if len(doc.Servers) > 0 {
+		s := doc.Servers[0]
+		baseURL = s.URL
+		for name, v := range s.Variables {
+			baseURL = strings.ReplaceAll(baseURL, "{"+name+"}", v.Default)
+		}
+		baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+
+	files := []*File{}
+	used := map[string]bool{}
+	paths := doc.Paths.InMatchingOrder()
+	sort.Strings(paths)
+	for _, p := range paths {
+		item := doc.Paths.Value(p)
+		ops := item.Operations()
+		methods := make([]string, 0, len(ops))
+		for method := range ops {
+			methods = append(methods, method)
+		}
+		sort.Strings(methods)
+		for _, method := range methods {
+			op := ops[method]
+			name := op.OperationID
+			if name == "" {
+				name = strings.ToLower(method) + "_" + p
+			}
+			files = append(files, &File{
+				Name:     uniqueName(used, identifier(name)) + ".yaml",
+				Scenario: openAPIScenario(baseURL, method, p, item, op),
+			})
+		}
+	}
+	return files, nil
+}
+
+func openAPIScenario(baseURL, method, path string, item *openapi3.PathItem, op *openapi3.Operation) *schema.Scenario {
+	title := op.OperationID
+	if title == "" {
+		title = fmt.Sprintf("%s %s", method, path)
+	}
+	vars := map[string]any{
+		baseURLVar: baseURL,
+	}
+
+	// operation parameters override path item parameters
+	params := map[string]*openapi3.Parameter{}
+	for _, ps := range []openapi3.Parameters{item.Parameters, op.Parameters} {
+		for _, ref := range ps {
+			if p := ref.Value; p != nil {
+				params[p.In+":"+p.Name] = p
+			}
+		}
+	}
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	url := path
+	var query, header yaml.MapSlice
+	for _, k := range keys {
+		p := params[k]
+		switch p.In {
+		case openapi3.ParameterInPath:
+			v := identifier(p.Name)
+			vars[v] = parameterSample(p)
+			url = strings.ReplaceAll(url, "{"+p.Name+"}", fmt.Sprintf("{{vars.%s}}", v))
+		case openapi3.ParameterInQuery:
+			if p.Required {
+				query = append(query, yaml.MapItem{Key: p.Name, Value: parameterSample(p)})
+			}
+		case openapi3.ParameterInHeader:
+			if p.Required {
+				header = append(header, yaml.MapItem{Key: p.Name, Value: fmt.Sprint(parameterSample(p))})
+			}
+		}
+	}
+
+	req := &http.Request{
+		Method: method,
+		URL:    fmt.Sprintf("{{vars.%s}}%s", baseURLVar, url),
+	}
+	if len(query) > 0 {
+		req.Query = query
+	}
+	if op.RequestBody != nil && op.RequestBody.Value != nil {
+		if mt := op.RequestBody.Value.Content.Get(jsonContentType); mt != nil {
+			header = append(header, yaml.MapItem{Key: "Content-Type", Value: jsonContentType})
+			req.Body = schemaSample(mt.Schema, map[*openapi3.Schema]bool{})
+			if req.Body == nil {
+				req.Body = yaml.MapSlice{}
+			}
+		}
+	}
+	if len(header) > 0 {
+		req.Header = header
+	}
+
+	return &schema.Scenario{
+		SchemaVersion: schemaVersion,
+		Title:         title,
+		Description:   op.Summary,
+		Vars:          vars,
+		Steps: []*schema.Step{
+			{
+				Title:    fmt.Sprintf("%s %s", method, path),
+				Protocol: "http",
+				Request:  req,
+				Expect: &http.Expect{
+					Code: expectedStatus(op),
+				},
+			},
+		},
+	}
+}
+
+// expectedStatus returns the lowest success status code of the operation.
+func expectedStatus(op *openapi3.Operation) string {
+	code := 0
+	if op.Responses != nil {
+		for k := range op.Responses.Map() {
+			c, err := strconv.Atoi(k)
+			if err != nil || c < 200 || c >= 300 {
+				continue
+			}
+			if code == 0 || c < code {
+				code = c
+			}
+		}
+	}
+	if code == 0 {
+		code = nethttp.StatusOK
+	}
+	return strconv.Itoa(code)
+}
+
+func parameterSample(p *openapi3.Parameter) any {
+	if p.Example != nil {
+		return normalize(p.Example)
+	}
+	if v := schemaSample(p.Schema, map[*openapi3.Schema]bool{}); v != nil {
+		return v
+	}
+	return ""
+}
+
+// schemaSample returns a placeholder value that matches the schema.
+func schemaSample(ref *openapi3.SchemaRef, visited map[*openapi3.Schema]bool) any {
+	if ref == nil || ref.Value == nil {
+		return nil
+	}
+	s := ref.Value
+	if visited[s] {
+		return nil
+	}
+	visited[s] = true
+	defer delete(visited, s)
+
+	switch {
+	case s.Example != nil:
+		return normalize(s.Example)
+	case s.Default != nil:
+		return normalize(s.Default)
+	case len(s.Enum) > 0:
+		return normalize(s.Enum[0])
+	case len(s.AllOf) > 0:
+		v := yaml.MapSlice{}
+		for _, sub := range s.AllOf {
+			if m, ok := schemaSample(sub, visited).(yaml.MapSlice); ok {
+				v = append(v, m...)
+			}
+		}
+		return v
+	case len(s.OneOf) > 0:
+		return schemaSample(s.OneOf[0], visited)
+	case len(s.AnyOf) > 0:
+		return schemaSample(s.AnyOf[0], visited)
+	}
+
+	switch {
+	case s.Type.Is(openapi3.TypeObject) || (s.Type == nil && len(s.Properties) > 0):
+		names := make([]string, 0, len(s.Properties))
+		for name := range s.Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		v := yaml.MapSlice{}
+		for _, name := range names {
+			if p := schemaSample(s.Properties[name], visited); p != nil {
+				v = append(v, yaml.MapItem{Key: name, Value: p})
+			}
+		}
+		return v
+	case s.Type.Is(openapi3.TypeArray):
+		if item := schemaSample(s.Items, visited); item != nil {
+			return []any{item}
+		}
+		return []any{}
+	case s.Type.Is(openapi3.TypeString):
+		return ""
+	case s.Type.Is(openapi3.TypeInteger), s.Type.Is(openapi3.TypeNumber):
+		return 0
+	case s.Type.Is(openapi3.TypeBoolean):
+		return false
+	}
+	return nil
+}
+
+// normalize converts the values decoded from JSON to print them in YAML naturally.
+// The integral numbers are printed without decimal points, and the keys of maps are sorted.
+func normalize(v any) any {
+	switch v := v.(type) {
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+			return int64(v)
+		}
+		return v
+	case []any:
+		s := make([]any, len(v))
+		for i, e := range v {
+			s[i] = normalize(e)
+		}
+		return s
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		m := make(yaml.MapSlice, 0, len(v))
+		for _, k := range keys {
+			m = append(m, yaml.MapItem{Key: k, Value: normalize(v[k])})
+		}
+		return m
+	}
+	return v
+}
@@ -0,0 +1,235 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/zoncoen/scenarigo/protocol/grpc"
+	"github.com/zoncoen/scenarigo/protocol/grpc/proto"
+	"github.com/zoncoen/scenarigo/schema"
+)
+
+const (
+	targetVar     = "target"
+	defaultTarget = "localhost:50051"
+)
+
+// ProtoOption represents an option to generate scenarios from proto files.
+type ProtoOption struct {
+	// Imports are the import paths to find the proto files.
+	Imports []string
+	// Files are the proto files to generate scenarios.
+	// If Imports is not empty, they are relative to one of the import paths.
+	Files []string
+	// OutputDir is the directory to write scenarios.
+	// The paths of the proto files in the generated scenarios are relative to it.
+	OutputDir string
+}
+
+// FromProto generates a scenario for each RPC method of the services defined in the proto files.
+func FromProto(ctx context.Context, opt ProtoOption) ([]*File, error) {
+	fds, err := proto.NewCompiler(opt.Imports).Compile(ctx, opt.Files)
+	if err != nil {
+		return nil, err
+	}
+
+	protoOpt, err := protoOption(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []*File{}
+	used := map[string]bool{}
+	for _, f := range fds.Files() {
+		svcs := f.Services()
+		for i := 0; i < svcs.Len(); i++ {
+			svc := svcs.Get(i)
+			methods := svc.Methods()
+			for j := 0; j < methods.Len(); j++ {
+				m := methods.Get(j)
+				name := identifier(fmt.Sprintf("%s_%s", svc.Name(), m.Name()))
+				files = append(files, &File{
+					Name:     uniqueName(used, name) + ".yaml",
+					Scenario: protoScenario(svc, m, protoOpt),
+				})
+			}
+		}
+	}
+	return files, nil
+}
+
+// protoOption returns the option to find the proto files from the generated scenarios.
+func protoOption(opt ProtoOption) (*grpc.ProtoOption, error) {
+	outDir := opt.OutputDir
+	if outDir == "" {
+		outDir = "."
+	}
+	outDir, err := filepath.Abs(outDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get output directory: %w", err)
+	}
+	rel := func(p string) (string, error) {
+		abs, err := filepath.Abs(p)
+		if err != nil {
+			return "", err
+		}
+		r, err := filepath.Rel(outDir, abs)
+		if err != nil {
+			return "", err
+		}
+		return filepath.ToSlash(r), nil
+	}
+
+	o := &grpc.ProtoOption{
+		Files: append([]string{}, opt.Files...),
+	}
+	for _, imp := range opt.Imports {
+		r, err := rel(imp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get relative path of %s: %w", imp, err)
+		}
+		o.Imports = append(o.Imports, r)
+	}
+	// If import paths present, the file paths are relative to one of the import paths.
+	if len(o.Imports) == 0 {
+		for i, f := range o.Files {
+			r, err := rel(f)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get relative path of %s: %w", f, err)
+			}
+			o.Files[i] = r
+		}
+	}
+	return o, nil
+}
+
+func protoScenario(svc protoreflect.ServiceDescriptor, m protoreflect.MethodDescriptor, protoOpt *grpc.ProtoOption) *schema.Scenario {
+	insecure := true
+	req := &grpc.Request{
+		Target:  fmt.Sprintf("{{vars.%s}}", targetVar),
+		Service: string(svc.FullName()),
+		Method:  string(m.Name()),
+		Options: &grpc.RequestOptions{
+			Proto: protoOpt,
+			Auth: &grpc.AuthOption{
+				Insecure: &insecure,
+			},
+		},
+	}
+	msg := messageSample(m.Input(), map[protoreflect.FullName]bool{})
+	if m.IsStreamingClient() {
+		req.Messages = []any{msg}
+	} else {
+		req.Message = msg
+	}
+
+	return &schema.Scenario{
+		SchemaVersion: schemaVersion,
+		Title:         fmt.Sprintf("/%s/%s", svc.FullName(), m.Name()),
+		Vars: map[string]any{
+			targetVar: defaultTarget,
+		},
+		Steps: []*schema.Step{
+			{
+				Title:    string(m.Name()),
+				Protocol: "grpc",
+				Request:  req,
+				Expect: &grpc.Expect{
+					Code: "OK",
+				},
+			},
+		},
+	}
+}
+
+// messageSample returns a placeholder message in the JSON mapping of Protocol Buffers.
+func messageSample(md protoreflect.MessageDescriptor, visited map[protoreflect.FullName]bool) yaml.MapSlice {
+	v := yaml.MapSlice{}
+	if visited[md.FullName()] {
+		return v
+	}
+	visited[md.FullName()] = true
+	defer delete(visited, md.FullName())
+
+	fields := md.Fields()
+	oneofs := map[protoreflect.FullName]bool{}
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		// set only the first field of each oneof
+		if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() {
+			if oneofs[od.FullName()] {
+				continue
+			}
+			oneofs[od.FullName()] = true
+		}
+		var value any
+		switch {
+		case fd.IsMap():
+			value = yaml.MapSlice{}
+		case fd.IsList():
+			if item := fieldSample(fd, visited); item != nil {
+				value = []any{item}
+			} else {
+				value = []any{}
+			}
+		default:
+			value = fieldSample(fd, visited)
+		}
+		if value == nil {
+			continue
+		}
+		v = append(v, yaml.MapItem{Key: fd.JSONName(), Value: value})
+	}
+	return v
+}
+
+func fieldSample(fd protoreflect.FieldDescriptor, visited map[protoreflect.FullName]bool) any {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return false
+	case protoreflect.EnumKind:
+		if values := fd.Enum().Values(); values.Len() > 0 {
+			return string(values.Get(0).Name())
+		}
+		return nil
+	case protoreflect.StringKind, protoreflect.BytesKind:
+		return ""
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return wellKnownSample(fd.Message(), visited)
+	default:
+		return 0
+	}
+}
+
+func wellKnownSample(md protoreflect.MessageDescriptor, visited map[protoreflect.FullName]bool) any {
+	name := string(md.FullName())
+	if !strings.HasPrefix(name, "google.protobuf.") {
+		if visited[md.FullName()] {
+			return nil
+		}
+		return messageSample(md, visited)
+	}
+	switch name {
+	case "google.protobuf.Timestamp":
+		return "1970-01-01T00:00:00Z"
+	case "google.protobuf.Duration":
+		return "0s"
+	case "google.protobuf.BoolValue":
+		return false
+	case "google.protobuf.StringValue", "google.protobuf.BytesValue":
+		return ""
+	case "google.protobuf.DoubleValue", "google.protobuf.FloatValue",
+		"google.protobuf.Int64Value", "google.protobuf.UInt64Value",
+		"google.protobuf.Int32Value", "google.protobuf.UInt32Value":
+		return 0
+	case "google.protobuf.Empty":
+		return yaml.MapSlice{}
+	}
+	// omit the fields which have no obvious placeholder such as google.protobuf.Any
+	return nil
+}
@@ -0,0 +1,116 @@
+package snapshot
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Path represents a query path to ignore the values in the snapshots such as ".id" and ".items[*].createdAt".
+// The "[*]" matches all elements of a list or all values of a map.
+type Path struct {
+	str  string
+	segs []segment
+}
+
+type segment struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// ParsePath parses a query path.
+func ParsePath(s string) (*Path, error) {
+	p := &Path{str: s}
+	rest := s
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("invalid path %q: empty key", s)
+			}
+			p.segs = append(p.segs, segment{key: rest[:end]})
+			rest = rest[end:]
+		case '[':
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("invalid path %q: missing ]", s)
+			}
+			seg, err := parseBracket(rest[1:end])
+			if err != nil {
+				return nil, fmt.Errorf("invalid path %q: %w", s, err)
+			}
+			p.segs = append(p.segs, seg)
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("invalid path %q: must start with . or [", s)
+		}
+	}
+	if len(p.segs) == 0 {
+		return nil, fmt.Errorf("invalid path %q: empty path", s)
+	}
+	return p, nil
+}
+
+func parseBracket(s string) (segment, error) {
+	if s == "*" {
+		return segment{wildcard: true}, nil
+	}
+	if len(s) >= 2 && (s[0] == '\'' || s[0] == '"') && s[len(s)-1] == s[0] {
+		return segment{key: s[1 : len(s)-1]}, nil
+	}
+	i, err := strconv.Atoi(s)
+	if err != nil || i < 0 {
+		return segment{}, fmt.Errorf("invalid index %q", s)
+	}
+	return segment{index: i, isIndex: true}, nil
+}
+
+// String returns the path string.
+func (p *Path) String() string {
+	return p.str
+}
+
+// replace replaces the values at the path by the new value.
+func (p *Path) replace(v, nv interface{}) interface{} {
+	return replace(v, p.segs, nv)
+}
+
+func replace(v interface{}, segs []segment, nv interface{}) interface{} {
+	if len(segs) == 0 {
+		return nv
+	}
+	seg, rest := segs[0], segs[1:]
+	switch x := v.(type) {
+	case map[string]interface{}:
+		if seg.wildcard {
+			for k, e := range x {
+				x[k] = replace(e, rest, nv)
+			}
+			return x
+		}
+		if seg.isIndex {
+			return x
+		}
+		if e, ok := x[seg.key]; ok {
+			x[seg.key] = replace(e, rest, nv)
+		}
+	case []interface{}:
+		if seg.wildcard {
+			for i, e := range x {
+				x[i] = replace(e, rest, nv)
+			}
+			return x
+		}
+		if seg.isIndex && seg.index < len(x) {
+			x[seg.index] = replace(x[seg.index], rest, nv)
+		}
+	}
+	return v
+}
@@ -0,0 +1,146 @@
+package snapshot
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParsePath(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tests := map[string]struct {
+			path   string
+			v      interface{}
+			expect interface{}
+		}{
+			"key": {
+				path: ".id",
+				v: map[string]interface{}{
+					"id":   "xxx",
+					"name": "alice",
+				},
+				expect: map[string]interface{}{
+					"id":   Ignored,
+					"name": "alice",
+				},
+			},
+			"quoted key": {
+				path: `['created.at']`,
+				v: map[string]interface{}{
+					"created.at": "2024-01-01T00:00:00Z",
+				},
+				expect: map[string]interface{}{
+					"created.at": Ignored,
+				},
+			},
+			"index": {
+				path: ".items[1].id",
+				v: map[string]interface{}{
+					"items": []interface{}{
+						map[string]interface{}{"id": 1},
+						map[string]interface{}{"id": 2},
+					},
+				},
+				expect: map[string]interface{}{
+					"items": []interface{}{
+						map[string]interface{}{"id": 1},
+						map[string]interface{}{"id": Ignored},
+					},
+				},
+			},
+			"wildcard": {
+				path: ".items[*].id",
+				v: map[string]interface{}{
+					"items": []interface{}{
+						map[string]interface{}{"id": 1, "name": "a"},
+						map[string]interface{}{"id": 2, "name": "b"},
+					},
+				},
+				expect: map[string]interface{}{
+					"items": []interface{}{
+						map[string]interface{}{"id": Ignored, "name": "a"},
+						map[string]interface{}{"id": Ignored, "name": "b"},
+					},
+				},
+			},
+			"wildcard for map": {
+				path: "[*].updatedAt",
+				v: map[string]interface{}{
+					"a": map[string]interface{}{"updatedAt": 1},
+					"b": map[string]interface{}{"updatedAt": 2},
+				},
+				expect: map[string]interface{}{
+					"a": map[string]interface{}{"updatedAt": Ignored},
+					"b": map[string]interface{}{"updatedAt": Ignored},
+				},
+			},
+			"not found": {
+				path: ".items[2].id",
+				v: map[string]interface{}{
+					"items": []interface{}{
+						map[string]interface{}{"id": 1},
+					},
+				},
+				expect: map[string]interface{}{
+					"items": []interface{}{
+						map[string]interface{}{"id": 1},
+					},
+				},
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				p, err := ParsePath(test.path)
+				if err != nil {
+					t.Fatalf("failed to parse: %s", err)
+				}
+				if got, expect := p.String(), test.path; got != expect {
+					t.Errorf("expect %q but got %q", expect, got)
+				}
+				if diff := cmp.Diff(test.expect, p.replace(test.v, Ignored)); diff != "" {
+					t.Errorf("differs (-want +got):\n%s", diff)
+				}
+			})
+		}
+	})
+	t.Run("ng", func(t *testing.T) {
+		tests := map[string]struct {
+			path   string
+			expect string
+		}{
+			"empty": {
+				path:   "",
+				expect: `invalid path "": empty path`,
+			},
+			"no prefix": {
+				path:   "id",
+				expect: `invalid path "id": must start with . or [`,
+			},
+			"empty key": {
+				path:   ".items..id",
+				expect: `invalid path ".items..id": empty key`,
+			},
+			"missing bracket": {
+				path:   ".items[0",
+				expect: `invalid path ".items[0": missing ]`,
+			},
+			"invalid index": {
+				path:   ".items[-1]",
+				expect: `invalid path ".items[-1]": invalid index "-1"`,
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				_, err := ParsePath(test.path)
+				if err == nil {
+					t.Fatal("no error")
+				}
+				if got := err.Error(); got != test.expect {
+					t.Errorf("expect error %q but got %q", test.expect, got)
+				}
+			})
+		}
+	})
+}
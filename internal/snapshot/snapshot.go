@@ -0,0 +1,285 @@
+// Package snapshot provides the snapshot files to compare values with the stored ones.
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/goccy/go-yaml"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+const (
+	// Dir is the name of the directory which has the snapshot files next to the scenario files.
+	Dir = "__snapshots__"
+	// Ext is the extension of the snapshot files.
+	// It is not ".yaml" not to be loaded as a scenario file.
+	Ext = ".snap"
+
+	// Ignored is the placeholder of the ignored values.
+	Ignored = "<ignored>"
+)
+
+var marshalOpts = []yaml.EncodeOption{
+	yaml.CustomMarshaler[json.Number](func(n json.Number) ([]byte, error) {
+		return []byte(n.String()), nil
+	}),
+	yaml.CustomMarshaler[[]byte](func(b []byte) ([]byte, error) {
+		return yaml.Marshal(string(b))
+	}),
+}
+
+// File represents a snapshot file of a scenario file.
+// The snapshots are named by the path from the scenario file to the step such as "<scenario>/<step>".
+type File struct {
+	*store
+	prefix string
+}
+
+// store has the snapshots of a file shared by the sub files.
+type store struct {
+	path   string
+	update bool
+
+	m         sync.Mutex
+	loaded    bool
+	snapshots yaml.MapSlice
+}
+
+// New returns the snapshot file of the scenario file.
+// If update is true, the snapshots are overwritten by the actual values instead of comparing them.
+func New(scenarioPath string, update bool) *File {
+	return &File{
+		store: &store{
+			path:   FilePath(scenarioPath),
+			update: update,
+		},
+	}
+}
+
+// FilePath returns the path of the snapshot file of the scenario file.
+func FilePath(scenarioPath string) string {
+	return filepath.Join(filepath.Dir(scenarioPath), Dir, filepath.Base(scenarioPath)+Ext)
+}
+
+// Path returns the path of the snapshot file.
+func (f *File) Path() string {
+	return f.path
+}
+
+// Sub returns the snapshot file for the sub test called name.
+// The returned file shares the snapshots with f, and the names of the snapshots are prefixed with name.
+// The name should be unique among the sibling tests. See Names.
+func (f *File) Sub(name string) *File {
+	name = rewrite(name)
+	if f.prefix != "" {
+		name = fmt.Sprintf("%s/%s", f.prefix, name)
+	}
+	return &File{
+		store:  f.store,
+		prefix: name,
+	}
+}
+
+// Name returns the name of the snapshot for the test.
+// The name is appended to the test name if it is specified.
+func (f *File) Name(name string) string {
+	if name == "" {
+		return f.prefix
+	}
+	if f.prefix == "" {
+		return name
+	}
+	return fmt.Sprintf("%s/%s", f.prefix, name)
+}
+
+// Names makes the names of the sibling tests unique as well as "go test".
+// The second and subsequent occurrences of a name are suffixed with "#01", "#02", and so on,
+// and an empty name is treated as "#00".
+// The zero value is ready to use.
+type Names struct {
+	seen map[string]int
+}
+
+// Unique returns the unique name for name.
+func (n *Names) Unique(name string) string {
+	if n.seen == nil {
+		n.seen = map[string]int{}
+	}
+	count := n.seen[name]
+	n.seen[name] = count + 1
+	if name != "" && count == 0 {
+		return name
+	}
+	if name == "" {
+		return fmt.Sprintf("#%02d", count)
+	}
+	return fmt.Sprintf("%s#%02d", name, count)
+}
+
+// rewrite rewrites a name to having only printable characters and no white space in the same way as the test names.
+func rewrite(s string) string {
+	b := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch {
+		case unicode.IsSpace(r):
+			b = append(b, '_')
+		case !strconv.IsPrint(r):
+			s := strconv.QuoteRune(r)
+			b = append(b, s[1:len(s)-1]...)
+		default:
+			b = append(b, string(r)...)
+		}
+	}
+	return string(b)
+}
+
+// Match compares v with the snapshot called name.
+// The values at the ignored paths are replaced by the placeholder before comparing.
+// If the snapshots are updated, it stores v as the snapshot and reports whether the snapshot has changed.
+func (f *File) Match(name string, v interface{}, ignore []*Path) (bool, error) {
+	got, err := normalize(v, ignore)
+	if err != nil {
+		return false, err
+	}
+	gotStr, err := marshal(got)
+	if err != nil {
+		return false, err
+	}
+
+	f.m.Lock()
+	defer f.m.Unlock()
+	if err := f.load(); err != nil {
+		return false, err
+	}
+	idx := -1
+	for i, item := range f.snapshots {
+		if item.Key == name {
+			idx = i
+			break
+		}
+	}
+
+	var expectStr string
+	if idx >= 0 {
+		expect, err := normalize(f.snapshots[idx].Value, ignore)
+		if err != nil {
+			return false, err
+		}
+		expectStr, err = marshal(expect)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if !f.update {
+		if idx < 0 {
+			return false, fmt.Errorf("snapshot %q not found in %s: run with --update-snapshots to create it", name, f.path)
+		}
+		if expectStr != gotStr {
+			return false, fmt.Errorf("snapshot %q differs (-snapshot +actual):\n%s", name, diff(expectStr, gotStr))
+		}
+		return false, nil
+	}
+
+	if idx >= 0 && expectStr == gotStr {
+		return false, nil
+	}
+	if idx >= 0 {
+		f.snapshots[idx].Value = got
+	} else {
+		f.snapshots = append(f.snapshots, yaml.MapItem{Key: name, Value: got})
+	}
+	if err := f.save(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (f *store) load() error {
+	if f.loaded {
+		return nil
+	}
+	b, err := os.ReadFile(f.path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read snapshot file: %w", err)
+	}
+	if len(b) > 0 {
+		if err := yaml.UnmarshalWithOptions(b, &f.snapshots, yaml.UseOrderedMap()); err != nil {
+			return fmt.Errorf("failed to read snapshot file %s: %w", f.path, err)
+		}
+	}
+	f.loaded = true
+	return nil
+}
+
+func (f *store) save() error {
+	b, err := yaml.MarshalWithOptions(f.snapshots, marshalOpts...)
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshots: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o755); err != nil { //nolint:gosec
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	if err := os.WriteFile(f.path, b, 0o644); err != nil { //nolint:gosec
+		return fmt.Errorf("failed to write snapshot file: %w", err)
+	}
+	return nil
+}
+
+// normalize converts v into the plain value which consists of maps, slices, and scalars as YAML,
+// and replaces the values at the ignored paths by the placeholder.
+func normalize(v interface{}, ignore []*Path) (interface{}, error) {
+	b, err := yaml.MarshalWithOptions(v, marshalOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal value: %w", err)
+	}
+	var plain interface{}
+	if err := yaml.Unmarshal(b, &plain); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal value: %w", err)
+	}
+	for _, p := range ignore {
+		plain = p.replace(plain, Ignored)
+	}
+	return plain, nil
+}
+
+func marshal(v interface{}) (string, error) {
+	b, err := yaml.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal value: %w", err)
+	}
+	return string(b), nil
+}
+
+func diff(expect, got string) string {
+	dmp := diffmatchpatch.New()
+	a, b, lines := dmp.DiffLinesToChars(expect, got)
+	diffs := dmp.DiffCharsToLines(dmp.DiffMain(a, b, false), lines)
+	var sb strings.Builder
+	for _, d := range diffs {
+		if d.Text == "" {
+			continue
+		}
+		prefix := "  "
+		switch d.Type {
+		case diffmatchpatch.DiffDelete:
+			prefix = "- "
+		case diffmatchpatch.DiffInsert:
+			prefix = "+ "
+		case diffmatchpatch.DiffEqual:
+		}
+		for _, line := range strings.SplitAfter(strings.TrimSuffix(d.Text, "\n"), "\n") {
+			sb.WriteString(prefix)
+			sb.WriteString(strings.TrimSuffix(line, "\n"))
+			sb.WriteString("\n")
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
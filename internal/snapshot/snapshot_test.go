@@ -0,0 +1,150 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFilePath(t *testing.T) {
+	if got, expect := FilePath("scenarios/user.yaml"), filepath.Join("scenarios", "__snapshots__", "user.yaml.snap"); got != expect {
+		t.Errorf("expect %q but got %q", expect, got)
+	}
+}
+
+func TestFile_Name(t *testing.T) {
+	tests := map[string]struct {
+		subs   []string
+		name   string
+		expect string
+	}{
+		"default": {
+			subs:   []string{"get user", "GET"},
+			expect: "get_user/GET",
+		},
+		"with name": {
+			subs:   []string{"get user", "GET"},
+			name:   "body",
+			expect: "get_user/GET/body",
+		},
+		"no sub": {
+			name:   "body",
+			expect: "body",
+		},
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			f := New("scenarios/user.yaml", false)
+			for _, sub := range test.subs {
+				f = f.Sub(sub)
+			}
+			if got := f.Name(test.name); got != test.expect {
+				t.Errorf("expect %q but got %q", test.expect, got)
+			}
+		})
+	}
+}
+
+func TestNames_Unique(t *testing.T) {
+	var names Names
+	var got []string
+	for _, name := range []string{"GET", "", "GET", "POST", "", "GET"} {
+		got = append(got, names.Unique(name))
+	}
+	expect := []string{"GET", "#00", "GET#01", "POST", "#01", "GET#02"}
+	if diff := cmp.Diff(expect, got); diff != "" {
+		t.Errorf("differs (-want +got):\n%s", diff)
+	}
+}
+
+func TestFile_Match(t *testing.T) {
+	scenarioPath := filepath.Join(t.TempDir(), "user.yaml")
+	value := map[string]interface{}{
+		"id":    "c8b1f3",
+		"name":  "alice",
+		"age":   json.Number("20"),
+		"email": []byte("alice@example.com"),
+		"tags":  []interface{}{"a", "b"},
+	}
+	ignore := []*Path{mustParsePath(t, ".id")}
+
+	// not found
+	f := New(scenarioPath, false)
+	if _, err := f.Match("get_user", value, ignore); err == nil {
+		t.Fatal("no error")
+	} else if got, expect := err.Error(), `snapshot "get_user" not found in `; !strings.HasPrefix(got, expect) {
+		t.Fatalf("expect error %q but got %q", expect, got)
+	}
+
+	// create
+	f = New(scenarioPath, true)
+	updated, err := f.Match("get_user", value, ignore)
+	if err != nil {
+		t.Fatalf("failed to update: %s", err)
+	}
+	if !updated {
+		t.Fatal("snapshot is not updated")
+	}
+	b, err := os.ReadFile(FilePath(scenarioPath))
+	if err != nil {
+		t.Fatalf("failed to read snapshot file: %s", err)
+	}
+	if got, expect := string(b), `get_user:
+  age: 20
+  email: alice@example.com
+  id: <ignored>
+  name: alice
+  tags:
+  - a
+  - b
+`; got != expect {
+		t.Errorf("expect %q but got %q", expect, got)
+	}
+	if updated, err := f.Match("get_user", value, ignore); err != nil {
+		t.Fatalf("failed to update: %s", err)
+	} else if updated {
+		t.Error("snapshot is updated though it has not changed")
+	}
+
+	// match
+	f = New(scenarioPath, false)
+	value["id"] = "9a2e7d"
+	if _, err := f.Match("get_user", value, ignore); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// differ
+	value["name"] = "bob"
+	if _, err := f.Match("get_user", value, ignore); err == nil {
+		t.Fatal("no error")
+	} else if got, expect := err.Error(), `snapshot "get_user" differs (-snapshot +actual):
+  age: 20
+  email: alice@example.com
+  id: <ignored>
+- name: alice
++ name: bob
+  tags:
+  - a
+  - b`; got != expect {
+		t.Errorf("expect error %q but got %q", expect, got)
+	}
+	if _, err := f.Match("get_user", value, nil); err == nil {
+		t.Fatal("no error")
+	} else if got, expect := err.Error(), "- id: <ignored>\n+ id: 9a2e7d\n"; !strings.Contains(got, expect) {
+		t.Errorf("%q doesn't contain %q", got, expect)
+	}
+}
+
+func mustParsePath(t *testing.T, s string) *Path {
+	t.Helper()
+	p, err := ParsePath(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
@@ -0,0 +1,121 @@
+// Package streamutil provides utilities to receive a stream of messages such as WebSocket messages and server-sent events.
+package streamutil
+
+import (
+	"time"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/zoncoen/scenarigo/assert"
+	"github.com/zoncoen/scenarigo/context"
+	"github.com/zoncoen/scenarigo/errors"
+)
+
+// DefaultTimeout is the default maximum duration to wait for messages.
+const DefaultTimeout = 5 * time.Second
+
+// Option represents options for receiving messages.
+type Option struct {
+	// Count is the number of messages to receive.
+	Count int `yaml:"count,omitempty"`
+	// Until stops receiving when a received message matches it.
+	Until interface{} `yaml:"until,omitempty"`
+	// Timeout is the maximum duration to wait for messages. (default 5s)
+	Timeout string `yaml:"timeout,omitempty"`
+}
+
+// UnmarshalYAML implements yaml.BytesUnmarshaler interface.
+func (o *Option) UnmarshalYAML(b []byte) error {
+	type alias Option
+	var v alias
+	if err := Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*o = Option(v)
+	return nil
+}
+
+// Unmarshal decodes the options strictly.
+// It decodes the mappings as ordered maps to build the until condition in the same manner as the expect.
+func Unmarshal(b []byte, v interface{}) error {
+	return yaml.UnmarshalWithOptions(b, v, yaml.UseOrderedMap(), yaml.Strict())
+}
+
+// Receiver receives messages according to the options.
+type Receiver struct {
+	count   int
+	until   assert.Assertion
+	timeout time.Duration
+}
+
+// Build returns the receiver of the options.
+// The default options are used if o is nil.
+func (o *Option) Build(ctx *context.Context) (*Receiver, error) {
+	r := &Receiver{
+		timeout: DefaultTimeout,
+	}
+	if o == nil {
+		return r, nil
+	}
+	if o.Count < 0 {
+		return nil, errors.ErrorPathf("count", "count must be greater than or equal to 0 but got %d", o.Count)
+	}
+	r.count = o.Count
+	if o.Until != nil {
+		until, err := assert.Build(ctx.RequestContext(), o.Until, assert.FromTemplate(ctx))
+		if err != nil {
+			return nil, errors.WrapPathf(err, "until", "invalid until condition")
+		}
+		r.until = until
+	}
+	if o.Timeout != "" {
+		timeout, err := time.ParseDuration(o.Timeout)
+		if err != nil {
+			return nil, errors.WrapPathf(err, "timeout", "invalid timeout")
+		}
+		r.timeout = timeout
+	}
+	return r, nil
+}
+
+// Timeout returns the maximum duration to wait for messages.
+func (r *Receiver) Timeout() time.Duration {
+	return r.timeout
+}
+
+// Receive receives the messages from the channel until the number of them reaches the count, a message matches the until condition, handle stops receiving, or the timeout elapses.
+// The handle function converts a message into the value which is counted and checked by the until condition.
+// It returns nil to ignore the message and true to stop receiving.
+// The handleErr function is called with the error of reading messages or the canceled context.
+// It returns nil to stop receiving without errors.
+func Receive[T any](ctx *context.Context, r *Receiver, received <-chan T, readErr <-chan error, handle func(T) (interface{}, bool, error), handleErr func(error) error) error {
+	timer := time.NewTimer(r.timeout)
+	defer timer.Stop()
+	n := 0
+	for r.count <= 0 || n < r.count {
+		select {
+		case msg := <-received:
+			v, stop, err := handle(msg)
+			if err != nil {
+				return err
+			}
+			if stop {
+				return nil
+			}
+			if v == nil {
+				continue
+			}
+			n++
+			if r.until != nil && r.until.Assert(v) == nil {
+				return nil
+			}
+		case err := <-readErr:
+			return handleErr(err)
+		case <-timer.C:
+			return nil
+		case <-ctx.RequestContext().Done():
+			return handleErr(ctx.RequestContext().Err())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,234 @@
+package streamutil
+
+import (
+	gocontext "context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/zoncoen/scenarigo/context"
+)
+
+func TestOption_UnmarshalYAML(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		var o Option
+		if err := yaml.Unmarshal([]byte(`
+count: 2
+until:
+  data: done
+timeout: 1s`), &o); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		expect := Option{
+			Count: 2,
+			Until: yaml.MapSlice{
+				{Key: "data", Value: "done"},
+			},
+			Timeout: "1s",
+		}
+		if diff := cmp.Diff(expect, o); diff != "" {
+			t.Errorf("differs (-want +got):\n%s", diff)
+		}
+	})
+	t.Run("ng", func(t *testing.T) {
+		var o Option
+		if err := yaml.Unmarshal([]byte(`unknown: 1`), &o); err == nil {
+			t.Fatal("no error")
+		}
+	})
+}
+
+func TestOption_Build(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tests := map[string]struct {
+			opt     *Option
+			timeout time.Duration
+		}{
+			"nil": {
+				timeout: DefaultTimeout,
+			},
+			"empty": {
+				opt:     &Option{},
+				timeout: DefaultTimeout,
+			},
+			"timeout": {
+				opt:     &Option{Count: 1, Until: "done", Timeout: "10ms"},
+				timeout: 10 * time.Millisecond,
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				r, err := test.opt.Build(context.FromT(t))
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if got, expect := r.Timeout(), test.timeout; got != expect {
+					t.Errorf("expect %s but got %s", expect, got)
+				}
+			})
+		}
+	})
+	t.Run("ng", func(t *testing.T) {
+		tests := map[string]struct {
+			opt    *Option
+			expect string
+		}{
+			"negative count": {
+				opt:    &Option{Count: -1},
+				expect: ".count: count must be greater than or equal to 0 but got -1",
+			},
+			"invalid until": {
+				opt:    &Option{Until: "{{foo}}"},
+				expect: ".until: invalid until condition",
+			},
+			"invalid timeout": {
+				opt:    &Option{Timeout: "1"},
+				expect: ".timeout: invalid timeout",
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				_, err := test.opt.Build(context.FromT(t))
+				if err == nil {
+					t.Fatal("no error")
+				}
+				if got := err.Error(); !strings.HasPrefix(got, test.expect) {
+					t.Errorf("expect error %q but got %q", test.expect, got)
+				}
+			})
+		}
+	})
+}
+
+func TestReceive(t *testing.T) {
+	stopped := errors.New("stopped")
+	tests := map[string]struct {
+		opt       *Option
+		messages  []string
+		readErr   error
+		expect    []string
+		expectErr string
+	}{
+		"count": {
+			opt:      &Option{Count: 2},
+			messages: []string{"a", "b", "c"},
+			expect:   []string{"a", "b"},
+		},
+		"until": {
+			opt:      &Option{Until: "b"},
+			messages: []string{"a", "b", "c"},
+			expect:   []string{"a", "b"},
+		},
+		"ignore": {
+			opt:      &Option{Count: 2},
+			messages: []string{"a", "", "b", "c"},
+			expect:   []string{"a", "b"},
+		},
+		"stop": {
+			messages: []string{"a", "stop", "b"},
+			expect:   []string{"a"},
+		},
+		"end of stream": {
+			messages: []string{"a"},
+			readErr:  stopped,
+			expect:   []string{"a"},
+		},
+		"timeout": {
+			opt:      &Option{Timeout: "10ms"},
+			messages: []string{"a"},
+			expect:   []string{"a"},
+		},
+		"handle error": {
+			messages:  []string{"a", "error"},
+			expectErr: "invalid message",
+		},
+		"read error": {
+			readErr:   errors.New("closed"),
+			expectErr: "failed to read: closed",
+		},
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			ctx := context.FromT(t)
+			r, err := test.opt.Build(ctx)
+			if err != nil {
+				t.Fatalf("failed to build: %s", err)
+			}
+			received := make(chan string)
+			readErr := make(chan error, 1)
+			done := make(chan struct{})
+			defer close(done)
+			go func() {
+				for _, msg := range test.messages {
+					select {
+					case received <- msg:
+					case <-done:
+						return
+					}
+				}
+				if test.readErr != nil {
+					readErr <- test.readErr
+				}
+			}()
+			var got []string
+			err = Receive(ctx, r, received, readErr,
+				func(msg string) (interface{}, bool, error) {
+					switch msg {
+					case "":
+						return nil, false, nil
+					case "stop":
+						return nil, true, nil
+					case "error":
+						return nil, false, errors.New("invalid message")
+					}
+					got = append(got, msg)
+					return msg, false, nil
+				},
+				func(err error) error {
+					if errors.Is(err, stopped) {
+						return nil
+					}
+					return errors.New("failed to read: " + err.Error())
+				},
+			)
+			if test.expectErr != "" {
+				if err == nil {
+					t.Fatal("no error")
+				}
+				if got := err.Error(); got != test.expectErr {
+					t.Errorf("expect error %q but got %q", test.expectErr, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(test.expect, got); diff != "" {
+				t.Errorf("differs (-want +got):\n%s", diff)
+			}
+		})
+	}
+	t.Run("canceled", func(t *testing.T) {
+		reqCtx, cancel := gocontext.WithCancel(gocontext.Background())
+		cancel()
+		ctx := context.FromT(t).WithRequestContext(reqCtx)
+		r, err := (&Option{}).Build(ctx)
+		if err != nil {
+			t.Fatalf("failed to build: %s", err)
+		}
+		err = Receive(ctx, r, make(chan string), make(chan error),
+			func(string) (interface{}, bool, error) { return nil, false, nil },
+			func(err error) error { return err },
+		)
+		if !errors.Is(err, gocontext.Canceled) {
+			t.Errorf("expect context canceled but got %v", err)
+		}
+	})
+}
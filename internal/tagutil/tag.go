@@ -0,0 +1,116 @@
+// Package tagutil provides utilities to filter scenarios and steps by tags.
+package tagutil
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Expr represents a tag expression.
+// Terms joined by "&" must all match, and a term prefixed with "!" matches if the tag is not specified.
+// For example, "api&!slow" matches tags which contain "api" but don't contain "slow".
+type Expr struct {
+	str   string
+	terms []term
+}
+
+type term struct {
+	tag    string
+	negate bool
+}
+
+// ParseExpr parses a tag expression.
+func ParseExpr(s string) (*Expr, error) {
+	e := &Expr{str: s}
+	for _, t := range strings.Split(s, "&") {
+		t = strings.TrimSpace(t)
+		var negate bool
+		if strings.HasPrefix(t, "!") {
+			negate = true
+			t = strings.TrimSpace(strings.TrimPrefix(t, "!"))
+		}
+		if t == "" {
+			return nil, fmt.Errorf("invalid tag expression %q: empty tag", s)
+		}
+		e.terms = append(e.terms, term{tag: t, negate: negate})
+	}
+	return e, nil
+}
+
+// String returns the expression string.
+func (e *Expr) String() string {
+	return e.str
+}
+
+// Match reports whether the tags satisfy the expression.
+func (e *Expr) Match(tags []string) bool {
+	for _, t := range e.terms {
+		if contains(tags, t.tag) == t.negate {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter selects scenarios and steps by tags.
+type Filter struct {
+	include []*Expr
+	exclude []*Expr
+}
+
+// NewFilter returns a new filter.
+// It matches tags which satisfy any of the include expressions (or all tags if no include expressions are specified)
+// and satisfy none of the exclude expressions.
+// It returns nil if no expressions are specified.
+func NewFilter(include, exclude []string) (*Filter, error) {
+	if len(include) == 0 && len(exclude) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+	f := &Filter{}
+	for _, s := range include {
+		e, err := ParseExpr(s)
+		if err != nil {
+			return nil, err
+		}
+		f.include = append(f.include, e)
+	}
+	for _, s := range exclude {
+		e, err := ParseExpr(s)
+		if err != nil {
+			return nil, err
+		}
+		f.exclude = append(f.exclude, e)
+	}
+	return f, nil
+}
+
+// Match reports whether the tags pass the filter.
+// A nil filter matches any tags.
+func (f *Filter) Match(tags []string) bool {
+	if f == nil {
+		return true
+	}
+	for _, e := range f.exclude {
+		if e.Match(tags) {
+			return false
+		}
+	}
+	if len(f.include) == 0 {
+		return true
+	}
+	for _, e := range f.include {
+		if e.Match(tags) {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
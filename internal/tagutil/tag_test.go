@@ -0,0 +1,139 @@
+package tagutil
+
+import (
+	"testing"
+)
+
+func TestParseExpr(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		tests := map[string]struct {
+			expr   string
+			tags   []string
+			expect bool
+		}{
+			"single": {
+				expr:   "smoke",
+				tags:   []string{"smoke", "api"},
+				expect: true,
+			},
+			"single (not match)": {
+				expr: "smoke",
+				tags: []string{"api"},
+			},
+			"and": {
+				expr:   "smoke & api",
+				tags:   []string{"smoke", "api"},
+				expect: true,
+			},
+			"and (not match)": {
+				expr: "smoke&api",
+				tags: []string{"smoke"},
+			},
+			"negate": {
+				expr:   "api&!slow",
+				tags:   []string{"api"},
+				expect: true,
+			},
+			"negate (not match)": {
+				expr: "api&!slow",
+				tags: []string{"api", "slow"},
+			},
+			"negate only": {
+				expr:   "!slow",
+				expect: true,
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				e, err := ParseExpr(test.expr)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if got := e.Match(test.tags); got != test.expect {
+					t.Errorf("expect %t but got %t", test.expect, got)
+				}
+			})
+		}
+	})
+	t.Run("failure", func(t *testing.T) {
+		tests := map[string]struct {
+			expr   string
+			expect string
+		}{
+			"empty": {
+				expr:   "",
+				expect: `invalid tag expression "": empty tag`,
+			},
+			"empty term": {
+				expr:   "smoke&",
+				expect: `invalid tag expression "smoke&": empty tag`,
+			},
+			"negate only": {
+				expr:   "!",
+				expect: `invalid tag expression "!": empty tag`,
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				_, err := ParseExpr(test.expr)
+				if err == nil {
+					t.Fatal("no error")
+				}
+				if got := err.Error(); got != test.expect {
+					t.Errorf("expect %q but got %q", test.expect, got)
+				}
+			})
+		}
+	})
+}
+
+func TestFilter_Match(t *testing.T) {
+	tests := map[string]struct {
+		include []string
+		exclude []string
+		tags    []string
+		expect  bool
+	}{
+		"no filter": {
+			tags:   []string{"slow"},
+			expect: true,
+		},
+		"include": {
+			include: []string{"smoke", "api"},
+			tags:    []string{"api"},
+			expect:  true,
+		},
+		"include (not match)": {
+			include: []string{"smoke", "api"},
+			tags:    []string{"slow"},
+		},
+		"exclude": {
+			exclude: []string{"slow"},
+			tags:    []string{"api"},
+			expect:  true,
+		},
+		"exclude (not match)": {
+			exclude: []string{"slow"},
+			tags:    []string{"api", "slow"},
+		},
+		"exclude wins": {
+			include: []string{"api"},
+			exclude: []string{"slow"},
+			tags:    []string{"api", "slow"},
+		},
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			f, err := NewFilter(test.include, test.exclude)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got := f.Match(test.tags); got != test.expect {
+				t.Errorf("expect %t but got %t", test.expect, got)
+			}
+		})
+	}
+}
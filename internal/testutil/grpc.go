@@ -31,6 +31,9 @@ func StartTestGRPCServer(t *testing.T, srv testpb.TestServer, optFuncs ...TestGR
 
 	s := grpc.NewServer(serverOpts...)
 	testpb.RegisterTestServer(s, srv)
+	if opts.streamServer != nil {
+		testpb.RegisterStreamTestServer(s, opts.streamServer)
+	}
 	if opts.reflection {
 		reflection.Register(s)
 	}
@@ -56,8 +59,9 @@ func StartTestGRPCServer(t *testing.T, srv testpb.TestServer, optFuncs ...TestGR
 type TestGRPCServerOption func(*grpcServerOpts)
 
 type grpcServerOpts struct {
-	reflection bool
-	tls        *tlsConfig
+	reflection   bool
+	tls          *tlsConfig
+	streamServer testpb.StreamTestServer
 }
 
 type tlsConfig struct {
@@ -80,6 +84,12 @@ func EnableTLS(cert, key string) TestGRPCServerOption {
 	}
 }
 
+func WithStreamTestServer(srv testpb.StreamTestServer) TestGRPCServerOption {
+	return func(opts *grpcServerOpts) {
+		opts.streamServer = srv
+	}
+}
+
 type testServer func(context.Context, *testpb.EchoRequest) (*testpb.EchoResponse, error)
 
 func (f testServer) Echo(ctx context.Context, req *testpb.EchoRequest) (*testpb.EchoResponse, error) {
@@ -0,0 +1,51 @@
+// Package tracing provides OpenTelemetry tracing of scenario execution.
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/zoncoen/scenarigo/reporter"
+)
+
+const instrumentationName = "github.com/zoncoen/scenarigo"
+
+type keyTracerProvider struct{}
+
+// WithTracerProvider returns a copy of ctx which holds tp to start spans.
+func WithTracerProvider(ctx context.Context, tp trace.TracerProvider) context.Context {
+	return context.WithValue(ctx, keyTracerProvider{}, tp)
+}
+
+func tracerProvider(ctx context.Context) trace.TracerProvider {
+	if tp, ok := ctx.Value(keyTracerProvider{}).(trace.TracerProvider); ok && tp != nil {
+		return tp
+	}
+	return noop.NewTracerProvider()
+}
+
+// Start starts a span as a child of the span in ctx.
+// The span doesn't record anything if no tracer provider is set to ctx.
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return tracerProvider(ctx).Tracer(instrumentationName).Start(ctx, name, opts...)
+}
+
+// End ends span with the status by the result of the test.
+func End(span trace.Span, r reporter.Reporter) {
+	span.SetAttributes(attribute.String("scenarigo.result", reporter.TestResultString(r)))
+	if r.Failed() {
+		span.SetStatus(codes.Error, "test failed")
+	}
+	span.End()
+}
+
+// Inject injects the W3C trace context of the span in ctx into carrier.
+// It does nothing if ctx has no valid span.
+func Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	propagation.TraceContext{}.Inject(ctx, carrier)
+}
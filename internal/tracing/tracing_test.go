@@ -0,0 +1,105 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/zoncoen/scenarigo/reporter"
+)
+
+func TestStart(t *testing.T) {
+	t.Run("no tracer provider", func(t *testing.T) {
+		ctx, span := Start(context.Background(), "test")
+		defer span.End()
+		if span.IsRecording() {
+			t.Fatal("span must not be recorded")
+		}
+		header := http.Header{}
+		Inject(ctx, propagation.HeaderCarrier(header))
+		if got := header.Get("Traceparent"); got != "" {
+			t.Fatalf("unexpected traceparent: %s", got)
+		}
+	})
+	t.Run("with tracer provider", func(t *testing.T) {
+		sr := tracetest.NewSpanRecorder()
+		tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+		ctx, parent := Start(WithTracerProvider(context.Background(), tp), "parent")
+		ctx, span := Start(ctx, "child")
+		header := http.Header{}
+		Inject(ctx, propagation.HeaderCarrier(header))
+		span.End()
+		parent.End()
+
+		sc := span.SpanContext()
+		if got, expect := header.Get("Traceparent"), "00-"+sc.TraceID().String()+"-"+sc.SpanID().String()+"-01"; got != expect {
+			t.Errorf("expect %q but got %q", expect, got)
+		}
+		spans := sr.Ended()
+		if got, expect := len(spans), 2; got != expect {
+			t.Fatalf("expect %d spans but got %d", expect, got)
+		}
+		if got, expect := spans[0].Parent().SpanID(), parent.SpanContext().SpanID(); got != expect {
+			t.Errorf("expect parent %s but got %s", expect, got)
+		}
+	})
+}
+
+func TestEnd(t *testing.T) {
+	tests := map[string]struct {
+		f      func(reporter.Reporter)
+		result string
+		code   codes.Code
+	}{
+		"passed": {
+			f:      func(r reporter.Reporter) {},
+			result: "passed",
+			code:   codes.Unset,
+		},
+		"failed": {
+			f:      func(r reporter.Reporter) { r.Fail() },
+			result: "failed",
+			code:   codes.Error,
+		},
+		"skipped": {
+			f:      func(r reporter.Reporter) { r.SkipNow() },
+			result: "skipped",
+			code:   codes.Unset,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			sr := tracetest.NewSpanRecorder()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+			reporter.Run(func(r reporter.Reporter) {
+				r.Run("test", func(r reporter.Reporter) {
+					_, span := Start(WithTracerProvider(context.Background(), tp), "test")
+					defer End(span, r)
+					test.f(r)
+				})
+			}, reporter.WithWriter(&strings.Builder{}))
+			spans := sr.Ended()
+			if got, expect := len(spans), 1; got != expect {
+				t.Fatalf("expect %d spans but got %d", expect, got)
+			}
+			if got, expect := spans[0].Status().Code, test.code; got != expect {
+				t.Errorf("expect status %s but got %s", expect, got)
+			}
+			var result string
+			for _, attr := range spans[0].Attributes() {
+				if attr.Key == "scenarigo.result" {
+					result = attr.Value.AsString()
+				}
+			}
+			if got, expect := result, test.result; got != expect {
+				t.Errorf("expect result %q but got %q", expect, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Package wsutil provides utilities to communicate with WebSocket servers.
+package wsutil
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/zoncoen/scenarigo/context"
+	"github.com/zoncoen/scenarigo/errors"
+	"github.com/zoncoen/scenarigo/internal/reflectutil"
+	"github.com/zoncoen/scenarigo/version"
+)
+
+const closeTimeout = time.Second
+
+var defaultUserAgent = fmt.Sprintf("scenarigo/%s", version.String())
+
+// Frame represents a received data message.
+type Frame struct {
+	Type int
+	Data []byte
+}
+
+// BuildHeader returns the header of the opening handshake from the header field of the request.
+// The values of defaultHeader are added if the request header doesn't have them,
+// and the User-Agent header is set if it is not specified.
+func BuildHeader(ctx *context.Context, v interface{}, defaultHeader http.Header) (http.Header, error) {
+	header := http.Header{}
+	if v != nil {
+		x, err := ctx.ExecuteTemplate(v)
+		if err != nil {
+			return nil, errors.WrapPathf(err, "header", "failed to set header")
+		}
+		hdr, err := reflectutil.ConvertStringsMap(reflect.ValueOf(x))
+		if err != nil {
+			return nil, errors.WrapPathf(err, "header", "failed to set header")
+		}
+		for k, vs := range hdr {
+			for _, v := range vs {
+				header.Add(k, v)
+			}
+		}
+	}
+	for k, vs := range defaultHeader {
+		if len(header.Values(k)) > 0 {
+			continue
+		}
+		for _, v := range vs {
+			header.Add(k, v)
+		}
+	}
+	if header.Get("User-Agent") == "" {
+		header.Set("User-Agent", defaultUserAgent)
+	}
+	return header, nil
+}
+
+// DialOptions represents the options to connect to the WebSocket server.
+type DialOptions struct {
+	Header       http.Header
+	Subprotocols []string
+
+	// Proxy returns the URL of the proxy server for a request.
+	// By default, the proxy is determined by the environment variables such as HTTP_PROXY.
+	Proxy           func(*http.Request) (*url.URL, error)
+	TLSClientConfig *tls.Config
+}
+
+// Dial connects to the WebSocket server.
+func Dial(ctx *context.Context, urlStr string, opts *DialOptions) (*websocket.Conn, *http.Response, error) {
+	if opts == nil {
+		opts = &DialOptions{} //nolint:exhaustruct
+	}
+	proxy := opts.Proxy
+	if proxy == nil {
+		proxy = http.ProxyFromEnvironment
+	}
+	dialer := &websocket.Dialer{ //nolint:exhaustruct
+		Proxy:            proxy,
+		TLSClientConfig:  opts.TLSClientConfig,
+		HandshakeTimeout: websocket.DefaultDialer.HandshakeTimeout,
+		Subprotocols:     opts.Subprotocols,
+	}
+	conn, resp, err := dialer.DialContext(ctx.RequestContext(), urlStr, opts.Header)
+	if err != nil {
+		if resp != nil {
+			return nil, nil, errors.Errorf("failed to connect: %s: %s", err, resp.Status)
+		}
+		return nil, nil, errors.Errorf("failed to connect: %s", err)
+	}
+	return conn, resp, nil
+}
+
+// Read reads the messages from the connection in another goroutine.
+// It sends the error to the error channel and stops reading if it fails to read a message or done is closed.
+func Read(conn *websocket.Conn, done <-chan struct{}) (<-chan Frame, <-chan error) {
+	received := make(chan Frame)
+	readErr := make(chan error, 1)
+	go func() {
+		for {
+			typ, b, err := conn.ReadMessage()
+			if err != nil {
+				readErr <- err
+				return
+			}
+			select {
+			case received <- Frame{Type: typ, Data: b}:
+			case <-done:
+				return
+			}
+		}
+	}()
+	return received, readErr
+}
+
+// Close sends a close frame to the server.
+// It ignores the error because the connection may be already closed by the server.
+func Close(conn *websocket.Conn) {
+	_ = conn.WriteControl(
+		websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+		time.Now().Add(closeTimeout),
+	)
+}
@@ -0,0 +1,123 @@
+package wsutil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/websocket"
+
+	"github.com/zoncoen/scenarigo/context"
+)
+
+func TestBuildHeader(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tests := map[string]struct {
+			vars          interface{}
+			header        interface{}
+			defaultHeader http.Header
+			expect        http.Header
+		}{
+			"default": {
+				expect: http.Header{
+					"User-Agent": {defaultUserAgent},
+				},
+			},
+			"with templates": {
+				vars: map[string]string{"token": "xxx"},
+				header: map[string]interface{}{
+					"Authorization": "Bearer {{vars.token}}",
+					"User-Agent":    "test",
+				},
+				expect: http.Header{
+					"Authorization": {"Bearer xxx"},
+					"User-Agent":    {"test"},
+				},
+			},
+			"with default header": {
+				header: map[string]interface{}{
+					"Authorization": "Bearer xxx",
+				},
+				defaultHeader: http.Header{
+					"Authorization": {"Bearer default"},
+					"X-Tenant":      {"a"},
+					"User-Agent":    {"test"},
+				},
+				expect: http.Header{
+					"Authorization": {"Bearer xxx"},
+					"X-Tenant":      {"a"},
+					"User-Agent":    {"test"},
+				},
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				ctx := context.FromT(t)
+				if test.vars != nil {
+					ctx = ctx.WithVars(test.vars)
+				}
+				header, err := BuildHeader(ctx, test.header, test.defaultHeader)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if diff := cmp.Diff(test.expect, header); diff != "" {
+					t.Errorf("differs (-want +got):\n%s", diff)
+				}
+			})
+		}
+	})
+	t.Run("ng", func(t *testing.T) {
+		_, err := BuildHeader(context.FromT(t), map[string]interface{}{"Authorization": "{{vars.token}}"}, nil)
+		if err == nil {
+			t.Fatal("no error")
+		}
+		if got, expect := err.Error(), ".header"; !strings.HasPrefix(got, expect) {
+			t.Errorf("expect error %q but got %q", expect, got)
+		}
+	})
+}
+
+func TestDialAndRead(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		conn, err := upgrader.Upgrade(w, req, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_ = conn.WriteMessage(websocket.TextMessage, []byte("hello"))
+		_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	}))
+	defer srv.Close()
+
+	ctx := context.FromT(t)
+	t.Run("ok", func(t *testing.T) {
+		conn, _, err := Dial(ctx, "ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+		if err != nil {
+			t.Fatalf("failed to dial: %s", err)
+		}
+		defer conn.Close()
+		done := make(chan struct{})
+		defer close(done)
+		received, readErr := Read(conn, done)
+		f := <-received
+		if diff := cmp.Diff(Frame{Type: websocket.TextMessage, Data: []byte("hello")}, f); diff != "" {
+			t.Errorf("differs (-want +got):\n%s", diff)
+		}
+		if err := <-readErr; !websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+			t.Errorf("expect close error but got %v", err)
+		}
+	})
+	t.Run("ng", func(t *testing.T) {
+		_, _, err := Dial(ctx, srv.URL, nil)
+		if err == nil {
+			t.Fatal("no error")
+		}
+		if got, expect := err.Error(), "failed to connect: malformed ws or wss URL"; got != expect {
+			t.Errorf("expect error %q but got %q", expect, got)
+		}
+	})
+}
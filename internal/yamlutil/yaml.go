@@ -22,3 +22,8 @@ func (msg *RawMessage) UnmarshalYAML(bytes []byte) error {
 func (msg RawMessage) Unmarshal(v interface{}) error {
 	return yaml.UnmarshalWithOptions([]byte(msg), v, yaml.UseOrderedMap(), yaml.Strict())
 }
+
+// MarshalYAML implements yaml.BytesMarshaler interface.
+func (msg RawMessage) MarshalYAML() ([]byte, error) {
+	return []byte(msg), nil
+}
@@ -0,0 +1,127 @@
+package scenarigo
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/zoncoen/scenarigo/context"
+	"github.com/zoncoen/scenarigo/errors"
+	"github.com/zoncoen/scenarigo/schema"
+)
+
+// runLoop runs the step repeatedly according to the loop.
+// Each iteration is reported as a sub-step, and the result context of the last iteration is returned.
+func runLoop(ctx *context.Context, scenario *schema.Scenario, step *schema.Step, path string) *context.Context {
+	if step.Loop.ForEach != nil {
+		return runForEach(ctx, scenario, step, path)
+	}
+	return runUntil(ctx, scenario, step, path)
+}
+
+func runForEach(ctx *context.Context, scenario *schema.Scenario, step *schema.Step, path string) *context.Context {
+	x, err := ctx.ExecuteTemplate(step.Loop.ForEach)
+	if err != nil {
+		ctx.Reporter().Fatal(
+			errors.WithNodeAndColored(
+				errors.WrapPath(
+					err,
+					path+".loop.forEach",
+					"invalid forEach",
+				),
+				ctx.Node(),
+				ctx.EnabledColor(),
+			),
+		)
+	}
+	items := reflect.ValueOf(x)
+	if items.Kind() != reflect.Slice && items.Kind() != reflect.Array {
+		ctx.Reporter().Fatal(
+			errors.WithNodeAndColored(
+				errors.ErrorPathf(
+					path+".loop.forEach",
+					"must be a list but got %T", x,
+				),
+				ctx.Node(),
+				ctx.EnabledColor(),
+			),
+		)
+	}
+	result := ctx
+	for i := range items.Len() {
+		result = runIteration(ctx, scenario, step, path, i, map[string]any{
+			step.Loop.ItemName(): items.Index(i).Interface(),
+		})
+	}
+	return result
+}
+
+func runUntil(ctx *context.Context, scenario *schema.Scenario, step *schema.Step, path string) *context.Context {
+	maxIterations := step.Loop.MaxIterationCount()
+	for i := 0; maxIterations == 0 || i < maxIterations; i++ {
+		if i > 0 {
+			select {
+			case <-time.After(step.Loop.IntervalDuration()):
+			case <-ctx.RequestContext().Done():
+				ctx.Reporter().Fatal(
+					errors.WithNodeAndColored(
+						errors.ErrorPathf(
+							path+".loop.until",
+							"loop canceled: %s", ctx.RequestContext().Err(),
+						),
+						ctx.Node(),
+						ctx.EnabledColor(),
+					),
+				)
+			}
+		}
+		result := runIteration(ctx, scenario, step, path, i, nil)
+		done, err := executeIf(result, step.Loop.Until)
+		if err != nil {
+			ctx.Reporter().Fatal(
+				errors.WithNodeAndColored(
+					errors.WithPath(err, path+".loop.until"),
+					ctx.Node(),
+					ctx.EnabledColor(),
+				),
+			)
+		}
+		if done {
+			return result
+		}
+	}
+	ctx.Reporter().Fatal(
+		errors.WithNodeAndColored(
+			errors.ErrorPathf(
+				path+".loop.until",
+				"condition is not satisfied after %d iterations", maxIterations,
+			),
+			ctx.Node(),
+			ctx.EnabledColor(),
+		),
+	)
+	return ctx
+}
+
+// runIteration runs a copy of the step as a sub-step.
+// It stops the loop if the iteration failed.
+func runIteration(ctx *context.Context, scenario *schema.Scenario, step *schema.Step, path string, i int, vars map[string]any) *context.Context {
+	result := ctx
+	name := fmt.Sprintf("[%d]", i)
+	ok := ctx.Run(name, func(ctx *context.Context) {
+		ctx = withSnapshotName(ctx, name)
+		stp, err := step.Clone()
+		if err != nil {
+			ctx.Reporter().Fatalf("failed to copy step: %s", err)
+		}
+		if vars != nil {
+			ctx = ctx.WithVars(vars)
+		}
+		result = runStepWithTimeout(ctx, scenario, stp, path)
+	})
+	if !ok {
+		ctx.Reporter().FailNow()
+	}
+	// the reporter of the iteration has already finished
+	return result.WithReporter(ctx.Reporter())
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/zoncoen/scenarigo/logger"
 	"github.com/zoncoen/scenarigo/mock/protocol"
+	grpcprotocol "github.com/zoncoen/scenarigo/protocol/grpc"
 	"github.com/zoncoen/scenarigo/protocol/grpc/proto"
 )
 
@@ -64,10 +65,59 @@ func (_ *GRPC) NewServer(iter *protocol.MockIterator, l logger.Logger, config in
 	return srv, nil
 }
 
+// NewProxyServer implements protocol.ProxyProtocol interface.
+// If no proto files are specified, the service descriptors are resolved by the upstream server's reflection service.
+func (_ *GRPC) NewProxyServer(rec *protocol.Recorder, l logger.Logger, config interface{}) (protocol.Server, error) { //nolint:revive
+	if rec == nil {
+		return nil, errors.New("recorder is nil")
+	}
+	cfg, ok := config.(*ServerConfig)
+	if !ok {
+		return nil, fmt.Errorf("invalid config %T", config)
+	}
+	if cfg == nil || cfg.Upstream == nil || cfg.Upstream.Target == "" {
+		return nil, protocol.ErrNoUpstream
+	}
+	creds, err := cfg.Upstream.Auth.Credentials()
+	if err != nil {
+		return nil, fmt.Errorf("invalid upstream auth: %w", err)
+	}
+	conn, err := grpc.NewClient(cfg.Upstream.Target, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect upstream server: %w", err)
+	}
+	var resolver proto.ServiceDescriptorResolver
+	if len(cfg.Proto.Files) > 0 {
+		fds, err := proto.NewCompiler(cfg.Proto.Imports).Compile(context.Background(), cfg.Proto.Files)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile proto: %w", err)
+		}
+		resolver = fds
+	} else {
+		resolver = proto.NewReflectionClient(context.Background(), conn)
+	}
+	return &server{
+		config:   *cfg,
+		resolver: resolver,
+		proxy: &proxy{
+			conn: conn,
+			rec:  rec,
+			l:    l,
+		},
+	}, nil
+}
+
 // ServerConfig represents a server configuration.
 type ServerConfig struct {
-	Port  int         `yaml:"port,omitempty"`
-	Proto ProtoConfig `yaml:"proto,omitempty"`
+	Port     int             `yaml:"port,omitempty"`
+	Proto    ProtoConfig     `yaml:"proto,omitempty"`
+	Upstream *UpstreamConfig `yaml:"upstream,omitempty"`
+}
+
+// UpstreamConfig represents an upstream server configuration for record mode.
+type UpstreamConfig struct {
+	Target string                   `yaml:"target,omitempty"`
+	Auth   *grpcprotocol.AuthOption `yaml:"auth,omitempty"`
 }
 
 // ProtoConfig represents a proto configuration.
@@ -81,6 +131,7 @@ type server struct {
 	config   ServerConfig
 	iter     *protocol.MockIterator
 	resolver proto.ServiceDescriptorResolver
+	proxy    *proxy
 	addr     string
 	srv      *grpc.Server
 }
@@ -113,6 +164,9 @@ func (s *server) setup() (func() error, error) {
 		return nil, fmt.Errorf("failed to get service descriptor: %w", err)
 	}
 	for _, name := range names {
+		if _, ok := s.srv.GetServiceInfo()[string(name)]; ok {
+			continue
+		}
 		sd, err := s.resolver.ResolveService(name)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get service descriptor: %w", err)
@@ -190,6 +244,9 @@ func (s *server) Stop(ctx context.Context) error {
 	srv := s.srv
 	s.srv = nil
 	srv.GracefulStop() // GracefulStop() calls s.ln.Close()
+	if s.proxy != nil {
+		return s.proxy.conn.Close()
+	}
 	return nil
 }
 
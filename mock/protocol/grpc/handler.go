@@ -4,6 +4,7 @@ import (
 	gocontext "context"
 	"fmt"
 	"math"
+	"reflect"
 	"strconv"
 
 	"google.golang.org/grpc"
@@ -20,7 +21,9 @@ import (
 	"github.com/zoncoen/scenarigo/context"
 	"github.com/zoncoen/scenarigo/errors"
 	"github.com/zoncoen/scenarigo/internal/assertutil"
+	"github.com/zoncoen/scenarigo/internal/reflectutil"
 	"github.com/zoncoen/scenarigo/internal/yamlutil"
+	"github.com/zoncoen/scenarigo/mock/protocol"
 	grpcprotocol "github.com/zoncoen/scenarigo/protocol/grpc"
 )
 
@@ -42,9 +45,13 @@ func (s *server) convertToServicDesc(sd protoreflect.ServiceDescriptor) *grpc.Se
 		// 		},
 		// 	})
 		// } else {
+		handler := s.unaryHandler(sd.FullName(), m)
+		if s.proxy != nil {
+			handler = s.proxy.unaryHandler(sd.FullName(), m)
+		}
 		desc.Methods = append(desc.Methods, grpc.MethodDesc{
 			MethodName: string(m.Name()),
-			Handler:    s.unaryHandler(sd.FullName(), m),
+			Handler:    handler,
 		})
 		// }
 	}
@@ -53,39 +60,67 @@ func (s *server) convertToServicDesc(sd protoreflect.ServiceDescriptor) *grpc.Se
 
 func (s *server) unaryHandler(svcName protoreflect.FullName, method protoreflect.MethodDescriptor) func(srv any, ctx gocontext.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
 	return func(srv any, ctx gocontext.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
-		mock, err := s.iter.Next()
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to get mock: %s", err)
-		}
-
-		if mock.Protocol != "grpc" {
-			return nil, status.Error(codes.Internal, errors.WithPath(fmt.Errorf("received gRPC request but the mock protocol is %q", mock.Protocol), "protocol").Error())
-		}
-
-		var e expect
-		if err := mock.Expect.Unmarshal(&e); err != nil {
-			return nil, status.Error(codes.Internal, errors.WrapPath(err, "expect", "failed to unmarshal").Error())
-		}
-		assertion, err := e.build(context.New(nil))
-		if err != nil {
-			return nil, status.Error(codes.Internal, errors.WrapPath(err, "expect", "failed to build assretion").Error())
+		var (
+			req     proto.Message
+			decoded bool
+			decErr  error
+		)
+		decode := func() (proto.Message, error) {
+			if !decoded {
+				decoded = true
+				msg := dynamicpb.NewMessage(method.Input())
+				if err := dec(msg); err != nil {
+					decErr = status.Error(codes.Internal, errors.WrapPath(err, "expect.message", "failed to decode message").Error())
+				} else {
+					req = msg
+				}
+			}
+			return req, decErr
 		}
 
 		var md metadata.MD
 		if got, ok := metadata.FromIncomingContext(ctx); ok {
 			md = got
 		}
-		req := dynamicpb.NewMessage(method.Input())
-		if err := dec(req); err != nil {
-			return nil, status.Error(codes.Internal, errors.WrapPath(err, "expect.message", "failed to decode message").Error())
-		}
-		if err := assertion.Assert(&request{
-			service:  string(svcName),
-			method:   string(method.Name()),
-			metadata: yamlutil.NewMDMarshaler(md),
-			message:  req,
-		}); err != nil {
-			return nil, status.Error(codes.InvalidArgument, errors.WrapPath(err, "expect", "request assertion failed").Error())
+
+		mock, err := s.iter.Match("grpc", func(mock *protocol.Mock) error {
+			if mock.Protocol != "grpc" {
+				return status.Error(codes.Internal, errors.WithPath(fmt.Errorf("received gRPC request but the mock protocol is %q", mock.Protocol), "protocol").Error())
+			}
+
+			var e expect
+			if err := mock.Expect.Unmarshal(&e); err != nil {
+				return status.Error(codes.Internal, errors.WrapPath(err, "expect", "failed to unmarshal").Error())
+			}
+			assertion, err := e.build(context.New(nil))
+			if err != nil {
+				return status.Error(codes.Internal, errors.WrapPath(err, "expect", "failed to build assretion").Error())
+			}
+
+			req, err := decode()
+			if err != nil {
+				return err
+			}
+			if err := assertion.Assert(&request{
+				service:  string(svcName),
+				method:   string(method.Name()),
+				metadata: yamlutil.NewMDMarshaler(md),
+				message:  req,
+			}); err != nil {
+				return status.Error(codes.InvalidArgument, errors.WrapPath(err, "expect", "request assertion failed").Error())
+			}
+			return nil
+		})
+		if err != nil {
+			if _, ok := status.FromError(err); ok {
+				return nil, err
+			}
+			code := codes.Internal
+			var nmErr *protocol.NoMatchError
+			if errors.As(err, &nmErr) {
+				code = codes.InvalidArgument
+			}
+			return nil, status.Errorf(code, "failed to get mock: %s", err)
 		}
 
 		var resp Response
@@ -107,6 +142,9 @@ func (s *server) unaryHandler(svcName protoreflect.FullName, method protoreflect
 		if err != nil {
 			return nil, status.Error(codes.Internal, errors.WithPath(err, "response").Error())
 		}
+		if err := resp.setMetadata(ctx); err != nil {
+			return nil, status.Error(codes.Internal, errors.WithPath(err, "response").Error())
+		}
 		return msg, serr.Err()
 	}
 }
@@ -206,6 +244,45 @@ func (resp *Response) extract(msg proto.Message) (proto.Message, *status.Status,
 	return msg, nil, nil
 }
 
+// setMetadata sets the header and trailer of the response.
+func (resp *Response) setMetadata(ctx gocontext.Context) error {
+	header, err := convertToMD(resp.Header)
+	if err != nil {
+		return errors.WithPath(err, "header")
+	}
+	if len(header) > 0 {
+		if err := grpc.SetHeader(ctx, header); err != nil {
+			return errors.Errorf("failed to set header: %s", err)
+		}
+	}
+	trailer, err := convertToMD(resp.Trailer)
+	if err != nil {
+		return errors.WithPath(err, "trailer")
+	}
+	if len(trailer) > 0 {
+		if err := grpc.SetTrailer(ctx, trailer); err != nil {
+			return errors.Errorf("failed to set trailer: %s", err)
+		}
+	}
+	return nil
+}
+
+func convertToMD(s yaml.MapSlice) (metadata.MD, error) {
+	md := metadata.MD{}
+	for _, item := range s {
+		key, err := reflectutil.ConvertString(reflect.ValueOf(item.Key))
+		if err != nil {
+			return nil, errors.Errorf("expected key is string but got %T", item.Key)
+		}
+		vs, err := reflectutil.ConvertStrings(reflect.ValueOf(item.Value))
+		if err != nil {
+			return nil, errors.WithPath(err, key)
+		}
+		md.Append(key, vs...)
+	}
+	return md, nil
+}
+
 func strToCode(s string) (codes.Code, error) {
 	switch s {
 	case "OK":
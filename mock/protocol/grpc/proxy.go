@@ -0,0 +1,124 @@
+package grpc
+
+import (
+	gocontext "context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/zoncoen/scenarigo/errors"
+	"github.com/zoncoen/scenarigo/internal/yamlutil"
+	"github.com/zoncoen/scenarigo/logger"
+	"github.com/zoncoen/scenarigo/mock/protocol"
+	grpcprotocol "github.com/zoncoen/scenarigo/protocol/grpc"
+)
+
+type proxy struct {
+	conn *grpc.ClientConn
+	rec  *protocol.Recorder
+	l    logger.Logger
+}
+
+type recordedExpect struct {
+	Service string                                  `yaml:"service"`
+	Method  string                                  `yaml:"method"`
+	Message *grpcprotocol.ProtoMessageYAMLMarshaler `yaml:"message,omitempty"`
+}
+
+type recordedResponse struct {
+	Status  recordedStatus                          `yaml:"status"`
+	Header  *yamlutil.MDMarshaler                   `yaml:"header,omitempty"`
+	Trailer *yamlutil.MDMarshaler                   `yaml:"trailer,omitempty"`
+	Message *grpcprotocol.ProtoMessageYAMLMarshaler `yaml:"message,omitempty"`
+}
+
+type recordedStatus struct {
+	Code    string `yaml:"code"`
+	Message string `yaml:"message,omitempty"`
+}
+
+// recordedMetadata returns the metadata to record without the headers set by the transport.
+// The binary values which are not valid UTF-8 strings are recorded as hex strings.
+func recordedMetadata(md metadata.MD) *yamlutil.MDMarshaler {
+	recorded := metadata.MD{}
+	for k, vs := range md {
+		if strings.HasPrefix(k, ":") || k == "content-type" {
+			continue
+		}
+		recorded[k] = vs
+	}
+	if len(recorded) == 0 {
+		return nil
+	}
+	return yamlutil.NewMDMarshaler(recorded)
+}
+
+func (p *proxy) unaryHandler(svcName protoreflect.FullName, method protoreflect.MethodDescriptor) func(srv any, ctx gocontext.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	fullMethodName := fmt.Sprintf("/%s/%s", svcName, method.Name())
+	return func(srv any, ctx gocontext.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+		req := dynamicpb.NewMessage(method.Input())
+		if err := dec(req); err != nil {
+			return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to decode message").Error())
+		}
+
+		md := metadata.MD{}
+		if got, ok := metadata.FromIncomingContext(ctx); ok {
+			for k, vs := range got {
+				// Pseudo-headers and the headers set by the transport must not be forwarded.
+				if strings.HasPrefix(k, ":") || k == "content-type" || k == "user-agent" {
+					continue
+				}
+				md[k] = vs
+			}
+		}
+
+		var header, trailer metadata.MD
+		resp := dynamicpb.NewMessage(method.Output())
+		err := p.conn.Invoke(metadata.NewOutgoingContext(ctx, md), fullMethodName, req, resp, grpc.Header(&header), grpc.Trailer(&trailer))
+		sts := status.Convert(err)
+
+		recorded := &recordedResponse{
+			Status: recordedStatus{
+				Code: sts.Code().String(),
+			},
+		}
+		recorded.Header = recordedMetadata(header)
+		recorded.Trailer = recordedMetadata(trailer)
+		if err != nil {
+			recorded.Status.Message = sts.Message()
+		} else {
+			recorded.Message = &grpcprotocol.ProtoMessageYAMLMarshaler{Message: resp}
+		}
+		if err := p.rec.Record("grpc",
+			&recordedExpect{
+				Service: string(svcName),
+				Method:  string(method.Name()),
+				Message: &grpcprotocol.ProtoMessageYAMLMarshaler{Message: req},
+			},
+			recorded,
+		); err != nil {
+			p.l.Error(err, "failed to record the exchange")
+		}
+
+		if len(header) > 0 {
+			if err := grpc.SetHeader(ctx, header); err != nil {
+				p.l.Error(err, "failed to set header")
+			}
+		}
+		if len(trailer) > 0 {
+			if err := grpc.SetTrailer(ctx, trailer); err != nil {
+				p.l.Error(err, "failed to set trailer")
+			}
+		}
+		if err != nil {
+			return nil, sts.Err()
+		}
+		return resp, nil
+	}
+}
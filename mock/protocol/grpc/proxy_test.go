@@ -0,0 +1,184 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/zoncoen/scenarigo/internal/testutil"
+	"github.com/zoncoen/scenarigo/logger"
+	"github.com/zoncoen/scenarigo/mock/protocol"
+	testpb "github.com/zoncoen/scenarigo/testdata/gen/pb/test"
+)
+
+func TestGRPC_ProxyServer(t *testing.T) {
+	tests := map[string]struct {
+		reflection bool
+		config     string
+		upstream   func(context.Context, *testpb.EchoRequest) (*testpb.EchoResponse, error)
+		f          func(*testing.T, string)
+	}{
+		"proto files": {
+			config: `
+proto:
+  files:
+  - ./testdata/test.proto
+upstream:
+  target: %s
+  auth:
+    insecure: true
+`,
+			upstream: func(_ context.Context, req *testpb.EchoRequest) (*testpb.EchoResponse, error) {
+				return &testpb.EchoResponse{
+					MessageId:   req.GetMessageId(),
+					MessageBody: req.GetMessageBody(),
+				}, nil
+			},
+			f: sendEchoRequest(nil, "1", "hello"),
+		},
+		"reflection": {
+			reflection: true,
+			config: `
+upstream:
+  target: %s
+  auth:
+    insecure: true
+`,
+			upstream: func(_ context.Context, req *testpb.EchoRequest) (*testpb.EchoResponse, error) {
+				return &testpb.EchoResponse{
+					MessageId:   req.GetMessageId(),
+					MessageBody: req.GetMessageBody(),
+				}, nil
+			},
+			f: sendEchoRequest(nil, "1", "hello"),
+		},
+		"metadata": {
+			config: `
+proto:
+  files:
+  - ./testdata/test.proto
+upstream:
+  target: %s
+  auth:
+    insecure: true
+`,
+			upstream: func(ctx context.Context, req *testpb.EchoRequest) (*testpb.EchoResponse, error) {
+				if err := grpc.SetHeader(ctx, metadata.Pairs("x-request-id", "1")); err != nil {
+					return nil, err
+				}
+				if err := grpc.SetTrailer(ctx, metadata.Pairs("x-elapsed", "10ms", "x-elapsed", "20ms")); err != nil {
+					return nil, err
+				}
+				return nil, status.Error(codes.NotFound, "not found")
+			},
+			f: func(t *testing.T, addr string) {
+				t.Helper()
+				c, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				if err != nil {
+					t.Fatalf("failed to connect server: %s", err)
+				}
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+				defer cancel()
+				var header, trailer metadata.MD
+				_, err = testpb.NewTestClient(c).Echo(ctx, &testpb.EchoRequest{}, grpc.Header(&header), grpc.Trailer(&trailer))
+				if got, expect := status.Code(err), codes.NotFound; got != expect {
+					t.Errorf("expect status code %s but got %s", expect, got)
+				}
+				if diff := cmp.Diff([]string{"1"}, header.Get("x-request-id")); diff != "" {
+					t.Errorf("header differs (-want +got):\n%s", diff)
+				}
+				if diff := cmp.Diff([]string{"10ms", "20ms"}, trailer.Get("x-elapsed")); diff != "" {
+					t.Errorf("trailer differs (-want +got):\n%s", diff)
+				}
+			},
+		},
+		"error status": {
+			config: `
+proto:
+  files:
+  - ./testdata/test.proto
+upstream:
+  target: %s
+  auth:
+    insecure: true
+`,
+			upstream: func(_ context.Context, req *testpb.EchoRequest) (*testpb.EchoResponse, error) {
+				return nil, status.Error(codes.PermissionDenied, "denied")
+			},
+			f: sendEchoRequest(status.New(codes.PermissionDenied, "denied"), "", ""),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var opts []testutil.TestGRPCServerOption
+			if test.reflection {
+				opts = append(opts, testutil.EnableReflection())
+			}
+			target := testutil.StartTestGRPCServer(t, testutil.TestGRPCServerFunc(test.upstream), opts...)
+
+			p := &GRPC{}
+			cfg, err := p.UnmarshalConfig([]byte(fmt.Sprintf(test.config, target)))
+			if err != nil {
+				t.Fatalf("failed to unmarshal config: %s", err)
+			}
+			rec := protocol.NewRecorder()
+			srv, err := p.NewProxyServer(rec, logger.NewNopLogger(), cfg)
+			if err != nil {
+				t.Fatalf("failed to create server: %s", err)
+			}
+			startServer(t, srv, test.f)
+
+			// replay the recorded mocks
+			iter := protocol.NewMockIterator(rec.Mocks())
+			cfg, err = p.UnmarshalConfig([]byte("proto:\n  files:\n  - ./testdata/test.proto"))
+			if err != nil {
+				t.Fatalf("failed to unmarshal config: %s", err)
+			}
+			srv, err = p.NewServer(iter, logger.NewNopLogger(), cfg)
+			if err != nil {
+				t.Fatalf("failed to create server: %s", err)
+			}
+			startServer(t, srv, test.f)
+			if err := iter.Stop(); err != nil {
+				t.Errorf("failed to stop mock iterator: %s", err)
+			}
+		})
+	}
+
+	t.Run("no upstream", func(t *testing.T) {
+		if _, err := (&GRPC{}).NewProxyServer(protocol.NewRecorder(), logger.NewNopLogger(), &ServerConfig{}); !errors.Is(err, protocol.ErrNoUpstream) {
+			t.Fatalf("expect %s but got %v", protocol.ErrNoUpstream, err)
+		}
+	})
+}
+
+func startServer(t *testing.T, srv protocol.Server, f func(*testing.T, string)) {
+	t.Helper()
+	go func() {
+		if err := srv.Start(context.Background()); err != nil {
+			t.Errorf("failed to start server: %s", err)
+		}
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := srv.Wait(ctx); err != nil {
+		t.Fatalf("failed to start server: %s", err)
+	}
+	addr, err := srv.Addr()
+	if err != nil {
+		t.Fatalf("failed to get address: %s", err)
+	}
+	f(t, addr)
+	if err := srv.Stop(ctx); err != nil {
+		t.Fatalf("failed to stop server: %s", err)
+	}
+}
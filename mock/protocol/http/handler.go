@@ -25,28 +25,6 @@ import (
 func NewHandler(iter *protocol.MockIterator, l logger.Logger) http.Handler {
 	ctx := context.New(nil)
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		mock, err := iter.Next()
-		if err != nil {
-			writeError(w, err, l)
-			return
-		}
-		if mock.Protocol != "http" {
-			err := fmt.Errorf("received HTTP request but the mock protocol is %q", mock.Protocol)
-			writeError(w, err, l)
-			return
-		}
-
-		var e expect
-		if err := mock.Expect.Unmarshal(&e); err != nil {
-			writeError(w, fmt.Errorf("failed to unmarshal expect: %w", err), l)
-			return
-		}
-		assertion, err := e.build(ctx)
-		if err != nil {
-			writeError(w, fmt.Errorf("failed to build assertion: %w", err), l)
-			return
-		}
-
 		b, err := io.ReadAll(r.Body)
 		if err != nil {
 			writeError(w, fmt.Errorf("failed to read request body: %w", err), l)
@@ -69,12 +47,30 @@ func NewHandler(iter *protocol.MockIterator, l logger.Logger) http.Handler {
 			"body":   body,
 		})
 
-		if err := assertion.Assert(&request{
-			path:   r.URL.Path,
-			header: r.Header,
-			body:   body,
-		}); err != nil {
-			writeError(w, fmt.Errorf("assertion error: %w", err), l)
+		mock, err := iter.Match("http", func(mock *protocol.Mock) error {
+			if mock.Protocol != "http" {
+				return fmt.Errorf("received HTTP request but the mock protocol is %q", mock.Protocol)
+			}
+			var e expect
+			if err := mock.Expect.Unmarshal(&e); err != nil {
+				return fmt.Errorf("failed to unmarshal expect: %w", err)
+			}
+			assertion, err := e.build(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to build assertion: %w", err)
+			}
+			if err := assertion.Assert(&request{
+				method: r.Method,
+				path:   r.URL.Path,
+				header: r.Header,
+				body:   body,
+			}); err != nil {
+				return fmt.Errorf("assertion error: %w", err)
+			}
+			return nil
+		})
+		if err != nil {
+			writeError(w, err, l)
 			return
 		}
 
@@ -110,23 +106,32 @@ func writeError(w http.ResponseWriter, err error, l logger.Logger) {
 }
 
 type request struct {
+	method string
 	path   string
 	header http.Header
 	body   interface{}
 }
 
 type expect struct {
+	Method *string       `yaml:"method"`
 	Path   *string       `yaml:"path"`
 	Header yaml.MapSlice `yaml:"header"`
 	Body   interface{}   `yaml:"body"`
 }
 
 func (e *expect) build(ctx *context.Context) (assert.Assertion, error) {
-	var pathAssertion assert.Assertion = assert.AssertionFunc(func(_ interface{}) error {
-		return nil
-	})
+	var (
+		methodAssertion = assert.Nop()
+		pathAssertion   = assert.Nop()
+		err             error
+	)
+	if e.Method != nil {
+		methodAssertion, err = assert.Build(ctx.RequestContext(), *e.Method, assert.FromTemplate(ctx))
+		if err != nil {
+			return nil, errors.WrapPathf(err, "method", "invalid expect method")
+		}
+	}
 	if e.Path != nil {
-		var err error
 		pathAssertion, err = assert.Build(ctx.RequestContext(), *e.Path, assert.FromTemplate(ctx))
 		if err != nil {
 			return nil, errors.WrapPathf(err, "path", "invalid expect path")
@@ -148,6 +153,9 @@ func (e *expect) build(ctx *context.Context) (assert.Assertion, error) {
 		if !ok {
 			return errors.Errorf("expected request but got %T", v)
 		}
+		if err := methodAssertion.Assert(req.method); err != nil {
+			return errors.WithPath(err, "method")
+		}
 		if err := pathAssertion.Assert(req.path); err != nil {
 			return errors.WithPath(err, "path")
 		}
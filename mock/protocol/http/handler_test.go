@@ -27,6 +27,7 @@ func TestHandler(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		tests := map[string]struct {
 			filename string
+			mode     protocol.Mode
 			steps    []step
 		}{
 			"http": {
@@ -63,6 +64,80 @@ func TestHandler(t *testing.T) {
 					},
 				},
 			},
+			"match mode": {
+				filename: "testdata/http-match.yaml",
+				mode:     protocol.ModeMatch,
+				steps: []step{
+					{
+						request: func() *http.Request {
+							return httptest.NewRequest(http.MethodGet, "/items", nil)
+						},
+						expect: &expect{
+							code: 200,
+							header: http.Header{
+								"Content-Type": []string{"application/json"},
+							},
+							body: `{"items": []}`,
+						},
+					},
+					{
+						request: func() *http.Request {
+							return httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader(`{"message":"bye"}`))
+						},
+						expect: &expect{
+							code: 400,
+							header: http.Header{
+								"Content-Type": []string{"application/json"},
+							},
+							body: `{"message": "bad request"}`,
+						},
+					},
+					{
+						request: func() *http.Request {
+							return httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader(`{"message":"hello"}`))
+						},
+						expect: &expect{
+							code: 200,
+							header: http.Header{
+								"Content-Type": []string{"application/json"},
+							},
+							body: `{"message": "hello"}`,
+						},
+					},
+					{
+						request: func() *http.Request {
+							return httptest.NewRequest(http.MethodGet, "/health", nil)
+						},
+						expect: &expect{
+							code:   200,
+							header: http.Header{},
+							body:   ``,
+						},
+					},
+					{
+						request: func() *http.Request {
+							return httptest.NewRequest(http.MethodGet, "/items", nil)
+						},
+						expect: &expect{
+							code: 200,
+							header: http.Header{
+								"Content-Type": []string{"application/json"},
+							},
+							body: `{"items": []}`,
+						},
+					},
+					{
+						request: func() *http.Request {
+							return httptest.NewRequest(http.MethodGet, "/health", nil)
+						},
+						expect: &expect{
+							code:   200,
+							header: http.Header{},
+							body:   ``,
+						},
+					},
+				},
+			},
 		}
 		for name, test := range tests {
 			test := test
@@ -76,7 +151,7 @@ func TestHandler(t *testing.T) {
 				if err := yaml.NewDecoder(f).Decode(&mocks); err != nil {
 					t.Fatal(err)
 				}
-				iter := protocol.NewMockIterator(mocks)
+				iter := protocol.NewMockIterator(mocks, protocol.WithMode(test.mode))
 				h := NewHandler(iter, logger.NewNopLogger())
 				for _, step := range test.steps {
 					rec := httptest.NewRecorder()
@@ -100,6 +175,7 @@ func TestHandler(t *testing.T) {
 	t.Run("failure", func(t *testing.T) {
 		tests := map[string]struct {
 			filename string
+			mode     protocol.Mode
 			steps    []step
 		}{
 			"invalid protocol": {
@@ -201,6 +277,25 @@ func TestHandler(t *testing.T) {
 					},
 				},
 			},
+			"no mocks match": {
+				filename: "testdata/http-no-match.yaml",
+				mode:     protocol.ModeMatch,
+				steps: []step{
+					{
+						request: func() *http.Request {
+							return httptest.NewRequest(http.MethodDelete, "/items", nil)
+						},
+						expect: &expect{
+							code: 500,
+							header: http.Header{
+								"Content-Type": []string{"text/plain; charset=utf-8"},
+							},
+							body: `no mocks match the request:
+mocks[1]: assertion error: .method: expected "GET" but got "DELETE"`,
+						},
+					},
+				},
+			},
 		}
 		for name, test := range tests {
 			test := test
@@ -214,7 +309,7 @@ func TestHandler(t *testing.T) {
 				if err := yaml.NewDecoder(f).Decode(&mocks); err != nil {
 					t.Fatal(err)
 				}
-				iter := protocol.NewMockIterator(mocks)
+				iter := protocol.NewMockIterator(mocks, protocol.WithMode(test.mode))
 				h := NewHandler(iter, logger.NewNopLogger())
 				for _, step := range test.steps {
 					rec := httptest.NewRecorder()
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"net/url"
 	"sync"
 	"time"
 
@@ -55,9 +56,37 @@ func (_ *HTTP) NewServer(iter *protocol.MockIterator, l logger.Logger, config in
 	return srv, nil
 }
 
+// NewProxyServer implements protocol.ProxyProtocol interface.
+func (_ *HTTP) NewProxyServer(rec *protocol.Recorder, l logger.Logger, config interface{}) (protocol.Server, error) { //nolint:revive
+	if rec == nil {
+		return nil, errors.New("recorder is nil")
+	}
+	cfg, ok := config.(*ServerConfig)
+	if !ok {
+		return nil, fmt.Errorf("invalid config %T", config)
+	}
+	if cfg == nil || cfg.Upstream == nil || cfg.Upstream.URL == "" {
+		return nil, protocol.ErrNoUpstream
+	}
+	u, err := url.Parse(cfg.Upstream.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid upstream URL: %w", err)
+	}
+	return &server{
+		handler: NewProxyHandler(u, rec, l),
+		config:  *cfg,
+	}, nil
+}
+
 // ServerConfig represents a server configuration.
 type ServerConfig struct {
-	Port int `yaml:"port,omitempty"`
+	Port     int             `yaml:"port,omitempty"`
+	Upstream *UpstreamConfig `yaml:"upstream,omitempty"`
+}
+
+// UpstreamConfig represents an upstream server configuration for record mode.
+type UpstreamConfig struct {
+	URL string `yaml:"url,omitempty"`
 }
 
 type server struct {
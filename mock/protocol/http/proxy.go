@@ -0,0 +1,143 @@
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/zoncoen/scenarigo/logger"
+	"github.com/zoncoen/scenarigo/mock/protocol"
+)
+
+// ignoredHeaders are not recorded because they depend on the connection or will be set on replay.
+var ignoredHeaders = map[string]struct{}{
+	"Connection":        {},
+	"Content-Length":    {},
+	"Date":              {},
+	"Keep-Alive":        {},
+	"Transfer-Encoding": {},
+}
+
+type recordedExpect struct {
+	Method string      `yaml:"method"`
+	Path   string      `yaml:"path"`
+	Body   interface{} `yaml:"body,omitempty"`
+}
+
+type recordedResponse struct {
+	Code   string        `yaml:"code"`
+	Header yaml.MapSlice `yaml:"header,omitempty"`
+	Body   interface{}   `yaml:"body,omitempty"`
+}
+
+// NewProxyHandler returns a handler that proxies requests to the upstream server and records the exchanges.
+func NewProxyHandler(upstream *url.URL, rec *protocol.Recorder, l logger.Logger) http.Handler {
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reqBody, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, fmt.Errorf("failed to read request body: %w", err), l)
+			return
+		}
+
+		u := *upstream
+		u.Path = strings.TrimSuffix(upstream.Path, "/") + r.URL.Path
+		u.RawQuery = r.URL.RawQuery
+		req, err := http.NewRequestWithContext(r.Context(), r.Method, u.String(), bytes.NewReader(reqBody))
+		if err != nil {
+			writeError(w, fmt.Errorf("failed to create upstream request: %w", err), l)
+			return
+		}
+		req.Header = r.Header.Clone()
+		// Let the transport decompress the response body to record it as plain data.
+		req.Header.Del("Accept-Encoding")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			w.Header().Add("Content-Type", "text/plain; charset=utf-8")
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = w.Write([]byte(err.Error()))
+			l.Error(err, "failed to send request to the upstream server")
+			return
+		}
+		defer resp.Body.Close()
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			writeError(w, fmt.Errorf("failed to read upstream response body: %w", err), l)
+			return
+		}
+
+		if err := rec.Record("http",
+			&recordedExpect{
+				Method: r.Method,
+				Path:   r.URL.Path,
+				Body:   decodeBody(r.Header.Get("Content-Type"), reqBody),
+			},
+			&recordedResponse{
+				Code:   strconv.Itoa(resp.StatusCode),
+				Header: recordHeader(resp.Header),
+				Body:   decodeBody(resp.Header.Get("Content-Type"), respBody),
+			},
+		); err != nil {
+			l.Error(err, "failed to record the exchange")
+		}
+
+		for k, vs := range resp.Header {
+			for _, v := range vs {
+				w.Header().Add(k, v)
+			}
+		}
+		w.WriteHeader(resp.StatusCode)
+		if _, err := w.Write(respBody); err != nil {
+			l.Error(err, "failed to write response")
+		}
+	})
+}
+
+func recordHeader(h http.Header) yaml.MapSlice {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		if _, ok := ignoredHeaders[k]; ok {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	header := make(yaml.MapSlice, 0, len(keys))
+	for _, k := range keys {
+		var v interface{} = h[k]
+		if len(h[k]) == 1 {
+			v = h[k][0]
+		}
+		header = append(header, yaml.MapItem{Key: k, Value: v})
+	}
+	return header
+}
+
+// decodeBody decodes the JSON body to keep the structure in the recorded mock.
+// Other bodies are recorded as strings.
+func decodeBody(contentType string, b []byte) interface{} {
+	if len(b) == 0 {
+		return nil
+	}
+	mt, _, _ := mime.ParseMediaType(contentType)
+	if contentType == "" || mt == "application/json" || strings.HasSuffix(mt, "+json") {
+		var v interface{}
+		if err := yaml.UnmarshalWithOptions(b, &v, yaml.UseOrderedMap()); err == nil {
+			return v
+		}
+	}
+	return string(b)
+}
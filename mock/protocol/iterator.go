@@ -3,30 +3,125 @@ package protocol
 import (
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/zoncoen/scenarigo/internal/yamlutil"
 )
 
+// Mode represents how the mock server selects a mock for an incoming request.
+type Mode string
+
+const (
+	// ModeSequential returns mocks strictly in the order of definition.
+	ModeSequential Mode = "sequential"
+	// ModeMatch returns the first mock that matches the incoming request.
+	// Mocks are evaluated in descending order of priority, and in the order of definition if the priorities are equal.
+	ModeMatch Mode = "match"
+	// ModeRecord proxies requests to the upstream servers and records the exchanges as mocks.
+	ModeRecord Mode = "record"
+)
+
+// Validate returns an error if the mode is unknown.
+func (m Mode) Validate() error {
+	switch m {
+	case "", ModeSequential, ModeMatch, ModeRecord:
+		return nil
+	}
+	return fmt.Errorf("unknown mode %q", m)
+}
+
 // Mock represents a mock.
 type Mock struct {
 	Protocol string              `yaml:"protocol"`
 	Expect   yamlutil.RawMessage `yaml:"expect"`
 	Response yamlutil.RawMessage `yaml:"response"`
+
+	// Times is the number of times the mock can be used. (default 1)
+	Times *int `yaml:"times,omitempty"`
+	// Unlimited allows the mock to be used any number of times.
+	Unlimited bool `yaml:"unlimited,omitempty"`
+	// Priority is used to determine the order of evaluation in match mode.
+	// The mock with the higher priority is evaluated first.
+	Priority int `yaml:"priority,omitempty"`
+}
+
+// Validate validates the mock.
+func (m *Mock) Validate() error {
+	if m.Times != nil {
+		if m.Unlimited {
+			return errors.New("times and unlimited can't be specified at the same time")
+		}
+		if *m.Times < 1 {
+			return fmt.Errorf("times must be greater than 0 but got %d", *m.Times)
+		}
+	}
+	return nil
+}
+
+type mockEntry struct {
+	index  int
+	mock   Mock
+	remain int
+}
+
+func (e *mockEntry) consume() {
+	if !e.mock.Unlimited {
+		e.remain--
+	}
+}
+
+func (e *mockEntry) consumed() bool {
+	return !e.mock.Unlimited && e.remain <= 0
+}
+
+// MockIteratorOption represents an option for MockIterator.
+type MockIteratorOption func(*MockIterator)
+
+// WithMode returns an option to set the mode of mock selection.
+func WithMode(mode Mode) MockIteratorOption {
+	return func(i *MockIterator) {
+		if mode != "" {
+			i.mode = mode
+		}
+	}
 }
 
 // MockIterator is an iterator over Mocks.
 type MockIterator struct {
-	m     sync.Mutex
-	mocks []Mock
+	m       sync.Mutex
+	mode    Mode
+	entries []*mockEntry
 }
 
 // New returns a new MockIterator.
-func NewMockIterator(mocks []Mock) *MockIterator {
+func NewMockIterator(mocks []Mock, opts ...MockIteratorOption) *MockIterator {
 	//nolint:exhaustruct
-	return &MockIterator{
-		mocks: mocks,
+	i := &MockIterator{
+		mode: ModeSequential,
+	}
+	for _, opt := range opts {
+		opt(i)
 	}
+	i.entries = make([]*mockEntry, len(mocks))
+	for idx, mock := range mocks {
+		remain := 1
+		if mock.Times != nil {
+			remain = *mock.Times
+		}
+		i.entries[idx] = &mockEntry{
+			index:  idx,
+			mock:   mock,
+			remain: remain,
+		}
+	}
+	if i.mode == ModeMatch {
+		sort.SliceStable(i.entries, func(a, b int) bool {
+			return i.entries[a].mock.Priority > i.entries[b].mock.Priority
+		})
+	}
+	return i
 }
 
 // Next returns the next mock.
@@ -37,28 +132,73 @@ func (i *MockIterator) Next() (*Mock, error) {
 }
 
 func (i *MockIterator) next() (*Mock, error) {
-	if len(i.mocks) == 0 {
+	if len(i.entries) == 0 {
 		return nil, errors.New("no mocks remain")
 	}
-	var mock Mock
-	mock, i.mocks = i.mocks[0], i.mocks[1:]
+	e := i.entries[0]
+	e.consume()
+	if e.consumed() {
+		i.entries = i.entries[1:]
+	}
+	mock := e.mock
 	return &mock, nil
 }
 
+// Match returns the mock selected for the incoming request of the protocol.
+// The function f reports whether the mock matches the request by returning nil.
+//
+// In sequential mode, the next mock is always selected and the error returned by f is returned as it is.
+// In match mode, the first mock that f returns nil is selected and mocks for other protocols are skipped.
+// If no mocks match the request, a NoMatchError is returned.
+func (i *MockIterator) Match(protocol string, f func(*Mock) error) (*Mock, error) {
+	i.m.Lock()
+	defer i.m.Unlock()
+
+	if i.mode != ModeMatch {
+		mock, err := i.next()
+		if err != nil {
+			return nil, err
+		}
+		if err := f(mock); err != nil {
+			return nil, err
+		}
+		return mock, nil
+	}
+
+	var errs []error
+	for idx, e := range i.entries {
+		if e.mock.Protocol != protocol {
+			continue
+		}
+		mock := e.mock
+		if err := f(&mock); err != nil {
+			errs = append(errs, fmt.Errorf("mocks[%d]: %w", e.index, err))
+			continue
+		}
+		e.consume()
+		if e.consumed() {
+			i.entries = append(i.entries[:idx:idx], i.entries[idx+1:]...)
+		}
+		return &mock, nil
+	}
+	return nil, &NoMatchError{errs: errs}
+}
+
 // Stop terminates the iteration.
 // It should be called after you finish using the iterator.
 // If mocks not consumed remain returns a MocksRemainError.
+// Mocks that can be used unlimited times are not considered as remaining.
 func (i *MockIterator) Stop() error {
 	i.m.Lock()
 	defer i.m.Unlock()
 
 	var count int
-	for {
-		if _, err := i.next(); err != nil {
-			break
+	for _, e := range i.entries {
+		if !e.mock.Unlimited {
+			count++
 		}
-		count++
 	}
+	i.entries = nil
 
 	if count > 0 {
 		return &MocksRemainError{count: count}
@@ -75,3 +215,20 @@ type MocksRemainError struct {
 func (e *MocksRemainError) Error() string {
 	return fmt.Sprintf("last %d mocks remain", e.count)
 }
+
+// NoMatchError is the error returned by Match when no mocks match the request.
+type NoMatchError struct {
+	errs []error
+}
+
+// Error implements error interface.
+func (e *NoMatchError) Error() string {
+	if len(e.errs) == 0 {
+		return "no mocks match the request"
+	}
+	msgs := make([]string, len(e.errs))
+	for i, err := range e.errs {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("no mocks match the request:\n%s", strings.Join(msgs, "\n"))
+}
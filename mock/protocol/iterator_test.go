@@ -1,10 +1,13 @@
 package protocol
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/goccy/go-yaml"
 	"github.com/google/go-cmp/cmp"
+
+	"github.com/zoncoen/scenarigo/internal/ptr"
 )
 
 func TestMockIterator(t *testing.T) {
@@ -153,3 +156,180 @@ func TestMockIterator(t *testing.T) {
 		})
 	})
 }
+
+func TestMockIterator_Match(t *testing.T) {
+	mocks := func(t *testing.T, s string) []Mock {
+		t.Helper()
+		var mocks []Mock
+		if err := yaml.Unmarshal([]byte(s), &mocks); err != nil {
+			t.Fatalf("failed to unmarshal: %s", err)
+		}
+		return mocks
+	}
+	matchResponse := func(resp string) func(*Mock) error {
+		return func(m *Mock) error {
+			var v string
+			if err := m.Response.Unmarshal(&v); err != nil {
+				return err
+			}
+			if v != resp {
+				return fmt.Errorf("expected %q but got %q", resp, v)
+			}
+			return nil
+		}
+	}
+	matchAny := func(*Mock) error { return nil }
+
+	t.Run("sequential", func(t *testing.T) {
+		iter := NewMockIterator(mocks(t, `
+- protocol: http
+  response: a
+  times: 2
+- protocol: http
+  response: b
+`))
+		for i, expect := range []string{"a", "a", "b"} {
+			if _, err := iter.Match("http", matchResponse(expect)); err != nil {
+				t.Fatalf("[%d] unexpected error: %s", i, err)
+			}
+		}
+		if _, err := iter.Match("http", matchAny); err == nil {
+			t.Fatal("no error")
+		} else if got, expect := err.Error(), "no mocks remain"; got != expect {
+			t.Errorf("expect %q but got %q", expect, got)
+		}
+		if err := iter.Stop(); err != nil {
+			t.Fatalf("failed to stop iterator: %s", err)
+		}
+	})
+	t.Run("sequential returns the error as it is", func(t *testing.T) {
+		iter := NewMockIterator(mocks(t, `
+- protocol: http
+  response: a
+`))
+		if _, err := iter.Match("http", matchResponse("b")); err == nil {
+			t.Fatal("no error")
+		} else if got, expect := err.Error(), `expected "b" but got "a"`; got != expect {
+			t.Errorf("expect %q but got %q", expect, got)
+		}
+		if err := iter.Stop(); err != nil {
+			t.Fatalf("failed to stop iterator: %s", err)
+		}
+	})
+	t.Run("match", func(t *testing.T) {
+		iter := NewMockIterator(mocks(t, `
+- protocol: http
+  response: a
+- protocol: grpc
+  response: b
+- protocol: http
+  response: c
+  times: 2
+- protocol: http
+  response: d
+  unlimited: true
+`), WithMode(ModeMatch))
+		for i, expect := range []string{"c", "d", "a", "c", "d", "d"} {
+			m, err := iter.Match("http", matchResponse(expect))
+			if err != nil {
+				t.Fatalf("[%d] unexpected error: %s", i, err)
+			}
+			if m.Protocol != "http" {
+				t.Fatalf("[%d] unexpected protocol %q", i, m.Protocol)
+			}
+		}
+		if _, err := iter.Match("http", matchResponse("c")); err == nil {
+			t.Fatal("no error")
+		} else if got, expect := err.Error(), "no mocks match the request:\nmocks[3]: expected \"c\" but got \"d\""; got != expect {
+			t.Errorf("expect %q but got %q", expect, got)
+		}
+		if _, err := iter.Match("grpc", matchAny); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if err := iter.Stop(); err != nil {
+			t.Fatalf("failed to stop iterator: %s", err)
+		}
+	})
+	t.Run("match with priority", func(t *testing.T) {
+		iter := NewMockIterator(mocks(t, `
+- protocol: http
+  response: a
+- protocol: http
+  response: b
+  priority: 10
+- protocol: http
+  response: c
+  priority: 10
+`), WithMode(ModeMatch))
+		for i, expect := range []string{"b", "c", "a"} {
+			m, err := iter.Match("http", matchAny)
+			if err != nil {
+				t.Fatalf("[%d] unexpected error: %s", i, err)
+			}
+			if err := matchResponse(expect)(m); err != nil {
+				t.Fatalf("[%d] %s", i, err)
+			}
+		}
+		if err := iter.Stop(); err != nil {
+			t.Fatalf("failed to stop iterator: %s", err)
+		}
+	})
+	t.Run("mocks remain", func(t *testing.T) {
+		iter := NewMockIterator(mocks(t, `
+- protocol: http
+  response: a
+  times: 2
+- protocol: http
+  response: b
+  unlimited: true
+`), WithMode(ModeMatch))
+		if _, err := iter.Match("http", matchAny); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if err := iter.Stop(); err == nil {
+			t.Fatal("no error")
+		} else if got, expect := err.Error(), "last 1 mocks remain"; got != expect {
+			t.Errorf("expect %q but got %q", expect, got)
+		}
+	})
+}
+
+func TestMock_Validate(t *testing.T) {
+	tests := map[string]struct {
+		mock   Mock
+		expect string
+	}{
+		"default": {},
+		"times": {
+			mock: Mock{Times: ptr.To(3)},
+		},
+		"unlimited": {
+			mock: Mock{Unlimited: true},
+		},
+		"zero times": {
+			mock:   Mock{Times: ptr.To(0)},
+			expect: "times must be greater than 0 but got 0",
+		},
+		"times with unlimited": {
+			mock:   Mock{Times: ptr.To(1), Unlimited: true},
+			expect: "times and unlimited can't be specified at the same time",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.mock.Validate()
+			if test.expect == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("no error")
+			}
+			if got := err.Error(); got != test.expect {
+				t.Errorf("expect %q but got %q", test.expect, got)
+			}
+		})
+	}
+}
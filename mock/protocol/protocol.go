@@ -57,6 +57,12 @@ type Protocol interface {
 	NewServer(iter *MockIterator, l logger.Logger, config interface{}) (Server, error)
 }
 
+// ProxyProtocol is the interface that creates proxy server to record exchanges with the upstream server.
+type ProxyProtocol interface {
+	Protocol
+	NewProxyServer(rec *Recorder, l logger.Logger, config interface{}) (Server, error)
+}
+
 // Server represents a mock server.
 type Server interface {
 	Start(context.Context) error
@@ -65,5 +71,9 @@ type Server interface {
 	Addr() (string, error)
 }
 
-// ErrServerClosed is the error that the server is already closed.
-var ErrServerClosed = errors.New("server closed")
+var (
+	// ErrServerClosed is the error that the server is already closed.
+	ErrServerClosed = errors.New("server closed")
+	// ErrNoUpstream is the error that the upstream server is not specified.
+	ErrNoUpstream = errors.New("upstream not specified")
+)
@@ -0,0 +1,53 @@
+package protocol
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/zoncoen/scenarigo/internal/yamlutil"
+)
+
+// Recorder records exchanges with the upstream servers as mocks.
+type Recorder struct {
+	m     sync.Mutex
+	mocks []Mock
+}
+
+// NewRecorder returns a new Recorder.
+func NewRecorder() *Recorder {
+	//nolint:exhaustruct
+	return &Recorder{}
+}
+
+// Record appends the exchange as a mock.
+// The expect and response are encoded to YAML.
+func (r *Recorder) Record(protocol string, expect, response interface{}) error {
+	e, err := yaml.Marshal(expect)
+	if err != nil {
+		return fmt.Errorf("failed to marshal expect: %w", err)
+	}
+	resp, err := yaml.Marshal(response)
+	if err != nil {
+		return fmt.Errorf("failed to marshal response: %w", err)
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	//nolint:exhaustruct
+	r.mocks = append(r.mocks, Mock{
+		Protocol: protocol,
+		Expect:   yamlutil.RawMessage(e),
+		Response: yamlutil.RawMessage(resp),
+	})
+	return nil
+}
+
+// Mocks returns the recorded mocks.
+func (r *Recorder) Mocks() []Mock {
+	r.m.Lock()
+	defer r.m.Unlock()
+	mocks := make([]Mock, len(r.mocks))
+	copy(mocks, r.mocks)
+	return mocks
+}
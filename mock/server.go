@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"sync"
 
 	"golang.org/x/sync/errgroup"
 
+	"github.com/goccy/go-yaml"
 	"github.com/hashicorp/go-multierror"
 	"github.com/zoncoen/scenarigo/internal/yamlutil"
 	"github.com/zoncoen/scenarigo/logger"
@@ -24,18 +26,23 @@ func NewServer(config *ServerConfig, l logger.Logger) (*Server, error) {
 	if config == nil {
 		return nil, errors.New("config is nil")
 	}
-	iter := protocol.NewMockIterator(config.Mocks)
+	if err := config.Mode.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid mode: %w", err)
+	}
+	for i, m := range config.Mocks {
+		if err := m.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid mocks[%d]: %w", i, err)
+		}
+	}
+	if config.Mode == protocol.ModeRecord {
+		return newRecordServer(config, l)
+	}
+	iter := protocol.NewMockIterator(config.Mocks, protocol.WithMode(config.Mode))
 	protocols := protocol.All()
 	servers := map[string]protocol.Server{}
 	for name, p := range protocols {
 		p := p
-		var b []byte
-		if config.Protocols != nil {
-			if msg, ok := config.Protocols[p.Name()]; ok {
-				b = []byte(msg)
-			}
-		}
-		cfg, err := p.UnmarshalConfig(b)
+		cfg, err := p.UnmarshalConfig(config.protocolConfig(p.Name()))
 		if err != nil {
 			return nil, fmt.Errorf("failed to unmarshal %s config: %w", name, err)
 		}
@@ -52,19 +59,76 @@ func NewServer(config *ServerConfig, l logger.Logger) (*Server, error) {
 	}, nil
 }
 
+func newRecordServer(config *ServerConfig, l logger.Logger) (*Server, error) {
+	if config.Record == nil || config.Record.Output == "" {
+		return nil, errors.New("record.output must be specified in record mode")
+	}
+	rec := protocol.NewRecorder()
+	servers := map[string]protocol.Server{}
+	for name, p := range protocol.All() {
+		pp, ok := p.(protocol.ProxyProtocol)
+		if !ok {
+			continue
+		}
+		cfg, err := p.UnmarshalConfig(config.protocolConfig(p.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %s config: %w", name, err)
+		}
+		s, err := pp.NewProxyServer(rec, l, cfg)
+		if err != nil {
+			if errors.Is(err, protocol.ErrNoUpstream) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to create %s proxy server: %w", name, err)
+		}
+		servers[name] = s
+	}
+	if len(servers) == 0 {
+		return nil, errors.New("no upstream servers are specified for record mode")
+	}
+	return &Server{
+		servers:  servers,
+		logger:   l,
+		recorder: rec,
+		config:   config,
+	}, nil
+}
+
 // Server represents a mock server.
 type Server struct {
-	iter    *protocol.MockIterator
-	servers map[string]protocol.Server
-	logger  logger.Logger
+	iter     *protocol.MockIterator
+	servers  map[string]protocol.Server
+	logger   logger.Logger
+	recorder *protocol.Recorder
+	config   *ServerConfig
 }
 
 // ServerConfig represents a mock server configuration.
 type ServerConfig struct {
+	// Mode specifies how to select a mock for a request. (default sequential)
+	Mode      protocol.Mode                  `yaml:"mode,omitempty"`
+	Record    *RecordConfig                  `yaml:"record,omitempty"`
 	Mocks     []protocol.Mock                `yaml:"mocks,omitempty"`
 	Protocols map[string]yamlutil.RawMessage `yaml:"protocols,omitempty"`
 }
 
+// RecordConfig represents a configuration for record mode.
+type RecordConfig struct {
+	// Output is the path of the file to write the recorded mocks.
+	// The file can be used as the mock server configuration to replay the responses.
+	Output string `yaml:"output,omitempty"`
+}
+
+func (c *ServerConfig) protocolConfig(name string) []byte {
+	if c.Protocols == nil {
+		return nil
+	}
+	if msg, ok := c.Protocols[name]; ok {
+		return []byte(msg)
+	}
+	return nil
+}
+
 func (s *Server) Start(ctx context.Context) error {
 	eg, ctx := errgroup.WithContext(ctx)
 	for name, s := range s.servers {
@@ -128,6 +192,25 @@ func (s *Server) Stop(ctx context.Context) error {
 			return err
 		}
 	}
+	if s.recorder != nil {
+		if err := s.writeRecord(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Server) writeRecord() error {
+	b, err := yaml.Marshal(&ServerConfig{
+		Mocks:     s.recorder.Mocks(),
+		Protocols: s.config.Protocols,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal recorded mocks: %w", err)
+	}
+	if err := os.WriteFile(s.config.Record.Output, b, 0o644); err != nil { //nolint:gosec
+		return fmt.Errorf("failed to write recorded mocks: %w", err)
+	}
 	return nil
 }
 
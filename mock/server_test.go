@@ -2,14 +2,20 @@ package mock
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
 
 	"github.com/zoncoen/scenarigo/internal/yamlutil"
 	"github.com/zoncoen/scenarigo/logger"
@@ -120,6 +126,23 @@ func TestNewServer(t *testing.T) {
 				t.Fatal("no error")
 			}
 		})
+		t.Run("unknown mode", func(t *testing.T) {
+			if _, err := NewServer(&ServerConfig{Mode: "random"}, logger.NewNopLogger()); err == nil {
+				t.Fatal("no error")
+			} else if got, expect := err.Error(), `invalid mode: unknown mode "random"`; got != expect {
+				t.Errorf("expect %q but got %q", expect, got)
+			}
+		})
+		t.Run("invalid mock", func(t *testing.T) {
+			zero := 0
+			if _, err := NewServer(&ServerConfig{
+				Mocks: []protocol.Mock{{Protocol: "http", Times: &zero}},
+			}, logger.NewNopLogger()); err == nil {
+				t.Fatal("no error")
+			} else if got, expect := err.Error(), "invalid mocks[0]: times must be greater than 0 but got 0"; got != expect {
+				t.Errorf("expect %q but got %q", expect, got)
+			}
+		})
 	})
 }
 
@@ -416,3 +439,165 @@ func (s *mockServer) Addr() (string, error) {
 	}
 	return "", nil
 }
+
+func TestServer_Record(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Path", r.URL.Path)
+		if len(b) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"not found"}`))
+			return
+		}
+		_, _ = w.Write(b)
+	}))
+	t.Cleanup(upstream.Close)
+
+	type exchange struct {
+		method string
+		path   string
+		body   string
+		code   int
+		expect string
+	}
+	exchanges := []exchange{
+		{
+			method: http.MethodPost,
+			path:   "/echo",
+			body:   `{"message":"hello","id":1}`,
+			code:   http.StatusOK,
+			expect: `{"message":"hello","id":1}`,
+		},
+		{
+			method: http.MethodGet,
+			path:   "/items",
+			code:   http.StatusNotFound,
+			expect: `{"message":"not found"}`,
+		},
+	}
+	run := func(t *testing.T, config *ServerConfig) {
+		t.Helper()
+		srv, err := NewServer(config, logger.NewNopLogger())
+		if err != nil {
+			t.Fatalf("failed to create server: %s", err)
+		}
+		ch := make(chan error)
+		go func() {
+			ch <- srv.Start(context.Background())
+		}()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if err := srv.Wait(ctx); err != nil {
+			t.Fatalf("failed to wait: %s", err)
+		}
+		addrs, err := srv.Addrs()
+		if err != nil {
+			t.Fatalf("failed to get addresses: %s", err)
+		}
+		for i, e := range exchanges {
+			req, err := http.NewRequest(e.method, fmt.Sprintf("http://%s%s", addrs["http"], e.path), strings.NewReader(e.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if e.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("[%d] failed to request: %s", i, err)
+			}
+			b, err := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				t.Fatalf("[%d] failed to read body: %s", i, err)
+			}
+			if got, expect := resp.StatusCode, e.code; got != expect {
+				t.Errorf("[%d] expect %d but got %d: %s", i, expect, got, b)
+			}
+			if got, expect := resp.Header.Get("X-Path"), e.path; got != expect {
+				t.Errorf("[%d] expect X-Path %q but got %q", i, expect, got)
+			}
+			var got, expect any
+			if err := json.Unmarshal(b, &got); err != nil {
+				t.Fatalf("[%d] failed to unmarshal: %s: %s", i, err, b)
+			}
+			if err := json.Unmarshal([]byte(e.expect), &expect); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(expect, got); diff != "" {
+				t.Errorf("[%d] differs (-want +got):\n%s", i, diff)
+			}
+		}
+		if err := srv.Stop(ctx); err != nil {
+			t.Fatalf("failed to stop: %s", err)
+		}
+		if err := <-ch; err != nil {
+			t.Fatalf("failed to start: %s", err)
+		}
+	}
+
+	output := filepath.Join(t.TempDir(), "mocks.yaml")
+	t.Run("record", func(t *testing.T) {
+		run(t, &ServerConfig{
+			Mode: protocol.ModeRecord,
+			Record: &RecordConfig{
+				Output: output,
+			},
+			Protocols: map[string]yamlutil.RawMessage{
+				"http": yamlutil.RawMessage(fmt.Sprintf("upstream:\n  url: %s", upstream.URL)),
+			},
+		})
+	})
+	t.Run("replay", func(t *testing.T) {
+		f, err := os.Open(output)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		var config ServerConfig
+		if err := yaml.NewDecoder(f, yaml.Strict()).Decode(&config); err != nil {
+			t.Fatalf("failed to decode recorded mocks: %s", err)
+		}
+		if got, expect := len(config.Mocks), len(exchanges); got != expect {
+			t.Fatalf("expect %d mocks but got %d", expect, got)
+		}
+		upstream.Close()
+		run(t, &config)
+	})
+	t.Run("failure", func(t *testing.T) {
+		tests := map[string]struct {
+			config *ServerConfig
+			expect string
+		}{
+			"no output": {
+				config: &ServerConfig{
+					Mode: protocol.ModeRecord,
+				},
+				expect: "record.output must be specified in record mode",
+			},
+			"no upstream": {
+				config: &ServerConfig{
+					Mode: protocol.ModeRecord,
+					Record: &RecordConfig{
+						Output: output,
+					},
+				},
+				expect: "no upstream servers are specified for record mode",
+			},
+		}
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				if _, err := NewServer(test.config, logger.NewNopLogger()); err == nil {
+					t.Fatal("no error")
+				} else if got := err.Error(); got != test.expect {
+					t.Errorf("expect %q but got %q", test.expect, got)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,154 @@
+package graphql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/zoncoen/scenarigo/assert"
+	"github.com/zoncoen/scenarigo/context"
+	"github.com/zoncoen/scenarigo/errors"
+	"github.com/zoncoen/scenarigo/internal/assertutil"
+)
+
+// Expect represents expected response values.
+type Expect struct {
+	// Code is the expected HTTP status code.
+	// It is not checked unless it is specified because the result has the errors.
+	Code   string        `yaml:"code,omitempty"`
+	Header yaml.MapSlice `yaml:"header,omitempty"`
+	Data   interface{}   `yaml:"data,omitempty"`
+	// Errors is the expected errors.
+	// If it is not specified, the response must not have any errors.
+	Errors     interface{} `yaml:"errors,omitempty"`
+	Extensions interface{} `yaml:"extensions,omitempty"`
+	// Messages is the expected results received by the subscription.
+	Messages []interface{} `yaml:"messages,omitempty"`
+}
+
+// Build implements protocol.AssertionBuilder interface.
+//
+//nolint:gocyclo,cyclop
+func (e *Expect) Build(ctx *context.Context) (assert.Assertion, error) {
+	var codeAssertion assert.Assertion
+	if e.Code != "" {
+		var err error
+		codeAssertion, err = assert.Build(ctx.RequestContext(), e.Code, assert.FromTemplate(ctx))
+		if err != nil {
+			return nil, errors.WrapPathf(err, "code", "invalid expect status code")
+		}
+	}
+
+	headerAssertion, err := assertutil.BuildHeaderAssertion(ctx, e.Header)
+	if err != nil {
+		return nil, errors.WrapPathf(err, "header", "invalid expect header")
+	}
+
+	build := func(v interface{}, path, name string) (assert.Assertion, error) {
+		if v == nil {
+			return nil, nil //nolint:nilnil
+		}
+		assertion, err := assert.Build(ctx.RequestContext(), v, assert.FromTemplate(ctx))
+		if err != nil {
+			return nil, errors.WrapPathf(err, path, "invalid expect %s", name)
+		}
+		return assertion, nil
+	}
+	dataAssertion, err := build(e.Data, "data", "data")
+	if err != nil {
+		return nil, err
+	}
+	errorsAssertion, err := build(e.Errors, "errors", "errors")
+	if err != nil {
+		return nil, err
+	}
+	extensionsAssertion, err := build(e.Extensions, "extensions", "extensions")
+	if err != nil {
+		return nil, err
+	}
+	var msgsAssertion assert.Assertion
+	if e.Messages != nil {
+		msgsAssertion, err = build(e.Messages, "messages", "messages")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return assert.AssertionFunc(func(v interface{}) error {
+		resp, ok := v.(*response)
+		if !ok {
+			return errors.Errorf("expected response but got %T", v)
+		}
+		if codeAssertion != nil {
+			if err := assertCode(codeAssertion, resp.Status); err != nil {
+				return errors.WithPath(err, "code")
+			}
+		}
+		if err := headerAssertion.Assert(resp.Header); err != nil {
+			return errors.WithPath(err, "header")
+		}
+		if errorsAssertion != nil {
+			if err := errorsAssertion.Assert(resp.Errors); err != nil {
+				return errors.WithPath(err, "errors")
+			}
+		} else if len(resp.Errors) > 0 {
+			return errors.ErrorPathf("errors", "expected no errors but got %s", formatErrors(resp.Errors))
+		}
+		if dataAssertion != nil {
+			if err := dataAssertion.Assert(resp.Data); err != nil {
+				return errors.WithPath(err, "data")
+			}
+		}
+		if extensionsAssertion != nil {
+			if err := extensionsAssertion.Assert(resp.Extensions); err != nil {
+				return errors.WithPath(err, "extensions")
+			}
+		}
+		if msgsAssertion != nil {
+			if expect, got := len(e.Messages), len(resp.Messages); expect != got {
+				return errors.ErrorPathf("messages", "expected %d messages but got %d", expect, got)
+			}
+			if err := msgsAssertion.Assert(resp.Messages); err != nil {
+				return errors.WithPath(err, "messages")
+			}
+		}
+		return nil
+	}), nil
+}
+
+func assertCode(assertion assert.Assertion, status string) error {
+	strs := strings.SplitN(status, " ", 2)
+	if len(strs) != 2 {
+		return errors.Errorf(`unexpected response status string: "%s"`, status)
+	}
+	if err := assertion.Assert(strs[0]); err == nil {
+		return nil
+	}
+	return assertion.Assert(strs[1])
+}
+
+// formatErrors returns the messages and the paths of the GraphQL errors.
+func formatErrors(errs []interface{}) string {
+	msgs := make([]string, 0, len(errs))
+	for _, e := range errs {
+		m, ok := e.(map[string]interface{})
+		if !ok {
+			msgs = append(msgs, fmt.Sprintf("%v", e))
+			continue
+		}
+		msg := fmt.Sprintf("%q", m["message"])
+		if path, ok := m["path"].([]interface{}); ok && len(path) > 0 {
+			elems := make([]string, len(path))
+			for i, p := range path {
+				elems[i] = fmt.Sprint(p)
+			}
+			msg = fmt.Sprintf("%s at %s", msg, strings.Join(elems, "."))
+		}
+		msgs = append(msgs, msg)
+	}
+	if len(msgs) == 1 {
+		return fmt.Sprintf("1 error: %s", msgs[0])
+	}
+	return fmt.Sprintf("%d errors: %s", len(msgs), strings.Join(msgs, ", "))
+}
@@ -0,0 +1,279 @@
+package graphql
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/zoncoen/scenarigo/context"
+)
+
+func TestExpect_Build(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tests := map[string]struct {
+			vars     interface{}
+			expect   *Expect
+			response *response
+		}{
+			"default": {
+				expect: &Expect{},
+				response: &response{
+					Status: "200 OK",
+					Data:   map[string]interface{}{"user": nil},
+				},
+			},
+			"status code is not checked by default": {
+				expect: &Expect{},
+				response: &response{
+					Status: "500 Internal Server Error",
+					Data:   map[string]interface{}{"user": nil},
+				},
+			},
+			"status code": {
+				expect: &Expect{
+					Code: "OK",
+				},
+				response: &response{
+					Status: "200 OK",
+				},
+			},
+			"header": {
+				expect: &Expect{
+					Header: yaml.MapSlice{
+						{Key: "Content-Type", Value: "application/json"},
+					},
+				},
+				response: &response{
+					Status: "200 OK",
+					Header: map[string][]string{
+						"Content-Type": {"application/json"},
+					},
+				},
+			},
+			"data": {
+				vars: map[string]string{"name": "Alice"},
+				expect: &Expect{
+					Data: yaml.MapSlice{
+						{
+							Key: "user",
+							Value: yaml.MapSlice{
+								{Key: "name", Value: "{{vars.name}}"},
+							},
+						},
+					},
+				},
+				response: &response{
+					Status: "200 OK",
+					Data: map[string]interface{}{
+						"user": map[string]interface{}{
+							"id":   "1",
+							"name": "Alice",
+						},
+					},
+				},
+			},
+			"errors": {
+				expect: &Expect{
+					Errors: []interface{}{
+						yaml.MapSlice{
+							{Key: "message", Value: "user not found"},
+						},
+					},
+				},
+				response: &response{
+					Status: "200 OK",
+					Data:   map[string]interface{}{"user": nil},
+					Errors: []interface{}{
+						map[string]interface{}{
+							"message": "user not found",
+							"path":    []interface{}{"user"},
+						},
+					},
+				},
+			},
+			"extensions": {
+				expect: &Expect{
+					Extensions: yaml.MapSlice{
+						{Key: "cost", Value: 1},
+					},
+				},
+				response: &response{
+					Status:     "200 OK",
+					Extensions: map[string]interface{}{"cost": 1},
+				},
+			},
+			"messages": {
+				expect: &Expect{
+					Messages: []interface{}{
+						yaml.MapSlice{
+							{
+								Key: "data",
+								Value: yaml.MapSlice{
+									{Key: "count", Value: 1},
+								},
+							},
+						},
+						yaml.MapSlice{
+							{
+								Key: "data",
+								Value: yaml.MapSlice{
+									{Key: "count", Value: 2},
+								},
+							},
+						},
+					},
+				},
+				response: &response{
+					Messages: []*Result{
+						{Data: map[string]interface{}{"count": 1}},
+						{Data: map[string]interface{}{"count": 2}},
+					},
+				},
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				ctx := context.FromT(t)
+				if test.vars != nil {
+					ctx = ctx.WithVars(test.vars)
+				}
+				assertion, err := test.expect.Build(ctx)
+				if err != nil {
+					t.Fatalf("failed to build assertion: %s", err)
+				}
+				if err := assertion.Assert(test.response); err != nil {
+					t.Errorf("got assertion error: %s", err)
+				}
+			})
+		}
+	})
+	t.Run("ng", func(t *testing.T) {
+		tests := map[string]struct {
+			expect           *Expect
+			response         *response
+			expectBuildError string
+			expectError      string
+		}{
+			"invalid code assertion": {
+				expect: &Expect{
+					Code: `{{foo}}`,
+				},
+				expectBuildError: `.code: invalid expect status code`,
+			},
+			"failed to execute template": {
+				expect: &Expect{
+					Data: yaml.MapSlice{
+						{Key: "user", Value: "{{vars.user}}"},
+					},
+				},
+				expectBuildError: `.data.user: invalid expect data`,
+			},
+			"wrong status code": {
+				expect: &Expect{
+					Code: "OK",
+				},
+				response: &response{
+					Status: "404 Not Found",
+				},
+				expectError: `.code: expected "OK" but got "Not Found"`,
+			},
+			"unexpected error": {
+				expect: &Expect{},
+				response: &response{
+					Status: "200 OK",
+					Data:   map[string]interface{}{"user": nil},
+					Errors: []interface{}{
+						map[string]interface{}{
+							"message": "user not found",
+							"path":    []interface{}{"user"},
+						},
+					},
+				},
+				expectError: `.errors: expected no errors but got 1 error: "user not found" at user`,
+			},
+			"unexpected errors": {
+				expect: &Expect{
+					Data: yaml.MapSlice{
+						{Key: "user", Value: nil},
+					},
+				},
+				response: &response{
+					Status: "200 OK",
+					Errors: []interface{}{
+						map[string]interface{}{
+							"message": "unauthorized",
+						},
+						map[string]interface{}{
+							"message": "user not found",
+							"path":    []interface{}{"users", 0},
+						},
+					},
+				},
+				expectError: `.errors: expected no errors but got 2 errors: "unauthorized", "user not found" at users.0`,
+			},
+			"wrong data": {
+				expect: &Expect{
+					Data: yaml.MapSlice{
+						{
+							Key: "user",
+							Value: yaml.MapSlice{
+								{Key: "name", Value: "Bob"},
+							},
+						},
+					},
+				},
+				response: &response{
+					Status: "200 OK",
+					Data: map[string]interface{}{
+						"user": map[string]interface{}{
+							"name": "Alice",
+						},
+					},
+				},
+				expectError: `.data.user.name: expected "Bob" but got "Alice"`,
+			},
+			"wrong number of messages": {
+				expect: &Expect{
+					Messages: []interface{}{
+						yaml.MapSlice{{Key: "data", Value: nil}},
+					},
+				},
+				response: &response{
+					Messages: []*Result{
+						{Data: map[string]interface{}{"count": 1}},
+						{Data: map[string]interface{}{"count": 2}},
+					},
+				},
+				expectError: `.messages: expected 1 messages but got 2`,
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				ctx := context.FromT(t)
+				assertion, err := test.expect.Build(ctx)
+				if test.expectBuildError != "" {
+					if err == nil {
+						t.Fatal("no error")
+					}
+					if got, expect := err.Error(), test.expectBuildError; !strings.HasPrefix(got, expect) {
+						t.Errorf("expect error %q but got %q", expect, got)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("failed to build assertion: %s", err)
+				}
+				err = assertion.Assert(test.response)
+				if err == nil {
+					t.Fatal("no assertion error")
+				}
+				if got, expect := err.Error(), test.expectError; got != expect {
+					t.Errorf("expect error %q but got %q", expect, got)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,83 @@
+// Package graphql provides the GraphQL protocol for scenarigo steps.
+package graphql
+
+import (
+	"bytes"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/zoncoen/scenarigo/protocol"
+	"github.com/zoncoen/scenarigo/schema/jsonschema"
+)
+
+// Register registers graphql protocol.
+func Register() {
+	protocol.Register(&GraphQL{})
+}
+
+// GraphQL is a protocol type for the scenarigo step.
+// The queries and mutations are sent by the HTTP client of http protocol, so the options of http protocol are also applied.
+type GraphQL struct{}
+
+// Name implements protocol.Protocol interface.
+func (p *GraphQL) Name() string {
+	return "graphql"
+}
+
+// UnmarshalOption implements protocol.Protocol interface.
+func (p *GraphQL) UnmarshalOption(_ []byte) error {
+	return nil
+}
+
+// UnmarshalRequest implements protocol.Protocol interface.
+// The inline query is parsed to report syntax errors on loading the scenario unless it contains templates.
+func (p *GraphQL) UnmarshalRequest(b []byte) (protocol.Invoker, error) {
+	var r Request
+	if err := yaml.UnmarshalWithOptions(b, &r, yaml.Strict()); err != nil {
+		return nil, err
+	}
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// UnmarshalExpect implements protocol.Protocol interface.
+func (p *GraphQL) UnmarshalExpect(b []byte) (protocol.AssertionBuilder, error) {
+	var e Expect
+	if b == nil {
+		return &e, nil
+	}
+	decoder := yaml.NewDecoder(bytes.NewBuffer(b), yaml.UseOrderedMap(), yaml.Strict())
+	if err := decoder.Decode(&e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// OptionSchema implements protocol.SchemaProvider interface.
+func (p *GraphQL) OptionSchema() *jsonschema.Schema {
+	return nil
+}
+
+// RequestSchema implements protocol.SchemaProvider interface.
+func (p *GraphQL) RequestSchema() *jsonschema.Schema {
+	s := jsonschema.Reflect(Request{})
+	if sub := s.Property("subscription"); sub != nil {
+		sub.SetProperty("protocol", &jsonschema.Schema{
+			Type: jsonschema.Types{jsonschema.TypeString},
+			Enum: []any{SubprotocolGraphQLTransportWS, SubprotocolGraphQLWS},
+		})
+	}
+	return s
+}
+
+// ExpectSchema implements protocol.SchemaProvider interface.
+func (p *GraphQL) ExpectSchema() *jsonschema.Schema {
+	s := jsonschema.Reflect(Expect{})
+	// the status code can be written as a number or a status text
+	s.SetProperty("code", &jsonschema.Schema{
+		Type: jsonschema.Types{jsonschema.TypeString, jsonschema.TypeInteger},
+	})
+	return s
+}
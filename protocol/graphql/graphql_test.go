@@ -0,0 +1,105 @@
+package graphql
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/zoncoen/scenarigo/protocol"
+)
+
+func TestGraphQL(t *testing.T) {
+	Register()
+	p := protocol.Get("graphql")
+	if p == nil {
+		t.Fatal("graphql protocol not found")
+	}
+	if err := p.UnmarshalOption([]byte("")); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGraphQL_UnmarshalRequest(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tests := map[string]struct {
+			bytes  []byte
+			expect *Request
+		}{
+			"query": {
+				bytes: []byte(`
+url: http://example.com/graphql
+query: "{ user { name } }"`),
+				expect: &Request{
+					URL:   "http://example.com/graphql",
+					Query: "{ user { name } }",
+				},
+			},
+			"query with templates": {
+				bytes: []byte(`query: "{{vars.query}}"`),
+				expect: &Request{
+					Query: "{{vars.query}}",
+				},
+			},
+			"query file": {
+				bytes: []byte(`queryFile: testdata/user.graphql`),
+				expect: &Request{
+					QueryFile: "testdata/user.graphql",
+				},
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				p := &GraphQL{}
+				invoker, err := p.UnmarshalRequest(test.bytes)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if diff := cmp.Diff(test.expect, invoker); diff != "" {
+					t.Errorf("request differs (-want +got):\n%s", diff)
+				}
+			})
+		}
+	})
+
+	t.Run("ng", func(t *testing.T) {
+		tests := map[string]struct {
+			bytes  []byte
+			expect string
+		}{
+			"no query": {
+				bytes:  []byte(`url: http://example.com/graphql`),
+				expect: ".query: query or queryFile must be specified",
+			},
+			"both query and queryFile": {
+				bytes: []byte(`
+query: "{ user { name } }"
+queryFile: testdata/user.graphql`),
+				expect: ".queryFile: query and queryFile can't be specified at the same time",
+			},
+			"syntax error": {
+				bytes:  []byte(`query: "{ user { name }"`),
+				expect: ".query: invalid query: query:1:16: Expected Name, found <EOF>",
+			},
+			"operation not found": {
+				bytes: []byte(`
+query: "query GetUser { user { name } }"
+operationName: ListUsers`),
+				expect: `.operationName: operation "ListUsers" not found`,
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				p := &GraphQL{}
+				_, err := p.UnmarshalRequest(test.bytes)
+				if err == nil {
+					t.Fatal("no error")
+				}
+				if got := err.Error(); got != test.expect {
+					t.Errorf("expect error %q but got %q", test.expect, got)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,322 @@
+package graphql
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+
+	"github.com/zoncoen/scenarigo/context"
+	"github.com/zoncoen/scenarigo/errors"
+	"github.com/zoncoen/scenarigo/internal/filepathutil"
+	"github.com/zoncoen/scenarigo/internal/reflectutil"
+	"github.com/zoncoen/scenarigo/protocol/http"
+	"github.com/zoncoen/scenarigo/protocol/http/unmarshaler"
+)
+
+var defaultHeader = []struct {
+	name  string
+	value string
+}{
+	{name: "Content-Type", value: "application/json"},
+	{name: "Accept", value: "application/graphql-response+json, application/json"},
+}
+
+// Request represents a request.
+type Request struct {
+	URL    string      `yaml:"url,omitempty"`
+	Header interface{} `yaml:"header,omitempty"`
+
+	// Query is the GraphQL document.
+	Query string `yaml:"query,omitempty"`
+	// QueryFile is the path of the file which has the GraphQL document, relative to the scenario file.
+	// The templates in the file are not executed.
+	QueryFile string `yaml:"queryFile,omitempty"`
+
+	OperationName string      `yaml:"operationName,omitempty"`
+	Variables     interface{} `yaml:"variables,omitempty"`
+
+	// Options are the options of http protocol to send queries and mutations.
+	// The subscriptions apply only the base URL, header, proxy, and TLS options.
+	Options *http.RequestOptions `yaml:"options,omitempty"`
+
+	// Subscription is the option for the subscription operation.
+	Subscription *SubscriptionOption `yaml:"subscription,omitempty"`
+}
+
+// Result represents a GraphQL execution result.
+type Result struct {
+	Data       interface{}   `yaml:"data,omitempty"`
+	Errors     []interface{} `yaml:"errors,omitempty"`
+	Extensions interface{}   `yaml:"extensions,omitempty"`
+}
+
+type response struct {
+	Status     string              `yaml:"status,omitempty"`
+	StatusCode int                 `yaml:"statusCode,omitempty"`
+	Header     map[string][]string `yaml:"header,omitempty"`
+	Data       interface{}         `yaml:"data,omitempty"`
+	Errors     []interface{}       `yaml:"errors,omitempty"`
+	Extensions interface{}         `yaml:"extensions,omitempty"`
+
+	// Messages are the execution results received by the subscription.
+	Messages []*Result `yaml:"messages,omitempty"`
+}
+
+// requestBody is the HTTP request body whose templates have already been executed.
+// It hides the values from the template execution of http protocol not to execute them twice.
+type requestBody struct {
+	v yaml.MapSlice
+}
+
+// MarshalYAML implements yaml.InterfaceMarshaler interface.
+func (b requestBody) MarshalYAML() (interface{}, error) {
+	return b.v, nil
+}
+
+const (
+	indentNum = 2
+)
+
+func (r *Request) addIndent(s string, indentNum int) string {
+	indent := strings.Repeat(" ", indentNum)
+	lines := []string{}
+	for _, line := range strings.Split(s, "\n") {
+		if line == "" {
+			lines = append(lines, line)
+		} else {
+			lines = append(lines, fmt.Sprintf("%s%s", indent, line))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// validate checks the request fields which can be checked before executing templates.
+func (r *Request) validate() error {
+	switch {
+	case r.Query != "" && r.QueryFile != "":
+		return errors.ErrorPath("queryFile", "query and queryFile can't be specified at the same time")
+	case r.Query == "" && r.QueryFile == "":
+		return errors.ErrorPath("query", "query or queryFile must be specified")
+	}
+	if r.Query != "" && !strings.Contains(r.Query, "{{") {
+		if _, err := r.operation(r.Query, "query", "query"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Invoke implements protocol.Invoker interface.
+func (r *Request) Invoke(ctx *context.Context) (*context.Context, interface{}, error) {
+	if err := r.validate(); err != nil {
+		return ctx, nil, err
+	}
+	query, op, err := r.buildQuery(ctx)
+	if err != nil {
+		return ctx, nil, err
+	}
+	variables, err := ctx.ExecuteTemplate(r.Variables)
+	if err != nil {
+		return ctx, nil, errors.WrapPathf(err, "variables", "failed to set variables")
+	}
+
+	//nolint:exhaustruct
+	reqDump := &Request{
+		Query:         query,
+		OperationName: r.OperationName,
+		Variables:     variables,
+	}
+	if op == ast.Subscription {
+		return r.subscribe(ctx, reqDump)
+	}
+	return r.post(ctx, reqDump)
+}
+
+// buildQuery returns the GraphQL document and the type of the operation to execute.
+func (r *Request) buildQuery(ctx *context.Context) (string, ast.Operation, error) {
+	if r.QueryFile != "" {
+		x, err := ctx.ExecuteTemplate(r.QueryFile)
+		if err != nil {
+			return "", "", errors.WrapPathf(err, "queryFile", "failed to get query file")
+		}
+		f, ok := x.(string)
+		if !ok {
+			return "", "", errors.ErrorPathf("queryFile", `queryFile must be "string" but got "%T"`, x)
+		}
+		b, err := os.ReadFile(filepathutil.From(filepath.Dir(ctx.ScenarioFilepath()), f))
+		if err != nil {
+			return "", "", errors.WrapPathf(err, "queryFile", "failed to read query file")
+		}
+		query := string(b)
+		op, err := r.operation(query, f, "queryFile")
+		if err != nil {
+			return "", "", err
+		}
+		return query, op, nil
+	}
+
+	x, err := ctx.ExecuteTemplate(r.Query)
+	if err != nil {
+		return "", "", errors.WrapPathf(err, "query", "failed to get query")
+	}
+	query, ok := x.(string)
+	if !ok {
+		return "", "", errors.ErrorPathf("query", `query must be "string" but got "%T"`, x)
+	}
+	op, err := r.operation(query, "query", "query")
+	if err != nil {
+		return "", "", err
+	}
+	return query, op, nil
+}
+
+// operation parses the GraphQL document and returns the type of the operation to execute.
+// The name is used to indicate the location of syntax errors, and the path is the field which has the query.
+func (r *Request) operation(query, name, path string) (ast.Operation, error) {
+	//nolint:exhaustruct
+	doc, err := parser.ParseQuery(&ast.Source{Name: name, Input: query})
+	if err != nil {
+		return "", errors.ErrorPathf(path, "invalid query: %s", err)
+	}
+	if len(doc.Operations) == 0 {
+		return "", errors.ErrorPath(path, "invalid query: no operation")
+	}
+	if r.OperationName == "" {
+		if len(doc.Operations) > 1 {
+			return "", errors.ErrorPath("operationName", "operationName must be specified for the query which has multiple operations")
+		}
+		return doc.Operations[0].Operation, nil
+	}
+	op := doc.Operations.ForName(r.OperationName)
+	if op == nil {
+		return "", errors.ErrorPathf("operationName", "operation %q not found", r.OperationName)
+	}
+	return op.Operation, nil
+}
+
+// post sends the query or mutation by the HTTP client of http protocol.
+func (r *Request) post(ctx *context.Context, reqDump *Request) (*context.Context, interface{}, error) {
+	//nolint:exhaustruct
+	req := &http.Request{
+		Method:  "POST",
+		URL:     r.URL,
+		Header:  r.header(),
+		Body:    requestBody{v: reqDump.payload()},
+		Options: r.Options,
+	}
+	ctx, _, err := req.Invoke(ctx)
+	if err != nil {
+		return ctx, nil, err
+	}
+	res, ok := ctx.Response().(*http.ResponseExtractor)
+	if !ok {
+		return ctx, nil, errors.Errorf("unexpected response type %T", ctx.Response())
+	}
+	if reqExt, ok := ctx.Request().(*http.RequestExtractor); ok {
+		reqDump.URL = reqExt.URL
+		reqDump.Header = reqExt.Header
+	}
+
+	result, err := decodeResult(res.Body)
+	if err != nil {
+		return ctx, nil, errors.Errorf("invalid GraphQL response: %s: %s", res.Status, err)
+	}
+	rvalue := &response{
+		Status:     res.Status,
+		StatusCode: res.StatusCode,
+		Header:     res.Header,
+		Data:       result.Data,
+		Errors:     result.Errors,
+		Extensions: result.Extensions,
+	}
+	ctx = ctx.WithRequest(reqDump).WithResponse(rvalue)
+	return ctx, rvalue, nil
+}
+
+// payload returns the GraphQL request parameters.
+func (r *Request) payload() yaml.MapSlice {
+	p := yaml.MapSlice{{Key: "query", Value: r.Query}}
+	if r.OperationName != "" {
+		p = append(p, yaml.MapItem{Key: "operationName", Value: r.OperationName})
+	}
+	if r.Variables != nil {
+		p = append(p, yaml.MapItem{Key: "variables", Value: r.Variables})
+	}
+	return p
+}
+
+// header returns the request header with the default header for GraphQL over HTTP.
+// The header values are kept as they are to execute the templates by http protocol.
+func (r *Request) header() interface{} {
+	hdr := map[string]interface{}{}
+	keys := map[string]struct{}{}
+	add := func(k, v interface{}) {
+		key := fmt.Sprint(k)
+		keys[strings.ToLower(key)] = struct{}{}
+		hdr[key] = v
+	}
+	switch h := r.Header.(type) {
+	case nil:
+	case yaml.MapSlice:
+		for _, item := range h {
+			add(item.Key, item.Value)
+		}
+	default:
+		rv := reflectutil.Elem(reflect.ValueOf(h))
+		if rv.Kind() != reflect.Map {
+			// http protocol reports the invalid header
+			return r.Header
+		}
+		iter := rv.MapRange()
+		for iter.Next() {
+			add(iter.Key().Interface(), iter.Value().Interface())
+		}
+	}
+	for _, item := range defaultHeader {
+		if _, ok := keys[strings.ToLower(item.name)]; !ok {
+			hdr[item.name] = item.value
+		}
+	}
+	return hdr
+}
+
+// decodeResult decodes the GraphQL execution result.
+func decodeResult(v interface{}) (*Result, error) {
+	switch b := v.(type) {
+	case []byte:
+		if err := unmarshaler.Get("application/json").Unmarshal(b, &v); err != nil {
+			return nil, errors.Errorf("failed to unmarshal as JSON: %s", err)
+		}
+	case string:
+		if err := unmarshaler.Get("application/json").Unmarshal([]byte(b), &v); err != nil {
+			return nil, errors.Errorf("failed to unmarshal as JSON: %s", err)
+		}
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, errors.Errorf("expected JSON object but got %T", v)
+	}
+	data, hasData := m["data"]
+	errs, hasErrors := m["errors"]
+	if !hasData && !hasErrors {
+		return nil, errors.New("neither data nor errors found")
+	}
+	result := &Result{
+		Data:       data,
+		Extensions: m["extensions"],
+	}
+	if hasErrors && errs != nil {
+		l, ok := errs.([]interface{})
+		if !ok {
+			return nil, errors.Errorf("errors must be a list but got %T", errs)
+		}
+		result.Errors = l
+	}
+	return result, nil
+}
@@ -0,0 +1,526 @@
+package graphql
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/websocket"
+
+	"github.com/zoncoen/scenarigo/context"
+	protocolhttp "github.com/zoncoen/scenarigo/protocol/http"
+)
+
+func startTestServer(t *testing.T) string {
+	t.Helper()
+	m := http.NewServeMux()
+	m.HandleFunc("/graphql", func(w http.ResponseWriter, req *http.Request) {
+		var params struct {
+			Query         string         `json:"query"`
+			OperationName string         `json:"operationName"`
+			Variables     map[string]any `json:"variables"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&params); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+		resp := map[string]any{
+			"extensions": map[string]any{
+				"contentType": req.Header.Get("Content-Type"),
+				"token":       req.Header.Get("X-Token"),
+			},
+		}
+		switch {
+		case params.OperationName == "ListUsers":
+			resp["data"] = map[string]any{"users": []any{map[string]any{"id": "1"}}}
+		case params.Variables["id"] == "1":
+			resp["data"] = map[string]any{"user": map[string]any{"id": "1", "name": "alice"}}
+		default:
+			resp["data"] = map[string]any{"user": nil}
+			resp["errors"] = []any{map[string]any{"message": "user not found", "path": []any{"user"}}}
+		}
+		w.Header().Set("Content-Type", "application/graphql-response+json")
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+	m.HandleFunc("/error", func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("internal error"))
+	})
+	upgrader := websocket.Upgrader{
+		Subprotocols: []string{SubprotocolGraphQLTransportWS, SubprotocolGraphQLWS},
+	}
+	m.HandleFunc("/subscriptions", func(w http.ResponseWriter, req *http.Request) {
+		conn, err := upgrader.Upgrade(w, req, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		legacy := conn.Subprotocol() == SubprotocolGraphQLWS
+
+		var msg struct {
+			ID      string         `json:"id"`
+			Type    string         `json:"type"`
+			Payload map[string]any `json:"payload"`
+		}
+		if err := conn.ReadJSON(&msg); err != nil || msg.Type != "connection_init" {
+			return
+		}
+		if msg.Payload["token"] != "secret" {
+			if legacy {
+				_ = conn.WriteJSON(map[string]any{"type": "connection_error", "payload": map[string]any{"message": "forbidden"}})
+				return
+			}
+			_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(4403, "Forbidden"))
+			return
+		}
+		if legacy {
+			_ = conn.WriteJSON(map[string]any{"type": "connection_ack"})
+			_ = conn.WriteJSON(map[string]any{"type": "ka"})
+		} else {
+			_ = conn.WriteJSON(map[string]any{"type": "ping"})
+			_ = conn.WriteJSON(map[string]any{"type": "connection_ack"})
+		}
+		for {
+			if err := conn.ReadJSON(&msg); err != nil {
+				return
+			}
+			if msg.Type == "subscribe" || msg.Type == "start" {
+				break
+			}
+		}
+		next := "next"
+		if legacy {
+			next = "data"
+		}
+		variables, _ := msg.Payload["variables"].(map[string]any)
+		if variables["fail"] == true {
+			_ = conn.WriteJSON(map[string]any{"id": msg.ID, "type": "error", "payload": []any{map[string]any{"message": "invalid subscription"}}})
+			return
+		}
+		for i := 1; i <= 3; i++ {
+			_ = conn.WriteJSON(map[string]any{"id": msg.ID, "type": next, "payload": map[string]any{"data": map[string]any{"counter": i}}})
+		}
+		_ = conn.WriteJSON(map[string]any{"id": msg.ID, "type": "complete"})
+		// wait for closing by the client
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	})
+	srv := httptest.NewServer(m)
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func TestRequest_Invoke(t *testing.T) {
+	url := startTestServer(t)
+	tests := map[string]struct {
+		vars   any
+		req    *Request
+		expect *response
+	}{
+		"query": {
+			vars: map[string]any{"id": "1", "token": "xxx"},
+			req: &Request{
+				URL: url + "/graphql",
+				Header: map[string]any{
+					"X-Token": "{{vars.token}}",
+				},
+				Query: `query GetUser($id: ID!) { user(id: $id) { id name } }`,
+				Variables: map[string]any{
+					"id": "{{vars.id}}",
+				},
+			},
+			expect: &response{
+				Status:     "200 OK",
+				StatusCode: http.StatusOK,
+				Data: map[string]any{
+					"user": map[string]any{"id": "1", "name": "alice"},
+				},
+				Extensions: map[string]any{
+					"contentType": "application/json",
+					"token":       "xxx",
+				},
+			},
+		},
+		"query file": {
+			req: &Request{
+				URL:           url + "/graphql",
+				QueryFile:     "user.graphql",
+				OperationName: "ListUsers",
+			},
+			expect: &response{
+				Status:     "200 OK",
+				StatusCode: http.StatusOK,
+				Data: map[string]any{
+					"users": []any{map[string]any{"id": "1"}},
+				},
+				Extensions: map[string]any{
+					"contentType": "application/json",
+					"token":       "",
+				},
+			},
+		},
+		"errors": {
+			req: &Request{
+				URL:   url + "/graphql",
+				Query: `{ user(id: "2") { id } }`,
+				Header: yaml.MapSlice{
+					{Key: "content-type", Value: "application/json; charset=utf-8"},
+				},
+			},
+			expect: &response{
+				Status:     "200 OK",
+				StatusCode: http.StatusOK,
+				Data: map[string]any{
+					"user": nil,
+				},
+				Errors: []any{
+					map[string]any{"message": "user not found", "path": []any{"user"}},
+				},
+				Extensions: map[string]any{
+					"contentType": "application/json; charset=utf-8",
+					"token":       "",
+				},
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.FromT(t).WithScenarioFilepath("testdata/scenario.yaml")
+			if test.vars != nil {
+				ctx = ctx.WithVars(test.vars)
+			}
+			ctx, result, err := test.req.Invoke(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			resp, ok := result.(*response)
+			if !ok {
+				t.Fatalf("expected *response but got %T", result)
+			}
+			resp.Header = nil
+			if diff := cmp.Diff(test.expect, resp); diff != "" {
+				t.Errorf("differs (-want +got):\n%s", diff)
+			}
+			if ctx.Response() != resp {
+				t.Errorf("response is not set to the context")
+			}
+			if _, ok := ctx.Request().(*Request); !ok {
+				t.Errorf("expected *Request but got %T", ctx.Request())
+			}
+		})
+	}
+}
+
+func TestRequest_Invoke_Subscription(t *testing.T) {
+	url := startTestServer(t)
+	ws := "ws" + strings.TrimPrefix(url, "http")
+	counters := func(n int) []*Result {
+		results := make([]*Result, n)
+		for i := range n {
+			results[i] = &Result{Data: map[string]any{"counter": json.Number(fmt.Sprint(i + 1))}}
+		}
+		return results
+	}
+	tests := map[string]struct {
+		req    *Request
+		expect *response
+	}{
+		"graphql-transport-ws": {
+			req: &Request{
+				URL:   url + "/subscriptions",
+				Query: `subscription { counter }`,
+				Subscription: &SubscriptionOption{
+					ConnectionParams: map[string]any{"token": "secret"},
+				},
+			},
+			expect: &response{
+				Status:     "101 Switching Protocols",
+				StatusCode: http.StatusSwitchingProtocols,
+				Messages:   counters(3),
+			},
+		},
+		"graphql-ws": {
+			req: &Request{
+				URL:   ws + "/subscriptions",
+				Query: `subscription { counter }`,
+				Subscription: &SubscriptionOption{
+					Protocol:         SubprotocolGraphQLWS,
+					ConnectionParams: map[string]any{"token": "secret"},
+				},
+			},
+			expect: &response{
+				Status:     "101 Switching Protocols",
+				StatusCode: http.StatusSwitchingProtocols,
+				Messages:   counters(3),
+			},
+		},
+		"count": {
+			req: &Request{
+				URL:   url + "/subscriptions",
+				Query: `subscription { counter }`,
+				Subscription: &SubscriptionOption{
+					ConnectionParams: map[string]any{"token": "secret"},
+					Count:            1,
+				},
+			},
+			expect: &response{
+				Status:     "101 Switching Protocols",
+				StatusCode: http.StatusSwitchingProtocols,
+				Messages:   counters(1),
+			},
+		},
+		"until": {
+			req: &Request{
+				URL:   url + "/subscriptions",
+				Query: `subscription { counter }`,
+				Subscription: &SubscriptionOption{
+					ConnectionParams: map[string]any{"token": "secret"},
+					Until: yaml.MapSlice{
+						{Key: "data", Value: yaml.MapSlice{{Key: "counter", Value: 2}}},
+					},
+				},
+			},
+			expect: &response{
+				Status:     "101 Switching Protocols",
+				StatusCode: http.StatusSwitchingProtocols,
+				Messages:   counters(2),
+			},
+		},
+		"error": {
+			req: &Request{
+				URL:       url + "/subscriptions",
+				Query:     `subscription { counter }`,
+				Variables: map[string]any{"fail": true},
+				Subscription: &SubscriptionOption{
+					ConnectionParams: map[string]any{"token": "secret"},
+				},
+			},
+			expect: &response{
+				Status:     "101 Switching Protocols",
+				StatusCode: http.StatusSwitchingProtocols,
+				Errors: []any{
+					map[string]any{"message": "invalid subscription"},
+				},
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, result, err := test.req.Invoke(context.FromT(t))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			resp, ok := result.(*response)
+			if !ok {
+				t.Fatalf("expected *response but got %T", result)
+			}
+			resp.Header = nil
+			if diff := cmp.Diff(test.expect, resp); diff != "" {
+				t.Errorf("differs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRequest_Invoke_Subscription_Options(t *testing.T) {
+	upgrader := websocket.Upgrader{
+		Subprotocols: []string{SubprotocolGraphQLTransportWS},
+	}
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/subscriptions" || req.Header.Get("X-Token") != "default" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, req, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		var msg struct {
+			ID   string `json:"id"`
+			Type string `json:"type"`
+		}
+		if err := conn.ReadJSON(&msg); err != nil || msg.Type != "connection_init" {
+			return
+		}
+		_ = conn.WriteJSON(map[string]any{"type": "connection_ack"})
+		if err := conn.ReadJSON(&msg); err != nil || msg.Type != "subscribe" {
+			return
+		}
+		_ = conn.WriteJSON(map[string]any{"id": msg.ID, "type": "next", "payload": map[string]any{"data": map[string]any{"counter": 1}}})
+		_ = conn.WriteJSON(map[string]any{"id": msg.ID, "type": "complete"})
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+	certPath := filepath.Join(t.TempDir(), "cert.pem")
+	if err := os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}), 0o600); err != nil {
+		t.Fatalf("failed to write certificate: %s", err)
+	}
+
+	req := &Request{
+		URL:   "/subscriptions",
+		Query: `subscription { counter }`,
+		Options: &protocolhttp.RequestOptions{
+			BaseURL: srv.URL,
+			Header: map[string]string{
+				"X-Token": "default",
+			},
+			TLS: &protocolhttp.TLSOption{
+				Certificate: certPath,
+			},
+		},
+	}
+	_, result, err := req.Invoke(context.FromT(t))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resp, ok := result.(*response)
+	if !ok {
+		t.Fatalf("expected *response but got %T", result)
+	}
+	expect := []*Result{{Data: map[string]any{"counter": json.Number("1")}}}
+	if diff := cmp.Diff(expect, resp.Messages); diff != "" {
+		t.Errorf("differs (-want +got):\n%s", diff)
+	}
+}
+
+func TestRequest_Invoke_Error(t *testing.T) {
+	url := startTestServer(t)
+	tests := map[string]struct {
+		req    *Request
+		expect string
+	}{
+		"no query": {
+			req: &Request{
+				URL: url + "/graphql",
+			},
+			expect: `.query: query or queryFile must be specified`,
+		},
+		"both query and query file": {
+			req: &Request{
+				URL:       url + "/graphql",
+				Query:     `{ users { id } }`,
+				QueryFile: "user.graphql",
+			},
+			expect: `.queryFile: query and queryFile can't be specified at the same time`,
+		},
+		"syntax error": {
+			req: &Request{
+				URL:   url + "/graphql",
+				Query: "query {\n  user {\n}",
+			},
+			expect: `.query: invalid query: query:3:1: expected at least one definition, found }`,
+		},
+		"syntax error in query file": {
+			req: &Request{
+				URL:       url + "/graphql",
+				QueryFile: "invalid.graphql",
+			},
+			expect: `.queryFile: invalid query: invalid.graphql:4:1: Expected Name, found <EOF>`,
+		},
+		"query file not found": {
+			req: &Request{
+				URL:       url + "/graphql",
+				QueryFile: "not-found.graphql",
+			},
+			expect: `.queryFile: failed to read query file`,
+		},
+		"no operation name": {
+			req: &Request{
+				URL:       url + "/graphql",
+				QueryFile: "user.graphql",
+			},
+			expect: `.operationName: operationName must be specified for the query which has multiple operations`,
+		},
+		"operation not found": {
+			req: &Request{
+				URL:           url + "/graphql",
+				Query:         `query GetUser { user(id: "1") { id } }`,
+				OperationName: "ListUsers",
+			},
+			expect: `.operationName: operation "ListUsers" not found`,
+		},
+		"failed to execute template": {
+			req: &Request{
+				URL:   url + "/graphql",
+				Query: `{ users { id } }`,
+				Variables: map[string]any{
+					"id": "{{vars.id}}",
+				},
+			},
+			expect: `.variables.'id': failed to set variables: failed to execute: {{vars.id}}: ".vars.id" not found`,
+		},
+		"invalid response": {
+			req: &Request{
+				URL:   url + "/error",
+				Query: `{ users { id } }`,
+			},
+			expect: `invalid GraphQL response: 500 Internal Server Error: failed to unmarshal as JSON`,
+		},
+		"unknown subscription protocol": {
+			req: &Request{
+				URL:   url + "/subscriptions",
+				Query: `subscription { counter }`,
+				Subscription: &SubscriptionOption{
+					Protocol: "unknown",
+				},
+			},
+			expect: `.subscription.protocol: unknown protocol "unknown"`,
+		},
+		"invalid subscription timeout": {
+			req: &Request{
+				URL:   url + "/subscriptions",
+				Query: `subscription { counter }`,
+				Subscription: &SubscriptionOption{
+					Timeout: "1",
+				},
+			},
+			expect: `.subscription.timeout: invalid timeout`,
+		},
+		"connection is not acknowledged": {
+			req: &Request{
+				URL:   url + "/subscriptions",
+				Query: `subscription { counter }`,
+			},
+			expect: `connection is not acknowledged: websocket: close 4403: Forbidden`,
+		},
+		"connection error": {
+			req: &Request{
+				URL:   url + "/subscriptions",
+				Query: `subscription { counter }`,
+				Subscription: &SubscriptionOption{
+					Protocol: SubprotocolGraphQLWS,
+				},
+			},
+			expect: `connection is not acknowledged: received connection_error message: {"message":"forbidden"}`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.FromT(t).WithScenarioFilepath("testdata/scenario.yaml")
+			_, _, err := test.req.Invoke(ctx)
+			if err == nil {
+				t.Fatal("no error")
+			}
+			if got := err.Error(); !strings.Contains(got, test.expect) {
+				t.Errorf("%q doesn't contain %q", got, test.expect)
+			}
+		})
+	}
+}
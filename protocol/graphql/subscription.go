@@ -0,0 +1,340 @@
+package graphql
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/gorilla/websocket"
+
+	"github.com/zoncoen/scenarigo/context"
+	"github.com/zoncoen/scenarigo/errors"
+	"github.com/zoncoen/scenarigo/internal/streamutil"
+	"github.com/zoncoen/scenarigo/internal/wsutil"
+	"github.com/zoncoen/scenarigo/protocol/http"
+	"github.com/zoncoen/scenarigo/protocol/http/marshaler"
+	"github.com/zoncoen/scenarigo/protocol/http/unmarshaler"
+)
+
+const (
+	// SubprotocolGraphQLTransportWS represents the GraphQL over WebSocket protocol implemented by graphql-ws.
+	SubprotocolGraphQLTransportWS = "graphql-transport-ws"
+	// SubprotocolGraphQLWS represents the legacy protocol implemented by subscriptions-transport-ws.
+	SubprotocolGraphQLWS = "graphql-ws"
+
+	subscriptionID = "1"
+)
+
+// SubscriptionOption represents options for the subscription over WebSocket.
+type SubscriptionOption struct {
+	// Protocol is the WebSocket subprotocol. (default graphql-transport-ws)
+	Protocol string `yaml:"protocol,omitempty"`
+	// ConnectionParams is the payload of the connection_init message.
+	ConnectionParams interface{} `yaml:"connectionParams,omitempty"`
+	// Count is the number of results to receive.
+	Count int `yaml:"count,omitempty"`
+	// Until stops receiving when a received result matches it.
+	Until interface{} `yaml:"until,omitempty"`
+	// Timeout is the maximum duration to wait for the results. (default 5s)
+	Timeout string `yaml:"timeout,omitempty"`
+}
+
+// UnmarshalYAML implements yaml.BytesUnmarshaler interface.
+// It decodes the options in the same manner as the receive options of websocket protocol.
+func (o *SubscriptionOption) UnmarshalYAML(b []byte) error {
+	type alias SubscriptionOption
+	var v alias
+	if err := streamutil.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*o = SubscriptionOption(v)
+	return nil
+}
+
+// receiveOption returns the options for receiving the results.
+func (o *SubscriptionOption) receiveOption() *streamutil.Option {
+	return &streamutil.Option{
+		Count:   o.Count,
+		Until:   o.Until,
+		Timeout: o.Timeout,
+	}
+}
+
+type message struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// messageTypes represents the message types of the subprotocol.
+type messageTypes struct {
+	subscribe string
+	next      string
+	complete  string
+}
+
+var subprotocols = map[string]messageTypes{
+	SubprotocolGraphQLTransportWS: {
+		subscribe: "subscribe",
+		next:      "next",
+		complete:  "complete",
+	},
+	SubprotocolGraphQLWS: {
+		subscribe: "start",
+		next:      "data",
+		complete:  "stop",
+	},
+}
+
+// subscribe executes the subscription over WebSocket.
+// The base URL, header, proxy, and TLS options of http protocol are applied to the opening handshake.
+//
+//nolint:gocyclo,cyclop,maintidx
+func (r *Request) subscribe(ctx *context.Context, reqDump *Request) (*context.Context, interface{}, error) {
+	opt := r.Subscription
+	if opt == nil {
+		opt = &SubscriptionOption{} //nolint:exhaustruct
+	}
+	subprotocol := opt.Protocol
+	if subprotocol == "" {
+		subprotocol = SubprotocolGraphQLTransportWS
+	}
+	types, ok := subprotocols[subprotocol]
+	if !ok {
+		return ctx, nil, errors.ErrorPathf("subscription.protocol", "unknown protocol %q", subprotocol)
+	}
+	dialCfg, err := r.buildDialConfig(ctx)
+	if err != nil {
+		return ctx, nil, err
+	}
+	urlStr, err := websocketURL(dialCfg.URL)
+	if err != nil {
+		return ctx, nil, err
+	}
+	header, err := wsutil.BuildHeader(ctx, r.Header, dialCfg.Header)
+	if err != nil {
+		return ctx, nil, err
+	}
+	params, err := ctx.ExecuteTemplate(opt.ConnectionParams)
+	if err != nil {
+		return ctx, nil, errors.WrapPathf(err, "subscription.connectionParams", "failed to set connection params")
+	}
+	receiver, err := opt.receiveOption().Build(ctx)
+	if err != nil {
+		return ctx, nil, errors.WithPath(err, "subscription")
+	}
+
+	reqDump.URL = urlStr
+	reqDump.Header = header
+	ctx = ctx.WithRequest(reqDump)
+	if b, err := yaml.Marshal(reqDump); err == nil {
+		ctx.Reporter().Logf("request:\n%s", r.addIndent(string(b), indentNum))
+	} else {
+		ctx.Reporter().Logf("failed to dump request:\n%s", err)
+	}
+
+	conn, resp, err := wsutil.Dial(ctx, urlStr, &wsutil.DialOptions{
+		Header:          header,
+		Subprotocols:    []string{subprotocol},
+		Proxy:           dialCfg.Proxy,
+		TLSClientConfig: dialCfg.TLSClientConfig,
+	})
+	if err != nil {
+		return ctx, nil, err
+	}
+	defer conn.Close()
+
+	rvalue := &response{ //nolint:exhaustruct
+		Status:     resp.Status,
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	received, readErr := wsutil.Read(conn, done)
+	send := func(id, typ string, payload interface{}) error {
+		msg := yaml.MapSlice{}
+		if id != "" {
+			msg = append(msg, yaml.MapItem{Key: "id", Value: id})
+		}
+		msg = append(msg, yaml.MapItem{Key: "type", Value: typ})
+		if payload != nil {
+			msg = append(msg, yaml.MapItem{Key: "payload", Value: payload})
+		}
+		b, err := marshaler.Get("application/json").Marshal(msg)
+		if err != nil {
+			return err
+		}
+		return conn.WriteMessage(websocket.TextMessage, b)
+	}
+
+	if err := send("", "connection_init", params); err != nil {
+		return ctx, nil, errors.Errorf("failed to send connection_init message: %s", err)
+	}
+	ackTimer := time.NewTimer(receiver.Timeout())
+	defer ackTimer.Stop()
+ACK:
+	for {
+		select {
+		case f := <-received:
+			msg, err := decodeMessage(f)
+			if err != nil {
+				return ctx, nil, errors.Errorf("connection is not acknowledged: %s", err)
+			}
+			switch msg.Type {
+			case "connection_ack":
+				break ACK
+			case "ping":
+				if err := send("", "pong", nil); err != nil {
+					return ctx, nil, errors.Errorf("failed to send pong message: %s", err)
+				}
+			case "ka":
+			default:
+				return ctx, nil, errors.Errorf("connection is not acknowledged: received %s message: %s", msg.Type, string(msg.Payload))
+			}
+		case err := <-readErr:
+			return ctx, nil, errors.Errorf("connection is not acknowledged: %s", err)
+		case <-ackTimer.C:
+			return ctx, nil, errors.Errorf("connection is not acknowledged within %s", receiver.Timeout())
+		case <-ctx.RequestContext().Done():
+			return ctx, nil, errors.Errorf("connection is not acknowledged: %s", ctx.RequestContext().Err())
+		}
+	}
+
+	if err := send(subscriptionID, types.subscribe, requestBody{v: reqDump.payload()}); err != nil {
+		return ctx, nil, errors.Errorf("failed to send %s message: %s", types.subscribe, err)
+	}
+
+	completed := false
+	err = streamutil.Receive(ctx, receiver, received, readErr,
+		func(f wsutil.Frame) (interface{}, bool, error) {
+			msg, err := decodeMessage(f)
+			if err != nil {
+				return nil, false, errors.Errorf("failed to receive message: %s", err)
+			}
+			if msg.ID != "" && msg.ID != subscriptionID {
+				return nil, false, nil
+			}
+			switch msg.Type {
+			case types.next:
+				result, err := decodePayload(msg.Payload, decodeResult)
+				if err != nil {
+					return nil, false, errors.Errorf("invalid %s message: %s", msg.Type, err)
+				}
+				rvalue.Messages = append(rvalue.Messages, result)
+				return result, false, nil
+			case "error":
+				errs, err := decodePayload(msg.Payload, decodeErrors)
+				if err != nil {
+					return nil, false, errors.Errorf("invalid %s message: %s", msg.Type, err)
+				}
+				rvalue.Errors = errs
+				completed = true
+				return nil, true, nil
+			case "complete":
+				completed = true
+				return nil, true, nil
+			case "ping":
+				if err := send("", "pong", nil); err != nil {
+					return nil, false, errors.Errorf("failed to send pong message: %s", err)
+				}
+			}
+			return nil, false, nil
+		},
+		func(err error) error {
+			var closeErr *websocket.CloseError
+			if !errors.As(err, &closeErr) {
+				return errors.Errorf("failed to receive message: %s", err)
+			}
+			if closeErr.Code != websocket.CloseNormalClosure {
+				return errors.Errorf("connection closed by the server: %s", err)
+			}
+			completed = true
+			return nil
+		},
+	)
+	if err != nil {
+		return ctx, nil, err
+	}
+
+	// Ignore the errors because the connection may be already closed by the server.
+	if !completed {
+		_ = send(subscriptionID, types.complete, nil)
+	}
+	if subprotocol == SubprotocolGraphQLWS {
+		_ = send("", "connection_terminate", nil)
+	}
+	wsutil.Close(conn)
+
+	ctx = ctx.WithResponse(rvalue)
+	if b, err := yaml.Marshal(rvalue); err == nil {
+		ctx.Reporter().Logf("response:\n%s", r.addIndent(string(b), indentNum))
+	} else {
+		ctx.Reporter().Logf("failed to dump response:\n%s", err)
+	}
+	return ctx, rvalue, nil
+}
+
+// buildDialConfig returns the settings of the options of http protocol to connect to the URL.
+func (r *Request) buildDialConfig(ctx *context.Context) (*http.DialConfig, error) {
+	x, err := ctx.ExecuteTemplate(r.URL)
+	if err != nil {
+		return nil, errors.WrapPathf(err, "url", "failed to get URL")
+	}
+	urlStr, ok := x.(string)
+	if !ok {
+		return nil, errors.ErrorPathf("url", `URL must be "string" but got "%T"`, x)
+	}
+	return http.NewDialConfig(ctx, r.Options, urlStr)
+}
+
+// websocketURL returns the URL to connect.
+// The schemes http and https are replaced with ws and wss.
+func websocketURL(urlStr string) (string, error) {
+	u, err := url.Parse(urlStr)
+	if err != nil {
+		return "", errors.WrapPathf(err, "url", "invalid URL")
+	}
+	switch u.Scheme {
+	case "http":
+		u.Scheme = "ws"
+	case "https":
+		u.Scheme = "wss"
+	}
+	return u.String(), nil
+}
+
+// decodeMessage decodes the message of the subprotocol.
+func decodeMessage(f wsutil.Frame) (*message, error) {
+	var msg message
+	if err := json.Unmarshal(f.Data, &msg); err != nil {
+		return nil, errors.Errorf("invalid message: %s: %s", string(f.Data), err)
+	}
+	return &msg, nil
+}
+
+func decodePayload[T any](payload json.RawMessage, decode func(interface{}) (T, error)) (T, error) {
+	var v interface{}
+	if len(payload) > 0 {
+		if err := unmarshaler.Get("application/json").Unmarshal(payload, &v); err != nil {
+			var zero T
+			return zero, errors.Errorf("failed to unmarshal payload: %s", err)
+		}
+	}
+	return decode(v)
+}
+
+// decodeErrors decodes the payload of the error message.
+// The legacy protocol sends an error object instead of a list.
+func decodeErrors(v interface{}) ([]interface{}, error) {
+	switch errs := v.(type) {
+	case []interface{}:
+		return errs, nil
+	case map[string]interface{}:
+		return []interface{}{errs}, nil
+	default:
+		return nil, errors.Errorf("expected errors but got %T", v)
+	}
+}
@@ -22,11 +22,12 @@ import (
 
 // Expect represents expected response values.
 type Expect struct {
-	Code    string        `yaml:"code,omitempty"`
-	Message interface{}   `yaml:"message,omitempty"`
-	Status  ExpectStatus  `yaml:"status,omitempty"`
-	Header  yaml.MapSlice `yaml:"header,omitempty"`
-	Trailer yaml.MapSlice `yaml:"trailer,omitempty"`
+	Code     string        `yaml:"code,omitempty"`
+	Message  interface{}   `yaml:"message,omitempty"`
+	Messages []interface{} `yaml:"messages,omitempty"`
+	Status   ExpectStatus  `yaml:"status,omitempty"`
+	Header   yaml.MapSlice `yaml:"header,omitempty"`
+	Trailer  yaml.MapSlice `yaml:"trailer,omitempty"`
 
 	// for backward compatibility
 	Body interface{} `yaml:"body,omitempty"`
@@ -82,6 +83,14 @@ func (e *Expect) Build(ctx *context.Context) (assert.Assertion, error) {
 		return nil, errors.WrapPathf(err, "message", "invalid expect response message")
 	}
 
+	var msgsAssertion assert.Assertion
+	if e.Messages != nil {
+		msgsAssertion, err = assert.Build(ctx.RequestContext(), e.Messages, assert.FromTemplate(ctx))
+		if err != nil {
+			return nil, errors.WrapPathf(err, "messages", "invalid expect response messages")
+		}
+	}
+
 	return assert.AssertionFunc(func(v interface{}) error {
 		resp, ok := v.(*response)
 		if !ok {
@@ -105,6 +114,9 @@ func (e *Expect) Build(ctx *context.Context) (assert.Assertion, error) {
 		if err := msgAssertion.Assert(resp.Message); err != nil {
 			return errors.WithPath(err, "message")
 		}
+		if err := e.assertMessages(msgsAssertion, resp.Messages); err != nil {
+			return errors.WithPath(err, "messages")
+		}
 		return nil
 	}), nil
 }
@@ -177,6 +189,16 @@ func (e *Expect) assertStatusMessage(assertion assert.Assertion, msg string) err
 	return err
 }
 
+func (e *Expect) assertMessages(assertion assert.Assertion, msgs []*ProtoMessageYAMLMarshaler) error {
+	if assertion == nil {
+		return nil
+	}
+	if expect, got := len(e.Messages), len(msgs); expect != got {
+		return errors.Errorf("expected %d messages but got %d", expect, got)
+	}
+	return assertion.Assert(msgs)
+}
+
 func (e *Expect) assertStatusDetails(assertions []assert.Assertion, actualDetails []any) error {
 	if len(assertions) == 0 {
 		return nil
@@ -76,6 +76,31 @@ func TestExpect_Build(t *testing.T) {
 					}},
 				},
 			},
+			"assert stream messages": {
+				expect: &Expect{
+					Code: "OK",
+					Messages: []interface{}{
+						yaml.MapSlice{
+							yaml.MapItem{
+								Key:   "messageId",
+								Value: "1",
+							},
+						},
+						yaml.MapSlice{
+							yaml.MapItem{
+								Key:   "messageId",
+								Value: "{{$ == \"2\"}}",
+							},
+						},
+					},
+				},
+				v: &response{
+					Messages: []*ProtoMessageYAMLMarshaler{
+						{&test.EchoResponse{MessageId: "1"}},
+						{&test.EchoResponse{MessageId: "2"}},
+					},
+				},
+			},
 			"assert metadata.header": {
 				expect: &Expect{
 					Code: "OK",
@@ -352,6 +377,45 @@ func TestExpect_Build(t *testing.T) {
 				},
 				expectAssertError: true,
 			},
+			"wrong stream message": {
+				expect: &Expect{
+					Messages: []interface{}{
+						yaml.MapSlice{
+							yaml.MapItem{
+								Key:   "messageId",
+								Value: "1",
+							},
+						},
+					},
+				},
+				v: &response{
+					Messages: []*ProtoMessageYAMLMarshaler{
+						{&test.EchoResponse{MessageId: "2"}},
+					},
+				},
+				expectAssertError: true,
+				expectError:       `.messages[0].messageId: expected "1" but got "2"`,
+			},
+			"wrong number of stream messages": {
+				expect: &Expect{
+					Messages: []interface{}{
+						yaml.MapSlice{
+							yaml.MapItem{
+								Key:   "messageId",
+								Value: "1",
+							},
+						},
+					},
+				},
+				v: &response{
+					Messages: []*ProtoMessageYAMLMarshaler{
+						{&test.EchoResponse{MessageId: "1"}},
+						{&test.EchoResponse{MessageId: "2"}},
+					},
+				},
+				expectAssertError: true,
+				expectError:       ".messages: expected 1 messages but got 2",
+			},
 			"invalid type of metadata.header": {
 				expect: &Expect{
 					Code: "OK",
@@ -10,6 +10,7 @@ import (
 	protobufextractor "github.com/zoncoen/query-go/extractor/protobuf"
 
 	"github.com/zoncoen/scenarigo/protocol"
+	"github.com/zoncoen/scenarigo/schema/jsonschema"
 )
 
 var grpcProtocol = &GRPC{}
@@ -97,3 +98,36 @@ func (p *GRPC) QueryOptions() []query.Option {
 		query.CustomIsInlineStructFieldFunc(protobufextractor.OneofIsInlineStructFieldFunc()),
 	}
 }
+
+// OptionSchema implements protocol.SchemaProvider interface.
+func (p *GRPC) OptionSchema() *jsonschema.Schema {
+	return jsonschema.Reflect(Option{})
+}
+
+// RequestSchema implements protocol.SchemaProvider interface.
+func (p *GRPC) RequestSchema() *jsonschema.Schema {
+	s := jsonschema.Reflect(Request{})
+	if body := s.Property("body"); body != nil {
+		body.Description = "Deprecated: use message instead."
+	}
+	return s
+}
+
+// ExpectSchema implements protocol.SchemaProvider interface.
+func (p *GRPC) ExpectSchema() *jsonschema.Schema {
+	s := jsonschema.Reflect(Expect{})
+	// the status code can be written as a number or a code name
+	code := func() *jsonschema.Schema {
+		return &jsonschema.Schema{
+			Type: jsonschema.Types{jsonschema.TypeString, jsonschema.TypeInteger},
+		}
+	}
+	s.SetProperty("code", code())
+	if status := s.Property("status"); status != nil {
+		status.SetProperty("code", code())
+	}
+	if body := s.Property("body"); body != nil {
+		body.Description = "Deprecated: use message instead."
+	}
+	return s
+}
@@ -16,6 +16,11 @@ type ServiceDescriptorResolver interface {
 	ResolveService(protoreflect.FullName) (protoreflect.ServiceDescriptor, error)
 }
 
+// MessageDescriptorResolver is an interface to resolve message descriptors.
+type MessageDescriptorResolver interface {
+	ResolveMessage(protoreflect.FullName) (protoreflect.MessageDescriptor, error)
+}
+
 // NewCompiler creates a new compiler with the given import paths.
 func NewCompiler(imports []string) *Compiler {
 	return &Compiler{
@@ -73,6 +78,23 @@ func (fds FileDescriptors) ResolveService(name protoreflect.FullName) (protorefl
 	return svcDesc, nil
 }
 
+// ResolveMessage resolves a message descriptor by the given full name.
+// The messages defined in the imported files are also resolved.
+func (fds FileDescriptors) ResolveMessage(name protoreflect.FullName) (protoreflect.MessageDescriptor, error) {
+	for _, f := range fds.Files() {
+		d, err := linker.ResolverFromFile(f).FindDescriptorByName(name)
+		if err != nil {
+			continue
+		}
+		md, ok := d.(protoreflect.MessageDescriptor)
+		if !ok {
+			return nil, fmt.Errorf("%q is not a message", name)
+		}
+		return md, nil
+	}
+	return nil, fmt.Errorf("message %q not found", name)
+}
+
 // Files returns the underlying protobuf files.
 func (fds FileDescriptors) Files() linker.Files {
 	return linker.Files(fds)
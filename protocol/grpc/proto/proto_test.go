@@ -13,12 +13,14 @@ func TestCompiler(t *testing.T) {
 		imports []string
 		files   []string
 		service string
+		message string
 	}{
 		"only files": {
 			files: []string{
 				"./testdata/foo.proto",
 			},
 			service: "scenarigo.testdata.foo.Foo",
+			message: "scenarigo.testdata.foo.Empty",
 		},
 		"with imports": {
 			imports: []string{
@@ -28,6 +30,7 @@ func TestCompiler(t *testing.T) {
 				"bar.proto",
 			},
 			service: "scenarigo.testdata.bar.Bar",
+			message: "scenarigo.testdata.empty.Empty",
 		},
 	}
 	for name, test := range tests {
@@ -53,6 +56,45 @@ func TestCompiler(t *testing.T) {
 			if _, err := fds.ResolveService(protoreflect.FullName(test.service)); err != nil {
 				t.Fatalf("failed to get service: %s", err)
 			}
+
+			md, err := fds.ResolveMessage(protoreflect.FullName(test.message))
+			if err != nil {
+				t.Fatalf("failed to get message: %s", err)
+			}
+			if got, expect := md.FullName(), protoreflect.FullName(test.message); got != expect {
+				t.Errorf("expect %q but got %q", expect, got)
+			}
+		})
+	}
+}
+
+func TestFileDescriptors_ResolveMessage_Error(t *testing.T) {
+	fds, err := NewCompiler(nil).Compile(context.Background(), []string{"./testdata/foo.proto"})
+	if err != nil {
+		t.Fatalf("failed to compile: %s", err)
+	}
+	tests := map[string]struct {
+		name   string
+		expect string
+	}{
+		"not found": {
+			name:   "scenarigo.testdata.foo.Unknown",
+			expect: `message "scenarigo.testdata.foo.Unknown" not found`,
+		},
+		"not message": {
+			name:   "scenarigo.testdata.foo.Foo",
+			expect: `"scenarigo.testdata.foo.Foo" is not a message`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := fds.ResolveMessage(protoreflect.FullName(test.name))
+			if err == nil {
+				t.Fatal("no error")
+			}
+			if got := err.Error(); got != test.expect {
+				t.Errorf("expect %q but got %q", test.expect, got)
+			}
 		})
 	}
 }
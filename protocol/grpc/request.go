@@ -22,12 +22,17 @@ import (
 
 	"github.com/goccy/go-yaml"
 	"github.com/zoncoen/query-go"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/zoncoen/scenarigo/context"
 	"github.com/zoncoen/scenarigo/errors"
 	"github.com/zoncoen/scenarigo/internal/filepathutil"
 	"github.com/zoncoen/scenarigo/internal/queryutil"
 	"github.com/zoncoen/scenarigo/internal/reflectutil"
+	"github.com/zoncoen/scenarigo/internal/tracing"
 	"github.com/zoncoen/scenarigo/internal/yamlutil"
 )
 
@@ -47,6 +52,7 @@ type Request struct {
 	Method   string          `yaml:"method,omitempty"`
 	Metadata interface{}     `yaml:"metadata,omitempty"`
 	Message  interface{}     `yaml:"message,omitempty"`
+	Messages []interface{}   `yaml:"messages,omitempty"`
 	Options  *RequestOptions `yaml:"options,omitempty"`
 
 	// for backward compatibility
@@ -160,9 +166,10 @@ func (r RequestExtractor) ExtractByKey(key string) (interface{}, bool) {
 }
 
 type request struct {
-	Method   string                     `yaml:"method,omitempty"`
-	Metadata any                        `yaml:"metadata,omitempty"`
-	Message  *ProtoMessageYAMLMarshaler `yaml:"message,omitempty"`
+	Method   string                       `yaml:"method,omitempty"`
+	Metadata any                          `yaml:"metadata,omitempty"`
+	Message  *ProtoMessageYAMLMarshaler   `yaml:"message,omitempty"`
+	Messages []*ProtoMessageYAMLMarshaler `yaml:"messages,omitempty"`
 }
 
 type ProtoMessageYAMLMarshaler struct {
@@ -180,10 +187,11 @@ func (m *ProtoMessageYAMLMarshaler) MarshalYAML(_ gocontext.Context) ([]byte, er
 }
 
 type response struct {
-	Status  *responseStatus            `yaml:"status,omitempty"`
-	Header  *yamlutil.MDMarshaler      `yaml:"header,omitempty"`
-	Trailer *yamlutil.MDMarshaler      `yaml:"trailer,omitempty"`
-	Message *ProtoMessageYAMLMarshaler `yaml:"message,omitempty"`
+	Status   *responseStatus              `yaml:"status,omitempty"`
+	Header   *yamlutil.MDMarshaler        `yaml:"header,omitempty"`
+	Trailer  *yamlutil.MDMarshaler        `yaml:"trailer,omitempty"`
+	Message  *ProtoMessageYAMLMarshaler   `yaml:"message,omitempty"`
+	Messages []*ProtoMessageYAMLMarshaler `yaml:"messages,omitempty"`
 }
 
 type responseStatus struct {
@@ -335,30 +343,74 @@ func (r *Request) Invoke(ctx *context.Context) (*context.Context, interface{}, e
 	if err != nil {
 		return ctx, nil, err
 	}
-	reqMsg, err := client.buildRequestMessage(ctx)
-	if err != nil {
-		return ctx, nil, err
+	ctx, span := r.startSpan(ctx)
+	defer span.End()
+	st := client.streamType()
+	if r.Messages != nil && !st.isClientStreaming() {
+		return ctx, nil, errors.ErrorPathf("messages", "messages can't be used for %s RPC, use message field instead", st)
 	}
-	ctx = r.dumpRequest(ctx, reqMsg)
 
 	var header, trailer metadata.MD
 	callOpts := []grpc.CallOption{
 		grpc.Header(&header),
 		grpc.Trailer(&trailer),
 	}
-	respMsg, sts, err := client.invoke(ctx.RequestContext(), reqMsg, callOpts...)
-	if err != nil {
-		return ctx, nil, err
-	}
 	resp := &response{
 		Status: &responseStatus{
 			status.New(codes.OK, ""),
 		},
-		Message: &ProtoMessageYAMLMarshaler{respMsg},
+	}
+	var sts *status.Status
+	if st == streamTypeUnary {
+		reqMsg, err := client.buildRequestMessage(ctx)
+		if err != nil {
+			return ctx, nil, err
+		}
+		ctx = r.dumpRequest(ctx, reqMsg)
+
+		var respMsg proto.Message
+		respMsg, sts, err = client.invoke(ctx.RequestContext(), reqMsg, callOpts...)
+		if err != nil {
+			span.SetStatus(otelcodes.Error, err.Error())
+			return ctx, nil, err
+		}
+		resp.Message = &ProtoMessageYAMLMarshaler{respMsg}
+	} else {
+		var reqMsgs []proto.Message
+		if st.isClientStreaming() {
+			reqMsgs, err = client.buildRequestMessages(ctx)
+		} else {
+			var reqMsg proto.Message
+			reqMsg, err = client.buildRequestMessage(ctx)
+			reqMsgs = []proto.Message{reqMsg}
+		}
+		if err != nil {
+			return ctx, nil, err
+		}
+		ctx = r.dumpStreamRequest(ctx, st, reqMsgs)
+
+		var respMsgs []proto.Message
+		respMsgs, sts, err = client.invokeStream(ctx.RequestContext(), reqMsgs, callOpts...)
+		if err != nil {
+			span.SetStatus(otelcodes.Error, err.Error())
+			return ctx, nil, err
+		}
+		if st.isServerStreaming() {
+			resp.Messages = make([]*ProtoMessageYAMLMarshaler, len(respMsgs))
+			for i, m := range respMsgs {
+				resp.Messages[i] = &ProtoMessageYAMLMarshaler{m}
+			}
+		} else if len(respMsgs) > 0 {
+			resp.Message = &ProtoMessageYAMLMarshaler{respMsgs[0]}
+		}
 	}
 	if sts != nil {
 		resp.Status = &responseStatus{sts}
 	}
+	span.SetAttributes(attribute.Int("rpc.grpc.status_code", int(resp.Status.Code())))
+	if resp.Status.Code() != codes.OK {
+		span.SetStatus(otelcodes.Error, resp.Status.Message())
+	}
 	if len(header) > 0 {
 		resp.Header = yamlutil.NewMDMarshaler(header)
 	}
@@ -376,8 +428,11 @@ func (r *Request) Invoke(ctx *context.Context) (*context.Context, interface{}, e
 }
 
 type serviceClient interface {
+	streamType() streamType
 	buildRequestMessage(*context.Context) (proto.Message, error)
+	buildRequestMessages(*context.Context) ([]proto.Message, error)
 	invoke(gocontext.Context, proto.Message, ...grpc.CallOption) (proto.Message, *status.Status, error)
+	invokeStream(gocontext.Context, []proto.Message, ...grpc.CallOption) ([]proto.Message, *status.Status, error)
 }
 
 func (r *Request) buildClient(ctx *context.Context, opts *RequestOptions) (serviceClient, error) {
@@ -418,12 +473,52 @@ func (r *Request) appendMetadata(ctx *context.Context) (*context.Context, error)
 	), nil
 }
 
+// startSpan starts a span for the RPC and propagates its trace context by the metadata.
+func (r *Request) startSpan(ctx *context.Context) (*context.Context, trace.Span) {
+	reqCtx, span := tracing.Start(ctx.RequestContext(), fmt.Sprintf("gRPC %s/%s", r.Service, r.Method),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("rpc.system", "grpc"),
+			attribute.String("rpc.service", r.Service),
+			attribute.String("rpc.method", r.Method),
+		),
+	)
+	carrier := propagation.MapCarrier{}
+	tracing.Inject(reqCtx, carrier)
+	pairs := make([]string, 0, len(carrier)*2)
+	for _, k := range carrier.Keys() {
+		pairs = append(pairs, k, carrier.Get(k))
+	}
+	if len(pairs) > 0 {
+		reqCtx = metadata.AppendToOutgoingContext(reqCtx, pairs...)
+	}
+	return ctx.WithRequestContext(reqCtx), span
+}
+
 func (r *Request) dumpRequest(ctx *context.Context, reqMsg proto.Message) *context.Context {
 	//nolint:exhaustruct
-	dumpReq := &request{
+	return r.dump(ctx, &request{
 		Method:  r.Method,
 		Message: &ProtoMessageYAMLMarshaler{reqMsg},
+	})
+}
+
+func (r *Request) dumpStreamRequest(ctx *context.Context, st streamType, reqMsgs []proto.Message) *context.Context {
+	if !st.isClientStreaming() && len(reqMsgs) == 1 {
+		return r.dumpRequest(ctx, reqMsgs[0])
+	}
+	msgs := make([]*ProtoMessageYAMLMarshaler, len(reqMsgs))
+	for i, m := range reqMsgs {
+		msgs[i] = &ProtoMessageYAMLMarshaler{m}
 	}
+	//nolint:exhaustruct
+	return r.dump(ctx, &request{
+		Method:   r.Method,
+		Messages: msgs,
+	})
+}
+
+func (r *Request) dump(ctx *context.Context, dumpReq *request) *context.Context {
 	reqMD, _ := metadata.FromOutgoingContext(ctx.RequestContext())
 	if len(reqMD) > 0 {
 		dumpReq.Metadata = yamlutil.NewMDMarshaler(reqMD)
@@ -20,6 +20,11 @@ import (
 type customServiceClient struct {
 	r      *Request
 	method reflect.Value
+	st     streamType
+
+	// message types of streaming RPCs
+	reqType  reflect.Type
+	respType reflect.Type
 }
 
 func newCustomServiceClient(r *Request, v reflect.Value) (*customServiceClient, error) {
@@ -41,6 +46,20 @@ func newCustomServiceClient(r *Request, v reflect.Value) (*customServiceClient,
 		}
 	}
 
+	if isStreamMethod(method) {
+		st, reqType, respType, err := validateStreamMethod(method)
+		if err != nil {
+			return nil, errors.ErrorPathf("method", `"%s.%s" must be a streaming RPC method of a gRPC client: %s`, r.Client, r.Method, err)
+		}
+		return &customServiceClient{
+			r:        r,
+			method:   method,
+			st:       st,
+			reqType:  reqType,
+			respType: respType,
+		}, nil
+	}
+
 	if err := validateMethod(method); err != nil {
 		return nil, errors.ErrorPathf("method", `"%s.%s" must be "func(context.Context, proto.Message, ...grpc.CallOption) (proto.Message, error): %s"`, r.Client, r.Method, err)
 	}
@@ -48,11 +67,20 @@ func newCustomServiceClient(r *Request, v reflect.Value) (*customServiceClient,
 	return &customServiceClient{
 		r:      r,
 		method: method,
+		st:     streamTypeUnary,
 	}, nil
 }
 
+func (client *customServiceClient) streamType() streamType {
+	return client.st
+}
+
 func (client *customServiceClient) buildRequestMessage(ctx *context.Context) (proto.Message, error) {
-	req := reflect.New(client.method.Type().In(1).Elem()).Interface()
+	reqType := client.method.Type().In(1)
+	if client.st != streamTypeUnary {
+		reqType = client.reqType
+	}
+	req := reflect.New(reqType.Elem()).Interface()
 	if err := buildRequestMsg(ctx, req, client.r.Message); err != nil {
 		return nil, errors.WrapPathf(err, "message", "failed to build request message")
 	}
@@ -63,6 +91,13 @@ func (client *customServiceClient) buildRequestMessage(ctx *context.Context) (pr
 	return reqMsg, nil
 }
 
+func (client *customServiceClient) buildRequestMessages(ctx *context.Context) ([]proto.Message, error) {
+	return buildRequestMsgs(ctx, client.r, func() proto.Message {
+		//nolint:forcetypeassert // the type is validated by validateStreamMethod
+		return reflect.New(client.reqType.Elem()).Interface().(proto.Message)
+	})
+}
+
 func (client *customServiceClient) invoke(ctx gocontext.Context, reqMsg proto.Message, opts ...grpc.CallOption) (proto.Message, *status.Status, error) {
 	in := []reflect.Value{
 		reflect.ValueOf(ctx),
@@ -105,6 +140,56 @@ func (client *customServiceClient) invoke(ctx gocontext.Context, reqMsg proto.Me
 	return respMsg, sts, nil
 }
 
+func (client *customServiceClient) invokeStream(ctx gocontext.Context, reqMsgs []proto.Message, opts ...grpc.CallOption) ([]proto.Message, *status.Status, error) {
+	in := []reflect.Value{
+		reflect.ValueOf(ctx),
+	}
+	if client.st == streamTypeServer {
+		// generated server streaming methods send the request message and close the send direction
+		if len(reqMsgs) > 0 {
+			in = append(in, reflect.ValueOf(reqMsgs[0]))
+		} else {
+			in = append(in, reflect.New(client.reqType.Elem()))
+		}
+		reqMsgs = nil
+	}
+	for _, o := range opts {
+		in = append(in, reflect.ValueOf(o))
+	}
+
+	rvalues := client.method.Call(in)
+	if len(rvalues) != 2 {
+		return nil, nil, errors.Errorf("expected return value length of method call is 2 but %d", len(rvalues))
+	}
+	if !rvalues[1].IsValid() {
+		return nil, nil, errors.New("second return value is invalid")
+	}
+	if !rvalues[1].IsNil() {
+		callErr, ok := rvalues[1].Interface().(error)
+		if !ok {
+			return nil, nil, errors.Errorf("expected second return value is error but %T", rvalues[1].Interface())
+		}
+		sts, ok := status.FromError(callErr)
+		if !ok {
+			return nil, nil, errors.Errorf(`expected gRPC status error but got %T: "%s"`, callErr, callErr.Error())
+		}
+		return nil, sts, nil
+	}
+	if !rvalues[0].IsValid() || rvalues[0].IsNil() {
+		return nil, nil, errors.New("first return value is invalid")
+	}
+	stream, ok := rvalues[0].Interface().(grpc.ClientStream)
+	if !ok {
+		return nil, nil, errors.Errorf("expected first return value is grpc.ClientStream but %T", rvalues[0].Interface())
+	}
+
+	respMsgs, sts := exchangeMessages(stream, reqMsgs, func() proto.Message {
+		//nolint:forcetypeassert // the type is validated by validateStreamMethod
+		return reflect.New(client.respType.Elem()).Interface().(proto.Message)
+	})
+	return respMsgs, sts, nil
+}
+
 func validateMethod(method reflect.Value) error {
 	if !method.IsValid() {
 		return errors.New("invalid")
@@ -142,6 +227,83 @@ func validateMethod(method reflect.Value) error {
 	return nil
 }
 
+// isStreamMethod reports whether method seems to be a streaming RPC method.
+func isStreamMethod(method reflect.Value) bool {
+	if !method.IsValid() || method.Kind() != reflect.Func || method.IsNil() {
+		return false
+	}
+	mt := method.Type()
+	return mt.NumOut() > 0 && mt.Out(0).Implements(typeClientStream)
+}
+
+// validateStreamMethod validates method and returns the kind of the stream and the types of request and response messages.
+//
+//	server streaming:        func(context.Context, proto.Message, ...grpc.CallOption) (grpc.ClientStream, error)
+//	client streaming:        func(context.Context, ...grpc.CallOption) (grpc.ClientStream, error) with CloseAndRecv method
+//	bidirectional streaming: func(context.Context, ...grpc.CallOption) (grpc.ClientStream, error)
+func validateStreamMethod(method reflect.Value) (streamType, reflect.Type, reflect.Type, error) {
+	mt := method.Type()
+	if n := mt.NumOut(); n != 2 {
+		return 0, nil, nil, errors.Errorf("number of return values must be 2 but got %d", n)
+	}
+	if t := mt.Out(1); !t.Implements(reflectutil.TypeError) {
+		return 0, nil, nil, errors.Errorf("second return value must be error but got %s", t.String())
+	}
+	if mt.NumIn() == 0 {
+		return 0, nil, nil, errors.New("number of arguments must be 2 or 3 but got 0")
+	}
+	if t := mt.In(0); !t.Implements(typeContext) {
+		return 0, nil, nil, errors.Errorf("first argument must be context.Context but got %s", t.String())
+	}
+	if t := mt.In(mt.NumIn() - 1); t != typeCallOpts {
+		return 0, nil, nil, errors.Errorf("last argument must be []grpc.CallOption but got %s", t.String())
+	}
+
+	streamT := mt.Out(0)
+	var (
+		st       streamType
+		reqType  reflect.Type
+		respType reflect.Type
+	)
+	switch mt.NumIn() {
+	case 3:
+		st = streamTypeServer
+		reqType = mt.In(1)
+		recv, ok := streamT.MethodByName("Recv")
+		if !ok {
+			return 0, nil, nil, errors.Errorf("%s doesn't have Recv method", streamT.String())
+		}
+		respType = recv.Type.Out(0)
+	case 2:
+		send, ok := streamT.MethodByName("Send")
+		if !ok {
+			return 0, nil, nil, errors.Errorf("%s doesn't have Send method", streamT.String())
+		}
+		if n := send.Type.NumIn(); n == 0 {
+			return 0, nil, nil, errors.Errorf("%s.Send must have an argument", streamT.String())
+		}
+		reqType = send.Type.In(send.Type.NumIn() - 1)
+		if recv, ok := streamT.MethodByName("CloseAndRecv"); ok {
+			st = streamTypeClient
+			respType = recv.Type.Out(0)
+		} else if recv, ok := streamT.MethodByName("Recv"); ok {
+			st = streamTypeBidi
+			respType = recv.Type.Out(0)
+		} else {
+			return 0, nil, nil, errors.Errorf("%s doesn't have Recv or CloseAndRecv method", streamT.String())
+		}
+	default:
+		return 0, nil, nil, errors.Errorf("number of arguments must be 2 or 3 but got %d", mt.NumIn())
+	}
+	if !reqType.Implements(typeMessage) || reqType.Kind() != reflect.Ptr {
+		return 0, nil, nil, errors.Errorf("request message must be proto.Message but got %s", reqType.String())
+	}
+	if !respType.Implements(typeMessage) || respType.Kind() != reflect.Ptr {
+		return 0, nil, nil, errors.Errorf("response message must be proto.Message but got %s", respType.String())
+	}
+	return st, reqType, respType, nil
+}
+
 func buildRequestMsg(ctx *context.Context, req interface{}, src interface{}) error {
 	x, err := ctx.ExecuteTemplate(src)
 	if err != nil {
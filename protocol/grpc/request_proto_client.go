@@ -150,6 +150,19 @@ func newProtoClient(ctx *context.Context, r *Request, opts *RequestOptions) (*pr
 	}, nil
 }
 
+func (client *protoClient) streamType() streamType {
+	switch {
+	case client.md.IsStreamingClient() && client.md.IsStreamingServer():
+		return streamTypeBidi
+	case client.md.IsStreamingClient():
+		return streamTypeClient
+	case client.md.IsStreamingServer():
+		return streamTypeServer
+	default:
+		return streamTypeUnary
+	}
+}
+
 func (client *protoClient) buildRequestMessage(ctx *context.Context) (proto.Message, error) {
 	in := dynamicpb.NewMessage(client.md.Input())
 	if err := buildRequestMsg(ctx, in, client.r.Message); err != nil {
@@ -158,6 +171,12 @@ func (client *protoClient) buildRequestMessage(ctx *context.Context) (proto.Mess
 	return in, nil
 }
 
+func (client *protoClient) buildRequestMessages(ctx *context.Context) ([]proto.Message, error) {
+	return buildRequestMsgs(ctx, client.r, func() proto.Message {
+		return dynamicpb.NewMessage(client.md.Input())
+	})
+}
+
 func (client *protoClient) invoke(ctx gocontext.Context, in proto.Message, opts ...grpc.CallOption) (proto.Message, *status.Status, error) {
 	out := dynamicpb.NewMessage(client.md.Output())
 	var sts *status.Status
@@ -166,3 +185,19 @@ func (client *protoClient) invoke(ctx gocontext.Context, in proto.Message, opts
 	}
 	return out, sts, nil
 }
+
+func (client *protoClient) invokeStream(ctx gocontext.Context, in []proto.Message, opts ...grpc.CallOption) ([]proto.Message, *status.Status, error) {
+	desc := &grpc.StreamDesc{
+		StreamName:    string(client.md.Name()),
+		ServerStreams: client.md.IsStreamingServer(),
+		ClientStreams: client.md.IsStreamingClient(),
+	}
+	stream, err := client.conn.NewStream(ctx, desc, client.fullMethodName, opts...)
+	if err != nil {
+		return nil, status.Convert(err), nil
+	}
+	out, sts := exchangeMessages(stream, in, func() proto.Message {
+		return dynamicpb.NewMessage(client.md.Output())
+	})
+	return out, sts, nil
+}
@@ -2,11 +2,16 @@ package grpc
 
 import (
 	"bytes"
+	gocontext "context"
 	"reflect"
 	"strings"
 	"testing"
 
+	otelcodes "go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
@@ -23,6 +28,7 @@ import (
 	"github.com/zoncoen/scenarigo/internal/mockutil"
 	"github.com/zoncoen/scenarigo/internal/queryutil"
 	"github.com/zoncoen/scenarigo/internal/testutil"
+	"github.com/zoncoen/scenarigo/internal/tracing"
 	"github.com/zoncoen/scenarigo/internal/yamlutil"
 	"github.com/zoncoen/scenarigo/reporter"
 	testpb "github.com/zoncoen/scenarigo/testdata/gen/pb/test"
@@ -339,6 +345,50 @@ func TestRequest_Invoke(t *testing.T) {
 	})
 }
 
+func TestRequest_Invoke_Tracing(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	var md metadata.MD
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := testpb.NewMockTestClient(ctrl)
+	client.EXPECT().Echo(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx gocontext.Context, _ *testpb.EchoRequest, _ ...grpc.CallOption) (*testpb.EchoResponse, error) {
+			md, _ = metadata.FromOutgoingContext(ctx)
+			return nil, status.New(codes.Unauthenticated, "unauthenticated").Err()
+		},
+	)
+
+	r := &Request{
+		Client: "{{vars.client}}",
+		Method: "Echo",
+	}
+	ctx := context.FromT(t).WithVars(map[string]interface{}{
+		"client": client,
+	})
+	ctx = ctx.WithRequestContext(tracing.WithTracerProvider(ctx.RequestContext(), tp))
+	if _, _, err := r.Invoke(ctx); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	spans := sr.Ended()
+	if got, expect := len(spans), 1; got != expect {
+		t.Fatalf("expect %d spans but got %d", expect, got)
+	}
+	span := spans[0]
+	if got, expect := span.Name(), "gRPC /Echo"; got != expect {
+		t.Errorf("expect span name %q but got %q", expect, got)
+	}
+	if got, expect := span.Status().Code, otelcodes.Error; got != expect {
+		t.Errorf("expect status %s but got %s", expect, got)
+	}
+	sc := span.SpanContext()
+	if got, expect := md.Get("traceparent"), []string{"00-" + sc.TraceID().String() + "-" + sc.SpanID().String() + "-01"}; !reflect.DeepEqual(got, expect) {
+		t.Errorf("expect traceparent %q but got %q", expect, got)
+	}
+}
+
 func TestRequest_Invoke_Log(t *testing.T) {
 	req := &testpb.EchoRequest{MessageId: "1", MessageBody: "hello"}
 	resp := &testpb.EchoResponse{MessageId: "1", MessageBody: "hello"}
@@ -0,0 +1,111 @@
+package grpc
+
+import (
+	"fmt"
+	"io"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/zoncoen/scenarigo/context"
+	"github.com/zoncoen/scenarigo/errors"
+)
+
+// streamType represents a kind of RPC.
+type streamType int
+
+const (
+	streamTypeUnary streamType = iota
+	streamTypeServer
+	streamTypeClient
+	streamTypeBidi
+)
+
+// String returns t as a string.
+func (t streamType) String() string {
+	switch t {
+	case streamTypeServer:
+		return "server streaming"
+	case streamTypeClient:
+		return "client streaming"
+	case streamTypeBidi:
+		return "bidirectional streaming"
+	default:
+		return "unary"
+	}
+}
+
+// isClientStreaming reports whether the client sends a stream of messages.
+func (t streamType) isClientStreaming() bool {
+	return t == streamTypeClient || t == streamTypeBidi
+}
+
+// isServerStreaming reports whether the server sends a stream of messages.
+func (t streamType) isServerStreaming() bool {
+	return t == streamTypeServer || t == streamTypeBidi
+}
+
+// exchangeMessages sends reqs to the stream and receives messages until the end of the stream.
+// Receiving runs concurrently with sending to avoid a deadlock caused by the flow control of bidirectional streams.
+func exchangeMessages(stream grpc.ClientStream, reqs []proto.Message, newResp func() proto.Message) ([]proto.Message, *status.Status) {
+	type result struct {
+		msgs []proto.Message
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		var res result
+		for {
+			msg := newResp()
+			if err := stream.RecvMsg(msg); err != nil {
+				if !errors.Is(err, io.EOF) {
+					res.err = err
+				}
+				break
+			}
+			res.msgs = append(res.msgs, msg)
+		}
+		done <- res
+	}()
+
+	for _, req := range reqs {
+		// SendMsg returns io.EOF if the stream was terminated by the server.
+		// The actual status can be discovered by RecvMsg.
+		if err := stream.SendMsg(req); err != nil {
+			break
+		}
+	}
+	_ = stream.CloseSend()
+
+	res := <-done
+	var sts *status.Status
+	if res.err != nil {
+		sts = status.Convert(res.err)
+	}
+	return res.msgs, sts
+}
+
+// buildRequestMsgs builds the request messages of client streaming RPCs.
+func buildRequestMsgs(ctx *context.Context, r *Request, newReq func() proto.Message) ([]proto.Message, error) {
+	// for convenience, a client streaming RPC with the message field sends the single message
+	if r.Messages == nil {
+		if r.Message == nil {
+			return nil, nil
+		}
+		req := newReq()
+		if err := buildRequestMsg(ctx, req, r.Message); err != nil {
+			return nil, errors.WrapPathf(err, "message", "failed to build request message")
+		}
+		return []proto.Message{req}, nil
+	}
+	reqs := make([]proto.Message, len(r.Messages))
+	for i, m := range r.Messages {
+		req := newReq()
+		if err := buildRequestMsg(ctx, req, m); err != nil {
+			return nil, errors.WrapPathf(err, fmt.Sprintf("messages[%d]", i), "failed to build request message")
+		}
+		reqs[i] = req
+	}
+	return reqs, nil
+}
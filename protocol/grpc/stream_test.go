@@ -0,0 +1,344 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/zoncoen/scenarigo/context"
+	"github.com/zoncoen/scenarigo/internal/ptr"
+	"github.com/zoncoen/scenarigo/internal/testutil"
+	testpb "github.com/zoncoen/scenarigo/testdata/gen/pb/test"
+)
+
+type testStreamServer struct{}
+
+func (testStreamServer) ServerStreamEcho(req *testpb.EchoRequest, stream testpb.StreamTest_ServerStreamEchoServer) error {
+	if req.GetMessageBody() == "error" {
+		return status.Error(codes.InvalidArgument, "invalid argument")
+	}
+	for i := range 3 {
+		if err := stream.Send(&testpb.EchoResponse{
+			MessageId:   fmt.Sprintf("%s-%d", req.GetMessageId(), i),
+			MessageBody: req.GetMessageBody(),
+		}); err != nil {
+			return err
+		}
+	}
+	stream.SetTrailer(metadata.Pairs("count", "3"))
+	return nil
+}
+
+func (testStreamServer) ClientStreamEcho(stream testpb.StreamTest_ClientStreamEchoServer) error {
+	var ids, bodies []string
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return err
+		}
+		ids = append(ids, req.GetMessageId())
+		bodies = append(bodies, req.GetMessageBody())
+	}
+	return stream.SendAndClose(&testpb.EchoResponse{
+		MessageId:   strings.Join(ids, ","),
+		MessageBody: strings.Join(bodies, ","),
+	})
+}
+
+func (testStreamServer) BidiStreamEcho(stream testpb.StreamTest_BidiStreamEchoServer) error {
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if req.GetMessageBody() == "error" {
+			return status.Error(codes.InvalidArgument, "invalid argument")
+		}
+		if err := stream.Send(&testpb.EchoResponse{
+			MessageId:   req.GetMessageId(),
+			MessageBody: req.GetMessageBody(),
+		}); err != nil {
+			return err
+		}
+	}
+}
+
+func TestRequest_Invoke_Stream(t *testing.T) {
+	target := testutil.StartTestGRPCServer(t,
+		testutil.TestGRPCServerFunc(nil),
+		testutil.EnableReflection(),
+		testutil.WithStreamTestServer(testStreamServer{}),
+	)
+	t.Cleanup(func() { connPool.closeConnection(target) })
+	conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	clients := map[string]func(method string) *Request{
+		"custom client": func(method string) *Request {
+			return &Request{
+				Client: "{{vars.client}}",
+				Method: method,
+			}
+		},
+		"proto client": func(method string) *Request {
+			return &Request{
+				Target:  "{{vars.target}}",
+				Service: testpb.StreamTest_ServiceDesc.ServiceName,
+				Method:  method,
+				Options: &RequestOptions{
+					Proto: &ProtoOption{
+						Imports: []string{"../../testdata/proto"},
+						Files:   []string{"test/stream.proto"},
+					},
+					Auth: &AuthOption{
+						Insecure: ptr.To(true),
+					},
+				},
+			}
+		},
+		"proto reflection client": func(method string) *Request {
+			return &Request{
+				Target:  "{{vars.target}}",
+				Service: testpb.StreamTest_ServiceDesc.ServiceName,
+				Method:  method,
+				Options: &RequestOptions{
+					Auth: &AuthOption{
+						Insecure: ptr.To(true),
+					},
+				},
+			}
+		},
+	}
+	tests := map[string]struct {
+		method          string
+		message         any
+		messages        []any
+		expectCode      codes.Code
+		expectMessage   *testpb.EchoResponse
+		expectMessages  []*testpb.EchoResponse
+		expectTrailer   metadata.MD
+		expectDumpCount int
+		expectError     string
+	}{
+		"server streaming": {
+			method: "ServerStreamEcho",
+			message: yaml.MapSlice{
+				yaml.MapItem{Key: "messageId", Value: "1"},
+				yaml.MapItem{Key: "messageBody", Value: "hello"},
+			},
+			expectCode: codes.OK,
+			expectMessages: []*testpb.EchoResponse{
+				{MessageId: "1-0", MessageBody: "hello"},
+				{MessageId: "1-1", MessageBody: "hello"},
+				{MessageId: "1-2", MessageBody: "hello"},
+			},
+			expectTrailer: metadata.Pairs("count", "3"),
+		},
+		"server streaming returns error": {
+			method: "ServerStreamEcho",
+			message: yaml.MapSlice{
+				yaml.MapItem{Key: "messageBody", Value: "error"},
+			},
+			expectCode: codes.InvalidArgument,
+		},
+		"client streaming": {
+			method: "ClientStreamEcho",
+			messages: []any{
+				yaml.MapSlice{
+					yaml.MapItem{Key: "messageId", Value: "1"},
+					yaml.MapItem{Key: "messageBody", Value: "hello"},
+				},
+				yaml.MapSlice{
+					yaml.MapItem{Key: "messageId", Value: "2"},
+					yaml.MapItem{Key: "messageBody", Value: "world"},
+				},
+			},
+			expectCode: codes.OK,
+			expectMessage: &testpb.EchoResponse{
+				MessageId:   "1,2",
+				MessageBody: "hello,world",
+			},
+		},
+		"client streaming with message": {
+			method: "ClientStreamEcho",
+			message: yaml.MapSlice{
+				yaml.MapItem{Key: "messageId", Value: "1"},
+			},
+			expectCode: codes.OK,
+			expectMessage: &testpb.EchoResponse{
+				MessageId: "1",
+			},
+		},
+		"bidirectional streaming": {
+			method: "BidiStreamEcho",
+			messages: []any{
+				yaml.MapSlice{
+					yaml.MapItem{Key: "messageId", Value: "1"},
+				},
+				yaml.MapSlice{
+					yaml.MapItem{Key: "messageId", Value: "2"},
+				},
+			},
+			expectCode: codes.OK,
+			expectMessages: []*testpb.EchoResponse{
+				{MessageId: "1"},
+				{MessageId: "2"},
+			},
+		},
+		"bidirectional streaming returns error": {
+			method: "BidiStreamEcho",
+			messages: []any{
+				yaml.MapSlice{
+					yaml.MapItem{Key: "messageId", Value: "1"},
+				},
+				yaml.MapSlice{
+					yaml.MapItem{Key: "messageBody", Value: "error"},
+				},
+			},
+			expectCode: codes.InvalidArgument,
+			expectMessages: []*testpb.EchoResponse{
+				{MessageId: "1"},
+			},
+		},
+		"messages for server streaming": {
+			method: "ServerStreamEcho",
+			messages: []any{
+				yaml.MapSlice{},
+			},
+			expectError: ".messages: messages can't be used for server streaming RPC, use message field instead",
+		},
+		"invalid message": {
+			method: "BidiStreamEcho",
+			messages: []any{
+				yaml.MapSlice{
+					yaml.MapItem{Key: "messageId", Value: "1"},
+				},
+				yaml.MapSlice{
+					yaml.MapItem{Key: "messageId", Value: 1},
+				},
+			},
+			expectError: ".messages[1]: failed to build request message",
+		},
+	}
+	for clientName, newRequest := range clients {
+		t.Run(clientName, func(t *testing.T) {
+			for name, test := range tests {
+				t.Run(name, func(t *testing.T) {
+					req := newRequest(test.method)
+					req.Message = test.message
+					req.Messages = test.messages
+					ctx := context.FromT(t).WithVars(map[string]any{
+						"client": testpb.NewStreamTestClient(conn),
+						"target": target,
+					})
+
+					_, result, err := req.Invoke(ctx)
+					if test.expectError != "" {
+						if err == nil {
+							t.Fatal("no error")
+						}
+						if got := err.Error(); !strings.Contains(got, test.expectError) {
+							t.Fatalf("expected error is %q but got %q", test.expectError, got)
+						}
+						return
+					}
+					if err != nil {
+						t.Fatalf("unexpected error: %s", err)
+					}
+
+					resp, ok := result.(*response)
+					if !ok {
+						t.Fatalf("failed to type conversion from %s to *response", reflect.TypeOf(result))
+					}
+					if got := resp.Status.Code(); got != test.expectCode {
+						t.Fatalf("expected code is %s but got %s: %s", test.expectCode, got, resp.Status.Err())
+					}
+					if test.expectMessage != nil {
+						if resp.Message == nil {
+							t.Fatal("no message")
+						}
+						if diff := cmp.Diff(test.expectMessage, resp.Message.Message, protocmp.Transform()); diff != "" {
+							t.Errorf("differs: (-want +got)\n%s", diff)
+						}
+					}
+					if got, expect := len(resp.Messages), len(test.expectMessages); got != expect {
+						t.Fatalf("expected %d messages but got %d", expect, got)
+					}
+					for i, m := range resp.Messages {
+						if diff := cmp.Diff(test.expectMessages[i], m.Message, protocmp.Transform()); diff != "" {
+							t.Errorf("[%d] differs: (-want +got)\n%s", i, diff)
+						}
+					}
+					if test.expectTrailer != nil {
+						if resp.Trailer == nil {
+							t.Fatal("no trailer")
+						}
+						if diff := cmp.Diff(test.expectTrailer, metadata.MD(*resp.Trailer)); diff != "" {
+							t.Errorf("trailer differs: (-want +got)\n%s", diff)
+						}
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestNewCustomServiceClient_Stream(t *testing.T) {
+	tests := map[string]struct {
+		method     string
+		expectType streamType
+	}{
+		"unary": {
+			method:     "Echo",
+			expectType: streamTypeUnary,
+		},
+		"server streaming": {
+			method:     "ServerStreamEcho",
+			expectType: streamTypeServer,
+		},
+		"client streaming": {
+			method:     "ClientStreamEcho",
+			expectType: streamTypeClient,
+		},
+		"bidirectional streaming": {
+			method:     "BidiStreamEcho",
+			expectType: streamTypeBidi,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var client any = testpb.NewStreamTestClient(nil)
+			if test.method == "Echo" {
+				client = testpb.NewTestClient(nil)
+			}
+			c, err := newCustomServiceClient(&Request{Client: "{{vars.client}}", Method: test.method}, reflect.ValueOf(client))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got := c.streamType(); got != test.expectType {
+				t.Fatalf("expected %s but got %s", test.expectType, got)
+			}
+		})
+	}
+}
@@ -12,4 +12,6 @@ var (
 	typeContext  = reflect.TypeOf((*context.Context)(nil)).Elem()
 	typeMessage  = reflect.TypeOf((*proto.Message)(nil)).Elem()
 	typeCallOpts = reflect.TypeOf([]grpc.CallOption(nil))
+
+	typeClientStream = reflect.TypeOf((*grpc.ClientStream)(nil)).Elem()
 )
@@ -0,0 +1,139 @@
+// Package cookie provides a cookie jar shared across the HTTP requests of a scenario.
+package cookie
+
+import (
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Jar is a cookie jar which remembers the cookies by name.
+// It enables to query the cookies by name regardless of the URL.
+type Jar struct {
+	jar *cookiejar.Jar
+	now func() time.Time
+
+	m       sync.Mutex
+	entries map[string][]*entry
+}
+
+// entry is a cookie set by a response.
+// The cookies which have the same name are distinguished by the domain and path as well as the cookie jar.
+type entry struct {
+	domain  string
+	path    string
+	value   string
+	expires time.Time // zero means the session cookie
+}
+
+// NewJar returns a new empty cookie jar.
+func NewJar() *Jar {
+	//nolint:exhaustruct
+	jar, _ := cookiejar.New(&cookiejar.Options{}) // cookiejar.New never returns an error
+	return &Jar{
+		jar:     jar,
+		now:     time.Now,
+		entries: map[string][]*entry{},
+	}
+}
+
+// SetCookies implements http.CookieJar interface.
+func (j *Jar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	j.jar.SetCookies(u, cookies)
+	j.m.Lock()
+	defer j.m.Unlock()
+	now := j.now()
+	host := strings.ToLower(u.Hostname())
+	for _, c := range cookies {
+		domain := host
+		if c.Domain != "" {
+			domain = strings.ToLower(strings.TrimPrefix(c.Domain, "."))
+			if host != domain && !strings.HasSuffix(host, "."+domain) {
+				continue // rejected by the cookie jar
+			}
+		}
+		path := c.Path
+		if path == "" || path[0] != '/' {
+			path = defaultPath(u.Path)
+		}
+		entries := j.entries[c.Name]
+		for i, e := range entries {
+			if e.domain == domain && e.path == path {
+				entries = append(entries[:i], entries[i+1:]...)
+				break
+			}
+		}
+		e := &entry{
+			domain: domain,
+			path:   path,
+			value:  c.Value,
+		}
+		switch {
+		case c.MaxAge < 0:
+			e = nil
+		case c.MaxAge > 0:
+			e.expires = now.Add(time.Duration(c.MaxAge) * time.Second)
+		case !c.Expires.IsZero():
+			if !c.Expires.After(now) {
+				e = nil
+			} else {
+				e.expires = c.Expires
+			}
+		}
+		if e != nil {
+			entries = append(entries, e)
+		}
+		if len(entries) == 0 {
+			delete(j.entries, c.Name)
+		} else {
+			j.entries[c.Name] = entries
+		}
+	}
+}
+
+// defaultPath returns the default path of the cookies set by the URL path.
+// See https://www.rfc-editor.org/rfc/rfc6265#section-5.1.4.
+func defaultPath(path string) string {
+	if path == "" || path[0] != '/' {
+		return "/"
+	}
+	i := strings.LastIndex(path, "/")
+	if i == 0 {
+		return "/"
+	}
+	return path[:i]
+}
+
+// Cookies implements http.CookieJar interface.
+func (j *Jar) Cookies(u *url.URL) []*http.Cookie {
+	return j.jar.Cookies(u)
+}
+
+// Values returns the names and values of the cookies to send to u.
+func (j *Jar) Values(u *url.URL) map[string]string {
+	values := map[string]string{}
+	for _, c := range j.jar.Cookies(u) {
+		values[c.Name] = c.Value
+	}
+	return values
+}
+
+// ExtractByKey implements query.KeyExtractor interface.
+// It returns the value of the cookie named key which has been set most recently and has not expired.
+func (j *Jar) ExtractByKey(key string) (interface{}, bool) {
+	j.m.Lock()
+	defer j.m.Unlock()
+	now := j.now()
+	entries := j.entries[key]
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		if !e.expires.IsZero() && !e.expires.After(now) {
+			continue
+		}
+		return e.value, true
+	}
+	return nil, false
+}
@@ -0,0 +1,246 @@
+package cookie
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type setCookies struct {
+	url     string
+	cookies []*http.Cookie
+	elapsed time.Duration
+}
+
+func TestJar_ExtractByKey(t *testing.T) {
+	tests := map[string]struct {
+		set    []setCookies
+		key    string
+		expect interface{}
+		found  bool
+	}{
+		"not found": {
+			key: "session",
+		},
+		"path doesn't match the URL": {
+			set: []setCookies{
+				{
+					url:     "http://example.com/auth/login",
+					cookies: []*http.Cookie{{Name: "session", Value: "xxx", Path: "/app"}},
+				},
+			},
+			key:    "session",
+			expect: "xxx",
+			found:  true,
+		},
+		"domain attribute": {
+			set: []setCookies{
+				{
+					url:     "http://auth.example.com/login",
+					cookies: []*http.Cookie{{Name: "session", Value: "xxx", Domain: "example.com"}},
+				},
+			},
+			key:    "session",
+			expect: "xxx",
+			found:  true,
+		},
+		"rejected domain": {
+			set: []setCookies{
+				{
+					url:     "http://example.com/login",
+					cookies: []*http.Cookie{{Name: "session", Value: "xxx", Domain: "example.org"}},
+				},
+			},
+			key: "session",
+		},
+		"most recently set": {
+			set: []setCookies{
+				{
+					url:     "http://example.com/a",
+					cookies: []*http.Cookie{{Name: "session", Value: "a", Path: "/a"}},
+				},
+				{
+					url:     "http://example.org/b",
+					cookies: []*http.Cookie{{Name: "session", Value: "b"}},
+				},
+			},
+			key:    "session",
+			expect: "b",
+			found:  true,
+		},
+		"overwritten": {
+			set: []setCookies{
+				{
+					url:     "http://example.com/",
+					cookies: []*http.Cookie{{Name: "session", Value: "a"}},
+				},
+				{
+					url:     "http://example.org/",
+					cookies: []*http.Cookie{{Name: "session", Value: "b"}},
+				},
+				{
+					url:     "http://example.com/",
+					cookies: []*http.Cookie{{Name: "session", Value: "c"}},
+				},
+			},
+			key:    "session",
+			expect: "c",
+			found:  true,
+		},
+		"deleted by Max-Age": {
+			set: []setCookies{
+				{
+					url:     "http://example.com/login",
+					cookies: []*http.Cookie{{Name: "session", Value: "xxx", Path: "/"}},
+				},
+				{
+					url:     "http://example.com/logout",
+					cookies: []*http.Cookie{{Name: "session", Path: "/", MaxAge: -1}},
+				},
+			},
+			key: "session",
+		},
+		"deleted by Expires": {
+			set: []setCookies{
+				{
+					url:     "http://example.com/login",
+					cookies: []*http.Cookie{{Name: "session", Value: "xxx", Path: "/"}},
+				},
+				{
+					url:     "http://example.com/logout",
+					cookies: []*http.Cookie{{Name: "session", Path: "/", Expires: time.Unix(1, 0)}},
+				},
+			},
+			key: "session",
+		},
+		"previous one remains after deletion": {
+			set: []setCookies{
+				{
+					url:     "http://example.com/",
+					cookies: []*http.Cookie{{Name: "session", Value: "a"}},
+				},
+				{
+					url:     "http://example.org/",
+					cookies: []*http.Cookie{{Name: "session", Value: "b"}},
+				},
+				{
+					url:     "http://example.org/",
+					cookies: []*http.Cookie{{Name: "session", MaxAge: -1}},
+				},
+			},
+			key:    "session",
+			expect: "a",
+			found:  true,
+		},
+		"expired": {
+			set: []setCookies{
+				{
+					url:     "http://example.com/",
+					cookies: []*http.Cookie{{Name: "session", Value: "xxx", MaxAge: 60}},
+					elapsed: time.Minute,
+				},
+			},
+			key: "session",
+		},
+		"not expired yet": {
+			set: []setCookies{
+				{
+					url:     "http://example.com/",
+					cookies: []*http.Cookie{{Name: "session", Value: "xxx", MaxAge: 60}},
+					elapsed: 59 * time.Second,
+				},
+			},
+			key:    "session",
+			expect: "xxx",
+			found:  true,
+		},
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			jar := NewJar()
+			jar.now = func() time.Time { return now }
+			for _, s := range test.set {
+				u, err := url.Parse(s.url)
+				if err != nil {
+					t.Fatalf("failed to parse URL: %s", err)
+				}
+				jar.SetCookies(u, s.cookies)
+				now = now.Add(s.elapsed)
+			}
+			v, found := jar.ExtractByKey(test.key)
+			if found != test.found {
+				t.Fatalf("expect found %t but got %t", test.found, found)
+			}
+			if diff := cmp.Diff(test.expect, v); diff != "" {
+				t.Errorf("differs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestJar_Values(t *testing.T) {
+	jar := NewJar()
+	login, err := url.Parse("http://example.com/auth/login")
+	if err != nil {
+		t.Fatalf("failed to parse URL: %s", err)
+	}
+	jar.SetCookies(login, []*http.Cookie{
+		{Name: "session", Value: "xxx", Path: "/app"},
+		{Name: "lang", Value: "en", Path: "/"},
+	})
+	tests := map[string]struct {
+		url    string
+		expect map[string]string
+	}{
+		"path matches": {
+			url: "http://example.com/app/items",
+			expect: map[string]string{
+				"session": "xxx",
+				"lang":    "en",
+			},
+		},
+		"path doesn't match": {
+			url: "http://example.com/auth/login",
+			expect: map[string]string{
+				"lang": "en",
+			},
+		},
+		"other host": {
+			url:    "http://example.org/app",
+			expect: map[string]string{},
+		},
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			u, err := url.Parse(test.url)
+			if err != nil {
+				t.Fatalf("failed to parse URL: %s", err)
+			}
+			if diff := cmp.Diff(test.expect, jar.Values(u)); diff != "" {
+				t.Errorf("differs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDefaultPath(t *testing.T) {
+	tests := map[string]string{
+		"":            "/",
+		"foo":         "/",
+		"/":           "/",
+		"/login":      "/",
+		"/auth/login": "/auth",
+		"/auth/":      "/auth",
+	}
+	for path, expect := range tests {
+		if got := defaultPath(path); got != expect {
+			t.Errorf("%q: expect %q but got %q", path, expect, got)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package http
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/url"
+
+	"github.com/zoncoen/scenarigo/context"
+	"github.com/zoncoen/scenarigo/errors"
+)
+
+// DialConfig represents the settings of the request options to open a connection other than HTTP requests such as WebSocket.
+type DialConfig struct {
+	// URL is the request URL joined with the base URL.
+	URL string
+	// Header is the default request header.
+	// The values of the request header should take precedence over it.
+	Header http.Header
+	// Proxy returns the URL of the proxy server for a request.
+	Proxy func(*http.Request) (*url.URL, error)
+	// TLSClientConfig is the TLS configuration. It is nil if the TLS options are not specified.
+	TLSClientConfig *tls.Config
+}
+
+// NewDialConfig returns the settings to connect to urlStr with the request options merged with the options of the protocol.
+// The timeout, redirect, cookieJar, and proto options are not applied.
+func NewDialConfig(ctx *context.Context, opts *RequestOptions, urlStr string) (*DialConfig, error) {
+	opts, err := resolveOptions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	urlStr, err = opts.url(urlStr)
+	if err != nil {
+		return nil, err
+	}
+	header, err := opts.header()
+	if err != nil {
+		return nil, errors.WithPath(err, "options")
+	}
+	rt, err := httpProtocol.transport(opts)
+	if err != nil {
+		return nil, errors.WithPath(err, "options")
+	}
+	cfg := &DialConfig{
+		URL:    urlStr,
+		Header: header,
+		Proxy:  http.ProxyFromEnvironment,
+	}
+	if t, ok := rt.(*http.Transport); ok {
+		cfg.Proxy = t.Proxy
+		cfg.TLSClientConfig = t.TLSClientConfig
+	}
+	return cfg, nil
+}
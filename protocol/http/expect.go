@@ -15,6 +15,9 @@ type Expect struct {
 	Code   string        `yaml:"code,omitempty"`
 	Header yaml.MapSlice `yaml:"header,omitempty"`
 	Body   interface{}   `yaml:"body,omitempty"`
+	// Cookies is the expected cookies in the cookie jar for the request URL.
+	// If the cookie jar is disabled, they are compared with the cookies set by the response.
+	Cookies interface{} `yaml:"cookies,omitempty"`
 }
 
 // Build implements protocol.AssertionBuilder interface.
@@ -39,21 +42,46 @@ func (e *Expect) Build(ctx *context.Context) (assert.Assertion, error) {
 		return nil, errors.WrapPathf(err, "body", "invalid expect response body")
 	}
 
+	var cookiesAssertion assert.Assertion
+	if e.Cookies != nil {
+		cookiesAssertion, err = assert.Build(ctx.RequestContext(), e.Cookies, assert.FromTemplate(ctx))
+		if err != nil {
+			return nil, errors.WrapPathf(err, "cookies", "invalid expect cookies")
+		}
+	}
+
 	return assert.AssertionFunc(func(v interface{}) error {
 		res, ok := v.(response)
 		if !ok {
 			return errors.Errorf("expected response but got %T", v)
 		}
-		if err := assertCode(codeAssertion, res.Status); err != nil {
-			return errors.WithPath(err, "code")
+		err := func() error {
+			if err := assertCode(codeAssertion, res.Status); err != nil {
+				return errors.WithPath(err, "code")
+			}
+			if err := headerAssertion.Assert(res.Header); err != nil {
+				return errors.WithPath(err, "header")
+			}
+			if err := assertion.Assert(res.Body); err != nil {
+				return errors.WithPath(err, "body")
+			}
+			if cookiesAssertion != nil {
+				if err := cookiesAssertion.Assert(res.cookies); err != nil {
+					return errors.WithPath(err, "cookies")
+				}
+			}
+			return nil
+		}()
+		// validate the response even if the assertion fails to count the operation in the coverage
+		if doc := ctx.OpenAPI(); doc != nil && res.req != nil {
+			if verr := doc.ValidateResponse(ctx.RequestContext(), res.req, res.StatusCode, res.Header, res.rawBody); verr != nil {
+				if err != nil {
+					return errors.Errors(err, verr)
+				}
+				return verr
+			}
 		}
-		if err := headerAssertion.Assert(res.Header); err != nil {
-			return errors.WithPath(err, "header")
-		}
-		if err := assertion.Assert(res.Body); err != nil {
-			return errors.WithPath(err, "body")
-		}
-		return nil
+		return err
 	}), nil
 }
 
@@ -5,6 +5,7 @@ import (
 
 	"github.com/goccy/go-yaml"
 	"github.com/zoncoen/scenarigo/context"
+	"github.com/zoncoen/scenarigo/protocol/http/unmarshaler"
 )
 
 func TestExpect_Build(t *testing.T) {
@@ -74,6 +75,47 @@ func TestExpect_Build(t *testing.T) {
 					Body:   map[string]string{"foo": "bar"},
 				},
 			},
+			"XML response body": {
+				expect: &Expect{
+					Body: yaml.MapSlice{
+						{
+							Key: "envelope",
+							Value: yaml.MapSlice{
+								{
+									Key: "body",
+									Value: yaml.MapSlice{
+										{Key: "item", Value: []interface{}{"a", "b"}},
+									},
+								},
+							},
+						},
+					},
+				},
+				response: response{
+					Status: "200 OK",
+					Body: unmarshaler.XMLElement{
+						"Envelope": unmarshaler.XMLElement{
+							"Body": unmarshaler.XMLElement{
+								"Item": []interface{}{"a", "b"},
+							},
+						},
+					},
+				},
+			},
+			"cookies": {
+				expect: &Expect{
+					Cookies: yaml.MapSlice{
+						yaml.MapItem{
+							Key:   "session",
+							Value: "xxx",
+						},
+					},
+				},
+				response: response{
+					Status:  "200 OK",
+					cookies: map[string]string{"session": "xxx", "foo": "bar"},
+				},
+			},
 			"with vars": {
 				vars: map[string]string{"foo": "bar"},
 				expect: &Expect{
@@ -192,6 +234,42 @@ func TestExpect_Build(t *testing.T) {
 				},
 				expectAssertError: true,
 			},
+			"wrong XML response body": {
+				expect: &Expect{
+					Body: yaml.MapSlice{
+						{
+							Key: "envelope",
+							Value: yaml.MapSlice{
+								{Key: "item", Value: "a"},
+							},
+						},
+					},
+				},
+				response: response{
+					Status: "200 OK",
+					Body: unmarshaler.XMLElement{
+						"Envelope": unmarshaler.XMLElement{
+							"Item": "b",
+						},
+					},
+				},
+				expectAssertError: true,
+			},
+			"wrong cookies": {
+				expect: &Expect{
+					Cookies: yaml.MapSlice{
+						yaml.MapItem{
+							Key:   "session",
+							Value: "xxx",
+						},
+					},
+				},
+				response: response{
+					Status:  "200 OK",
+					cookies: map[string]string{},
+				},
+				expectAssertError: true,
+			},
 			"wrong header key": {
 				expect: &Expect{
 					Header: yaml.MapSlice{
@@ -2,18 +2,34 @@ package http
 
 import (
 	"bytes"
+	"net/http"
+	"sync"
 
 	"github.com/goccy/go-yaml"
 	"github.com/zoncoen/scenarigo/protocol"
+	grpcproto "github.com/zoncoen/scenarigo/protocol/grpc/proto"
+	"github.com/zoncoen/scenarigo/schema/jsonschema"
 )
 
+var httpProtocol = &HTTP{}
+
 // Register registers http protocol.
 func Register() {
-	protocol.Register(&HTTP{})
+	protocol.Register(httpProtocol)
 }
 
 // HTTP is a protocol type for the scenarigo step.
-type HTTP struct{}
+type HTTP struct {
+	m          sync.Mutex
+	option     Option
+	transports map[string]*http.Transport
+	fds        map[string]grpcproto.FileDescriptors
+}
+
+// Option represents a Option for HTTP.
+type Option struct {
+	Request *RequestOptions `yaml:"request,omitempty"`
+}
 
 // Name implements protocol.Protocol interface.
 func (p *HTTP) Name() string {
@@ -21,8 +37,16 @@ func (p *HTTP) Name() string {
 }
 
 // UnmarshalOption implements protocol.Protocol interface.
-func (p *HTTP) UnmarshalOption(_ []byte) error {
-	return nil
+func (p *HTTP) UnmarshalOption(b []byte) error {
+	p.m.Lock()
+	defer p.m.Unlock()
+	return yaml.UnmarshalWithOptions(b, &p.option, yaml.Strict())
+}
+
+func (p *HTTP) getOption() *Option {
+	p.m.Lock()
+	defer p.m.Unlock()
+	return &p.option
 }
 
 // UnmarshalRequest implements protocol.Protocol interface.
@@ -46,3 +70,23 @@ func (p *HTTP) UnmarshalExpect(b []byte) (protocol.AssertionBuilder, error) {
 	}
 	return &e, nil
 }
+
+// OptionSchema implements protocol.SchemaProvider interface.
+func (p *HTTP) OptionSchema() *jsonschema.Schema {
+	return jsonschema.Reflect(Option{})
+}
+
+// RequestSchema implements protocol.SchemaProvider interface.
+func (p *HTTP) RequestSchema() *jsonschema.Schema {
+	return jsonschema.Reflect(Request{})
+}
+
+// ExpectSchema implements protocol.SchemaProvider interface.
+func (p *HTTP) ExpectSchema() *jsonschema.Schema {
+	s := jsonschema.Reflect(Expect{})
+	// the status code can be written as a number or a status text
+	s.SetProperty("code", &jsonschema.Schema{
+		Type: jsonschema.Types{jsonschema.TypeString, jsonschema.TypeInteger},
+	})
+	return s
+}
@@ -3,6 +3,7 @@ package http
 import (
 	"testing"
 
+	"github.com/goccy/go-yaml"
 	"github.com/google/go-cmp/cmp"
 	"github.com/zoncoen/scenarigo/protocol"
 )
@@ -34,6 +35,21 @@ func TestHTTP_UnmarshalRequest(t *testing.T) {
 					Method: "GET",
 				},
 			},
+			"stream": {
+				bytes: []byte(`
+stream:
+  count: 10
+  until:
+    event: done`),
+				expect: &Request{
+					Stream: &StreamOption{
+						Count: 10,
+						Until: yaml.MapSlice{
+							{Key: "event", Value: "done"},
+						},
+					},
+				},
+			},
 		}
 		for name, test := range tests {
 			test := test
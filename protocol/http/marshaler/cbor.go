@@ -0,0 +1,31 @@
+package marshaler
+
+import (
+	"github.com/fxamacker/cbor/v2"
+)
+
+func init() {
+	if err := Register(&cborMarshaler{}); err != nil {
+		panic(err)
+	}
+}
+
+//nolint:exhaustruct
+var cborEncMode, _ = cbor.EncOptions{Sort: cbor.SortBytewiseLexical}.EncMode()
+
+type cborMarshaler struct{}
+
+// MediaType implements RequestMarshaler interface.
+func (m *cborMarshaler) MediaType() string {
+	return "application/cbor"
+}
+
+// Marshal implements RequestMarshaler interface.
+// The keys of the maps are sorted to make the output deterministic.
+func (m *cborMarshaler) Marshal(v interface{}) ([]byte, error) {
+	pv, err := plainValue(v)
+	if err != nil {
+		return nil, err
+	}
+	return cborEncMode.Marshal(pv)
+}
@@ -0,0 +1,84 @@
+package marshaler
+
+import (
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCBOR_Marshal(t *testing.T) {
+	m := cborMarshaler{}
+	tests := map[string]struct {
+		v      interface{}
+		expect interface{}
+	}{
+		"nil": {
+			v:      nil,
+			expect: nil,
+		},
+		"string": {
+			v:      "hello",
+			expect: "hello",
+		},
+		"map": {
+			v: map[string]interface{}{
+				"id":   -1,
+				"tags": []interface{}{"a", "b"},
+			},
+			expect: map[interface{}]interface{}{
+				"id":   int64(-1),
+				"tags": []interface{}{"a", "b"},
+			},
+		},
+		"ordered map": {
+			v: yaml.MapSlice{
+				{Key: "name", Value: "alice"},
+				{Key: "items", Value: []interface{}{
+					yaml.MapSlice{{Key: "id", Value: uint64(1)}},
+				}},
+			},
+			expect: map[interface{}]interface{}{
+				"name": "alice",
+				"items": []interface{}{
+					map[interface{}]interface{}{"id": uint64(1)},
+				},
+			},
+		},
+		"bytes": {
+			v:      []byte{0x00, 0x01},
+			expect: []byte{0x00, 0x01},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			b, err := m.Marshal(test.v)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			var got interface{}
+			if err := cbor.Unmarshal(b, &got); err != nil {
+				t.Fatalf("failed to decode: %s", err)
+			}
+			if diff := cmp.Diff(test.expect, got); diff != "" {
+				t.Errorf("differs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCBOR_Marshal_Deterministic(t *testing.T) {
+	m := cborMarshaler{}
+	a, err := m.Marshal(yaml.MapSlice{{Key: "b", Value: 1}, {Key: "a", Value: 2}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	b, err := m.Marshal(map[string]interface{}{"a": 2, "b": 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(a, b); diff != "" {
+		t.Errorf("differs (-want +got):\n%s", diff)
+	}
+}
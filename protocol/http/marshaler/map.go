@@ -0,0 +1,91 @@
+package marshaler
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/goccy/go-yaml"
+	"github.com/pkg/errors"
+
+	"github.com/zoncoen/scenarigo/internal/reflectutil"
+)
+
+//nolint:exhaustruct
+var yamlMapSliceType = reflect.TypeOf(yaml.MapSlice{})
+
+type mapField struct {
+	name  string
+	value interface{}
+}
+
+// mapFields returns the fields of the map.
+// The fields of the ordered map keep the order, and the others are sorted by the names.
+func mapFields(v interface{}) ([]mapField, error) {
+	if ms, ok := v.(yaml.MapSlice); ok {
+		fields := make([]mapField, 0, len(ms))
+		for _, item := range ms {
+			name, err := reflectutil.ConvertString(reflect.ValueOf(item.Key))
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid field name")
+			}
+			fields = append(fields, mapField{name: name, value: item.Value})
+		}
+		return fields, nil
+	}
+	rv := reflectutil.Elem(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Map {
+		return nil, errors.Errorf("expected map but got %T", v)
+	}
+	fields := make([]mapField, 0, rv.Len())
+	iter := rv.MapRange()
+	for iter.Next() {
+		name, err := reflectutil.ConvertString(iter.Key())
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid field name")
+		}
+		fields = append(fields, mapField{name: name, value: iter.Value().Interface()})
+	}
+	sort.SliceStable(fields, func(i, j int) bool {
+		return fields[i].name < fields[j].name
+	})
+	return fields, nil
+}
+
+// plainValue converts the ordered maps in v into map[string]interface{} recursively
+// for the encoders which don't know yaml.MapSlice.
+func plainValue(v interface{}) (interface{}, error) {
+	if _, ok := v.([]byte); ok {
+		return v, nil
+	}
+	rv := reflectutil.Elem(reflect.ValueOf(v))
+	if !rv.IsValid() {
+		return nil, nil
+	}
+	switch {
+	case rv.Kind() == reflect.Map || rv.Type() == yamlMapSliceType:
+		fields, err := mapFields(v)
+		if err != nil {
+			return nil, err
+		}
+		m := make(map[string]interface{}, len(fields))
+		for _, f := range fields {
+			fv, err := plainValue(f.value)
+			if err != nil {
+				return nil, errors.Wrap(err, f.name)
+			}
+			m[f.name] = fv
+		}
+		return m, nil
+	case rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array:
+		s := make([]interface{}, rv.Len())
+		for i := range rv.Len() {
+			ev, err := plainValue(rv.Index(i).Interface())
+			if err != nil {
+				return nil, errors.Wrapf(err, "[%d]", i)
+			}
+			s[i] = ev
+		}
+		return s, nil
+	}
+	return v, nil
+}
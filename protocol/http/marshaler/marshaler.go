@@ -6,6 +6,8 @@ import (
 	"sync"
 
 	"github.com/pkg/errors"
+
+	grpcproto "github.com/zoncoen/scenarigo/protocol/grpc/proto"
 )
 
 // Default is the default request marshaler.
@@ -50,3 +52,21 @@ type RequestMarshaler interface {
 	MediaType() string
 	Marshal(v interface{}) ([]byte, error)
 }
+
+// ContentTypeMarshaler is the interface that marshals the HTTP request body with the Content-Type header value.
+// RequestMarshaler implements it if the header value has parameters determined by the body such as the boundary of multipart.
+type ContentTypeMarshaler interface {
+	MarshalWithContentType(v interface{}, opts *MarshalOptions) ([]byte, string, error)
+}
+
+// MarshalOptions represents the options to marshal the HTTP request body.
+type MarshalOptions struct {
+	// ContentType is the Content-Type header value of the request.
+	ContentType string
+	// BaseDir is the base directory of the relative file paths in the body.
+	BaseDir string
+	// MessageResolver resolves the protocol buffers message types.
+	MessageResolver grpcproto.MessageDescriptorResolver
+	// MessageType is the protocol buffers message type used if the Content-Type header value doesn't specify it.
+	MessageType string
+}
@@ -0,0 +1,40 @@
+package marshaler
+
+import (
+	"bytes"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func init() {
+	for _, mediaType := range []string{"application/msgpack", "application/x-msgpack"} {
+		if err := Register(&msgpackMarshaler{mediaType: mediaType}); err != nil {
+			panic(err)
+		}
+	}
+}
+
+type msgpackMarshaler struct {
+	mediaType string
+}
+
+// MediaType implements RequestMarshaler interface.
+func (m *msgpackMarshaler) MediaType() string {
+	return m.mediaType
+}
+
+// Marshal implements RequestMarshaler interface.
+// The keys of the maps are sorted to make the output deterministic.
+func (m *msgpackMarshaler) Marshal(v interface{}) ([]byte, error) {
+	pv, err := plainValue(v)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	enc.SetSortMapKeys(true)
+	if err := enc.Encode(pv); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,72 @@
+package marshaler
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestMsgpack_Marshal(t *testing.T) {
+	m := msgpackMarshaler{mediaType: "application/msgpack"}
+	tests := map[string]struct {
+		v      interface{}
+		expect interface{}
+	}{
+		"nil": {
+			v:      nil,
+			expect: nil,
+		},
+		"string": {
+			v:      "hello",
+			expect: "hello",
+		},
+		"map": {
+			v: map[string]interface{}{
+				"id":   1,
+				"tags": []interface{}{"a", "b"},
+			},
+			expect: map[string]interface{}{
+				"id":   int64(1),
+				"tags": []interface{}{"a", "b"},
+			},
+		},
+		"ordered map": {
+			v: yaml.MapSlice{
+				{Key: "name", Value: "alice"},
+				{Key: "items", Value: []interface{}{
+					yaml.MapSlice{{Key: "id", Value: uint64(1)}},
+				}},
+			},
+			expect: map[string]interface{}{
+				"name": "alice",
+				"items": []interface{}{
+					map[string]interface{}{"id": uint64(1)},
+				},
+			},
+		},
+		"bytes": {
+			v:      []byte{0x00, 0x01},
+			expect: "\x00\x01", // bin is decoded into string by the loose decoding
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			b, err := m.Marshal(test.v)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			var got interface{}
+			d := msgpack.NewDecoder(bytes.NewReader(b))
+			d.UseLooseInterfaceDecoding(true)
+			if err := d.Decode(&got); err != nil {
+				t.Fatalf("failed to decode: %s", err)
+			}
+			if diff := cmp.Diff(test.expect, got); diff != "" {
+				t.Errorf("differs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
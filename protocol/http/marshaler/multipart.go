@@ -0,0 +1,195 @@
+package marshaler
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/zoncoen/scenarigo/internal/filepathutil"
+	"github.com/zoncoen/scenarigo/internal/reflectutil"
+)
+
+func init() {
+	if err := Register(&multipartMarshaler{}); err != nil {
+		panic(err)
+	}
+}
+
+type multipartMarshaler struct{}
+
+// MediaType implements RequestMarshaler interface.
+func (m *multipartMarshaler) MediaType() string {
+	return "multipart/form-data"
+}
+
+// Marshal implements RequestMarshaler interface.
+func (m *multipartMarshaler) Marshal(v interface{}) ([]byte, error) {
+	b, _, err := m.MarshalWithContentType(v, &MarshalOptions{})
+	return b, err
+}
+
+// MarshalWithContentType implements ContentTypeMarshaler interface.
+//
+// The body must be a map whose values are the text fields or the part definitions.
+// A part definition is a map which has the file or value field and optionally the filename and contentType fields.
+func (m *multipartMarshaler) MarshalWithContentType(v interface{}, opts *MarshalOptions) ([]byte, string, error) {
+	fields, err := mapFields(v)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	if opts.ContentType != "" {
+		if _, params, err := mime.ParseMediaType(opts.ContentType); err == nil && params["boundary"] != "" {
+			if err := w.SetBoundary(params["boundary"]); err != nil {
+				return nil, "", errors.Wrap(err, "invalid boundary")
+			}
+		}
+	}
+	for _, f := range fields {
+		if err := writeMultipartField(w, f.name, f.value, opts); err != nil {
+			return nil, "", errors.Wrapf(err, "%s", f.name)
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), w.FormDataContentType(), nil
+}
+
+// multipartPart represents a part definition.
+type multipartPart struct {
+	File        string
+	Value       interface{}
+	Filename    string
+	ContentType string
+}
+
+func newMultipartPart(v interface{}) (*multipartPart, error) {
+	fields, err := mapFields(v)
+	if err != nil {
+		return nil, err
+	}
+	p := &multipartPart{}
+	for _, f := range fields {
+		var dst *string
+		switch f.name {
+		case "value":
+			p.Value = f.value
+			continue
+		case "file":
+			dst = &p.File
+		case "filename":
+			dst = &p.Filename
+		case "contentType":
+			dst = &p.ContentType
+		default:
+			return nil, errors.Errorf("unknown field %q", f.name)
+		}
+		s, err := reflectutil.ConvertString(reflect.ValueOf(f.value))
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s", f.name)
+		}
+		*dst = s
+	}
+	return p, nil
+}
+
+func writeMultipartField(w *multipart.Writer, name string, v interface{}, opts *MarshalOptions) error {
+	rv := reflectutil.Elem(reflect.ValueOf(v))
+	if rv.IsValid() && (rv.Kind() == reflect.Map || rv.Type() == yamlMapSliceType) {
+		p, err := newMultipartPart(v)
+		if err != nil {
+			return err
+		}
+		return p.write(w, name, opts)
+	}
+
+	var values []string
+	if b, ok := v.([]byte); ok {
+		values = []string{string(b)}
+	} else {
+		var err error
+		values, err = reflectutil.ConvertStrings(reflect.ValueOf(v))
+		if err != nil {
+			return err
+		}
+	}
+	for _, s := range values {
+		if err := w.WriteField(name, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *multipartPart) write(w *multipart.Writer, name string, opts *MarshalOptions) error {
+	var (
+		content  []byte
+		filename = p.Filename
+	)
+	switch {
+	case p.File != "" && p.Value != nil:
+		return errors.New("file and value can't be specified at the same time")
+	case p.File != "":
+		b, err := os.ReadFile(filepathutil.From(opts.BaseDir, p.File))
+		if err != nil {
+			return errors.Wrap(err, "failed to read file")
+		}
+		content = b
+		if filename == "" {
+			filename = filepath.Base(p.File)
+		}
+	case p.Value != nil:
+		if b, ok := p.Value.([]byte); ok {
+			content = b
+		} else {
+			s, err := reflectutil.ConvertString(reflect.ValueOf(p.Value))
+			if err != nil {
+				return err
+			}
+			content = []byte(s)
+		}
+	default:
+		return errors.New("file or value must be specified")
+	}
+
+	contentType := p.ContentType
+	if contentType == "" && filename != "" {
+		contentType = mime.TypeByExtension(filepath.Ext(filename))
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+	}
+
+	h := textproto.MIMEHeader{}
+	disposition := fmt.Sprintf(`form-data; name="%s"`, escapeQuotes(name))
+	if filename != "" {
+		disposition += fmt.Sprintf(`; filename="%s"`, escapeQuotes(filename))
+	}
+	h.Set("Content-Disposition", disposition)
+	if contentType != "" {
+		h.Set("Content-Type", contentType)
+	}
+	pw, err := w.CreatePart(h)
+	if err != nil {
+		return err
+	}
+	_, err = pw.Write(content)
+	return err
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
@@ -0,0 +1,211 @@
+package marshaler
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMultipart_MarshalWithContentType(t *testing.T) {
+	type part struct {
+		Name        string
+		Filename    string
+		ContentType string
+		Content     string
+	}
+	m := multipartMarshaler{}
+	t.Run("success", func(t *testing.T) {
+		tests := map[string]struct {
+			v           interface{}
+			opts        *MarshalOptions
+			expect      []part
+			expectBound string
+		}{
+			"text fields": {
+				v: map[string]interface{}{
+					"name": "alice",
+					"age":  20,
+					"tags": []interface{}{"a", "b"},
+				},
+				opts: &MarshalOptions{},
+				expect: []part{
+					{Name: "age", Content: "20"},
+					{Name: "name", Content: "alice"},
+					{Name: "tags", Content: "a"},
+					{Name: "tags", Content: "b"},
+				},
+			},
+			"ordered map": {
+				v: yaml.MapSlice{
+					{Key: "b", Value: "1"},
+					{Key: "a", Value: "2"},
+				},
+				opts: &MarshalOptions{},
+				expect: []part{
+					{Name: "b", Content: "1"},
+					{Name: "a", Content: "2"},
+				},
+			},
+			"file": {
+				v: map[string]interface{}{
+					"file": map[string]interface{}{
+						"file": "hello.json",
+					},
+				},
+				opts: &MarshalOptions{
+					BaseDir: "testdata",
+				},
+				expect: []part{
+					{Name: "file", Filename: "hello.json", ContentType: "application/json", Content: `{"message":"hello"}` + "\n"},
+				},
+			},
+			"file with filename and content type": {
+				v: map[string]interface{}{
+					"file": map[string]interface{}{
+						"file":        "testdata/hello.json",
+						"filename":    "greeting",
+						"contentType": "text/x-greeting",
+					},
+				},
+				opts: &MarshalOptions{},
+				expect: []part{
+					{Name: "file", Filename: "greeting", ContentType: "text/x-greeting", Content: `{"message":"hello"}` + "\n"},
+				},
+			},
+			"value": {
+				v: map[string]interface{}{
+					"meta": map[string]interface{}{
+						"value":       `{"id":1}`,
+						"contentType": "application/json",
+					},
+					"data": map[string]interface{}{
+						"value":    []byte{0x00, 0x01},
+						"filename": "data",
+					},
+				},
+				opts: &MarshalOptions{},
+				expect: []part{
+					{Name: "data", Filename: "data", ContentType: "application/octet-stream", Content: "\x00\x01"},
+					{Name: "meta", ContentType: "application/json", Content: `{"id":1}`},
+				},
+			},
+			"boundary": {
+				v: map[string]interface{}{
+					"name": "alice",
+				},
+				opts: &MarshalOptions{
+					ContentType: "multipart/form-data; boundary=xxxxx",
+				},
+				expect: []part{
+					{Name: "name", Content: "alice"},
+				},
+				expectBound: "xxxxx",
+			},
+		}
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				b, contentType, err := m.MarshalWithContentType(test.v, test.opts)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				mediaType, params, err := mime.ParseMediaType(contentType)
+				if err != nil {
+					t.Fatalf("failed to parse Content-Type: %s", err)
+				}
+				if got, expect := mediaType, "multipart/form-data"; got != expect {
+					t.Errorf("expect %q but got %q", expect, got)
+				}
+				if test.expectBound != "" {
+					if got := params["boundary"]; got != test.expectBound {
+						t.Errorf("expect boundary %q but got %q", test.expectBound, got)
+					}
+				}
+				var got []part
+				r := multipart.NewReader(bytes.NewReader(b), params["boundary"])
+				for {
+					p, err := r.NextPart()
+					if err == io.EOF {
+						break
+					}
+					if err != nil {
+						t.Fatalf("failed to read part: %s", err)
+					}
+					content, err := io.ReadAll(p)
+					if err != nil {
+						t.Fatalf("failed to read part: %s", err)
+					}
+					got = append(got, part{
+						Name:        p.FormName(),
+						Filename:    p.FileName(),
+						ContentType: p.Header.Get("Content-Type"),
+						Content:     string(content),
+					})
+				}
+				if diff := cmp.Diff(test.expect, got); diff != "" {
+					t.Errorf("differs (-want +got):\n%s", diff)
+				}
+			})
+		}
+	})
+	t.Run("failure", func(t *testing.T) {
+		tests := map[string]struct {
+			v      interface{}
+			expect string
+		}{
+			"not map": {
+				v:      "aaa",
+				expect: "expected map but got string",
+			},
+			"file not found": {
+				v: map[string]interface{}{
+					"file": map[string]interface{}{
+						"file": "not-found.txt",
+					},
+				},
+				expect: "file: failed to read file: open not-found.txt: no such file or directory",
+			},
+			"no file and value": {
+				v: map[string]interface{}{
+					"file": map[string]interface{}{
+						"filename": "a.txt",
+					},
+				},
+				expect: "file: file or value must be specified",
+			},
+			"both file and value": {
+				v: map[string]interface{}{
+					"file": map[string]interface{}{
+						"file":  "a.txt",
+						"value": "a",
+					},
+				},
+				expect: "file: file and value can't be specified at the same time",
+			},
+			"unknown field": {
+				v: map[string]interface{}{
+					"file": map[string]interface{}{
+						"path": "a.txt",
+					},
+				},
+				expect: `file: unknown field "path"`,
+			},
+		}
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				_, _, err := m.MarshalWithContentType(test.v, &MarshalOptions{})
+				if err == nil {
+					t.Fatal("no error")
+				}
+				if got := err.Error(); !strings.Contains(got, test.expect) {
+					t.Errorf("expect %q but got %q", test.expect, got)
+				}
+			})
+		}
+	})
+}
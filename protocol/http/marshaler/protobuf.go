@@ -0,0 +1,77 @@
+package marshaler
+
+import (
+	"bytes"
+	"mime"
+
+	"github.com/goccy/go-yaml"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+func init() {
+	for _, mediaType := range []string{"application/x-protobuf", "application/protobuf"} {
+		if err := Register(&protobufMarshaler{mediaType: mediaType}); err != nil {
+			panic(err)
+		}
+	}
+}
+
+type protobufMarshaler struct {
+	mediaType string
+}
+
+// MediaType implements RequestMarshaler interface.
+func (m *protobufMarshaler) MediaType() string {
+	return m.mediaType
+}
+
+// Marshal implements RequestMarshaler interface.
+// It always fails because the message type can't be resolved without the options.
+func (m *protobufMarshaler) Marshal(_ interface{}) ([]byte, error) {
+	return nil, errors.New("message type can't be resolved without proto files")
+}
+
+// MarshalWithContentType implements ContentTypeMarshaler interface.
+//
+// The message type is specified by the proto parameter of the Content-Type header such as "application/x-protobuf; proto=example.Message".
+// The body is converted into the message in the same way as the JSON mapping of protocol buffers.
+func (m *protobufMarshaler) MarshalWithContentType(v interface{}, opts *MarshalOptions) ([]byte, string, error) {
+	_, params, err := mime.ParseMediaType(opts.ContentType)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "invalid Content-Type")
+	}
+	name := params["proto"]
+	if name == "" {
+		name = opts.MessageType
+	}
+	if name == "" {
+		return nil, "", errors.New(`message type must be specified by the "proto" parameter of the Content-Type header or the option`)
+	}
+	if opts.MessageResolver == nil {
+		return nil, "", errors.New("message type can't be resolved without proto files")
+	}
+	md, err := opts.MessageResolver.ResolveMessage(protoreflect.FullName(name))
+	if err != nil {
+		return nil, "", err
+	}
+
+	msg := dynamicpb.NewMessage(md)
+	if v != nil {
+		var buf bytes.Buffer
+		if err := yaml.NewEncoder(&buf, yaml.JSON()).Encode(v); err != nil {
+			return nil, "", err
+		}
+		if err := protojson.Unmarshal(buf.Bytes(), msg); err != nil {
+			return nil, "", errors.Wrapf(err, "failed to convert into %s", name)
+		}
+	}
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, "", err
+	}
+	return b, opts.ContentType, nil
+}
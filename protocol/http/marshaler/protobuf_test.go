@@ -0,0 +1,138 @@
+package marshaler
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	grpcproto "github.com/zoncoen/scenarigo/protocol/grpc/proto"
+)
+
+func TestProtobuf_MarshalWithContentType(t *testing.T) {
+	fds, err := grpcproto.NewCompiler(nil).Compile(context.Background(), []string{"../testdata/item.proto"})
+	if err != nil {
+		t.Fatalf("failed to compile: %s", err)
+	}
+	md, err := fds.ResolveMessage("scenarigo.testdata.http.Item")
+	if err != nil {
+		t.Fatalf("failed to resolve message: %s", err)
+	}
+	m := protobufMarshaler{mediaType: "application/x-protobuf"}
+	t.Run("success", func(t *testing.T) {
+		tests := map[string]struct {
+			v      interface{}
+			opts   *MarshalOptions
+			expect string
+		}{
+			"proto parameter": {
+				v: yaml.MapSlice{
+					{Key: "id", Value: "1"},
+					{Key: "display_name", Value: "foo"},
+					{Key: "count", Value: uint64(2)},
+					{Key: "tags", Value: []interface{}{"a", "b"}},
+				},
+				opts: &MarshalOptions{
+					ContentType:     "application/x-protobuf; proto=scenarigo.testdata.http.Item",
+					MessageResolver: fds,
+				},
+				expect: `{"id":"1","displayName":"foo","count":2,"tags":["a","b"]}`,
+			},
+			"message type option": {
+				v: map[string]interface{}{
+					"displayName": "foo",
+				},
+				opts: &MarshalOptions{
+					ContentType:     "application/x-protobuf",
+					MessageResolver: fds,
+					MessageType:     "scenarigo.testdata.http.Item",
+				},
+				expect: `{"displayName":"foo"}`,
+			},
+			"nil": {
+				v: nil,
+				opts: &MarshalOptions{
+					ContentType:     "application/x-protobuf; proto=scenarigo.testdata.http.Item",
+					MessageResolver: fds,
+				},
+				expect: `{}`,
+			},
+		}
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				b, contentType, err := m.MarshalWithContentType(test.v, test.opts)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if got, expect := contentType, test.opts.ContentType; got != expect {
+					t.Errorf("expect %q but got %q", expect, got)
+				}
+				msg := dynamicpb.NewMessage(md)
+				if err := proto.Unmarshal(b, msg); err != nil {
+					t.Fatalf("failed to decode: %s", err)
+				}
+				expect := dynamicpb.NewMessage(md)
+				if err := protojson.Unmarshal([]byte(test.expect), expect); err != nil {
+					t.Fatalf("failed to unmarshal: %s", err)
+				}
+				if !proto.Equal(expect, msg) {
+					t.Errorf("differs:\n%s", cmp.Diff(protojson.Format(expect), protojson.Format(msg)))
+				}
+			})
+		}
+	})
+	t.Run("failure", func(t *testing.T) {
+		tests := map[string]struct {
+			v      interface{}
+			opts   *MarshalOptions
+			expect string
+		}{
+			"no message type": {
+				opts: &MarshalOptions{
+					ContentType:     "application/x-protobuf",
+					MessageResolver: fds,
+				},
+				expect: `message type must be specified by the "proto" parameter of the Content-Type header or the option`,
+			},
+			"no proto files": {
+				opts: &MarshalOptions{
+					ContentType: "application/x-protobuf; proto=scenarigo.testdata.http.Item",
+				},
+				expect: "message type can't be resolved without proto files",
+			},
+			"message not found": {
+				opts: &MarshalOptions{
+					ContentType:     "application/x-protobuf; proto=scenarigo.testdata.http.Unknown",
+					MessageResolver: fds,
+				},
+				expect: `message "scenarigo.testdata.http.Unknown" not found`,
+			},
+			"unknown field": {
+				v: map[string]interface{}{
+					"name": "foo",
+				},
+				opts: &MarshalOptions{
+					ContentType:     "application/x-protobuf; proto=scenarigo.testdata.http.Item",
+					MessageResolver: fds,
+				},
+				expect: `failed to convert into scenarigo.testdata.http.Item`,
+			},
+		}
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				_, _, err := m.MarshalWithContentType(test.v, test.opts)
+				if err == nil {
+					t.Fatal("no error")
+				}
+				if got := err.Error(); !strings.Contains(got, test.expect) {
+					t.Errorf("expect %q but got %q", test.expect, got)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,124 @@
+package marshaler
+
+import (
+	"bytes"
+	"encoding/xml"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/zoncoen/scenarigo/internal/reflectutil"
+)
+
+func init() {
+	for _, mediaType := range []string{"application/xml", "text/xml"} {
+		if err := Register(&xmlMarshaler{mediaType: mediaType}); err != nil {
+			panic(err)
+		}
+	}
+}
+
+type xmlMarshaler struct {
+	mediaType string
+}
+
+// MediaType implements RequestMarshaler interface.
+func (m *xmlMarshaler) MediaType() string {
+	return m.mediaType
+}
+
+// Marshal implements RequestMarshaler interface.
+//
+// The body must be a map which has the root element.
+// The keys of the map are the names of the child elements, "@" followed by the attribute names, and "#text" for the text content.
+// A list is encoded into the repeated elements.
+func (m *xmlMarshaler) Marshal(v interface{}) ([]byte, error) {
+	fields, err := mapFields(v)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) != 1 {
+		return nil, errors.Errorf("expected one root element but got %d", len(fields))
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	enc := xml.NewEncoder(&buf)
+	if err := encodeXMLElement(enc, fields[0].name, fields[0].value); err != nil {
+		return nil, err
+	}
+	if err := enc.Flush(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func encodeXMLElement(enc *xml.Encoder, name string, v interface{}) error {
+	rv := reflectutil.Elem(reflect.ValueOf(v))
+	if rv.IsValid() && (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array) && rv.Type() != yamlMapSliceType {
+		if _, ok := v.([]byte); !ok {
+			for i := range rv.Len() {
+				if err := encodeXMLElement(enc, name, rv.Index(i).Interface()); err != nil {
+					return errors.Wrapf(err, "%s[%d]", name, i)
+				}
+			}
+			return nil
+		}
+	}
+
+	//nolint:exhaustruct
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	var (
+		text     *string
+		children []mapField
+	)
+	switch {
+	case !rv.IsValid():
+	case rv.Kind() == reflect.Map || rv.Type() == yamlMapSliceType:
+		fields, err := mapFields(v)
+		if err != nil {
+			return errors.Wrap(err, name)
+		}
+		for _, f := range fields {
+			switch {
+			case strings.HasPrefix(f.name, "@"):
+				s, err := reflectutil.ConvertString(reflect.ValueOf(f.value))
+				if err != nil {
+					return errors.Wrapf(err, "%s.%s", name, f.name)
+				}
+				//nolint:exhaustruct
+				start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: strings.TrimPrefix(f.name, "@")}, Value: s})
+			case f.name == "#text":
+				s, err := reflectutil.ConvertString(reflect.ValueOf(f.value))
+				if err != nil {
+					return errors.Wrapf(err, "%s.%s", name, f.name)
+				}
+				text = &s
+			default:
+				children = append(children, f)
+			}
+		}
+	default:
+		s, err := reflectutil.ConvertString(rv)
+		if err != nil {
+			return errors.Wrap(err, name)
+		}
+		text = &s
+	}
+
+	if err := enc.EncodeToken(start); err != nil {
+		return err
+	}
+	if text != nil {
+		if err := enc.EncodeToken(xml.CharData(*text)); err != nil {
+			return err
+		}
+	}
+	for _, child := range children {
+		if err := encodeXMLElement(enc, child.name, child.value); err != nil {
+			return errors.Wrap(err, name)
+		}
+	}
+	return enc.EncodeToken(start.End())
+}
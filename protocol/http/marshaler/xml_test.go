@@ -0,0 +1,115 @@
+package marshaler
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+)
+
+func TestXML_Marshal(t *testing.T) {
+	m := xmlMarshaler{mediaType: "application/xml"}
+	t.Run("success", func(t *testing.T) {
+		tests := map[string]struct {
+			v      interface{}
+			expect string
+		}{
+			"text": {
+				v: map[string]interface{}{
+					"message": "hello",
+				},
+				expect: `<message>hello</message>`,
+			},
+			"empty": {
+				v: map[string]interface{}{
+					"message": nil,
+				},
+				expect: `<message></message>`,
+			},
+			"escape": {
+				v: map[string]interface{}{
+					"message": "<a & b>",
+				},
+				expect: `<message>&lt;a &amp; b&gt;</message>`,
+			},
+			"attributes and children": {
+				v: yaml.MapSlice{
+					{
+						Key: "soap:Envelope",
+						Value: yaml.MapSlice{
+							{Key: "@xmlns:soap", Value: "http://schemas.xmlsoap.org/soap/envelope/"},
+							{
+								Key: "soap:Body",
+								Value: yaml.MapSlice{
+									{
+										Key: "items",
+										Value: map[string]interface{}{
+											"@count": 2,
+											"item": []interface{}{
+												map[string]interface{}{"@id": 1, "#text": "foo"},
+												"bar",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				expect: `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body><items count="2"><item id="1">foo</item><item>bar</item></items></soap:Body></soap:Envelope>`,
+			},
+		}
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				b, err := m.Marshal(test.v)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if got, expect := string(b), `<?xml version="1.0" encoding="UTF-8"?>`+"\n"+test.expect; got != expect {
+					t.Errorf("expect %q but got %q", expect, got)
+				}
+			})
+		}
+	})
+	t.Run("failure", func(t *testing.T) {
+		tests := map[string]struct {
+			v      interface{}
+			expect string
+		}{
+			"not map": {
+				v:      "hello",
+				expect: "expected map but got string",
+			},
+			"no root element": {
+				v:      map[string]interface{}{},
+				expect: "expected one root element but got 0",
+			},
+			"multiple root elements": {
+				v: map[string]interface{}{
+					"a": "1",
+					"b": "2",
+				},
+				expect: "expected one root element but got 2",
+			},
+			"invalid attribute": {
+				v: map[string]interface{}{
+					"a": map[string]interface{}{
+						"@id": []interface{}{1},
+					},
+				},
+				expect: "a.@id: expected string but got []interface {}",
+			},
+		}
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				_, err := m.Marshal(test.v)
+				if err == nil {
+					t.Fatal("no error")
+				}
+				if got := err.Error(); !strings.Contains(got, test.expect) {
+					t.Errorf("expect %q but got %q", test.expect, got)
+				}
+			})
+		}
+	})
+}
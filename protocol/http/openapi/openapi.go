@@ -0,0 +1,247 @@
+// Package openapi provides the validation of HTTP responses against OpenAPI 3 documents.
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/legacy"
+	"github.com/zoncoen/query-go"
+
+	"github.com/zoncoen/scenarigo/errors"
+)
+
+// Document represents an OpenAPI 3 document to validate HTTP responses.
+// It also records the operations that have been exercised.
+type Document struct {
+	doc    *openapi3.T
+	router routers.Router
+	ops    []*Operation
+
+	m         sync.Mutex
+	exercised map[*openapi3.Operation]int
+}
+
+// Operation represents an operation of the OpenAPI document.
+type Operation struct {
+	Method      string
+	Path        string
+	OperationID string
+}
+
+// String returns the operation as "METHOD /path (operationId)".
+func (o *Operation) String() string {
+	s := fmt.Sprintf("%s %s", o.Method, o.Path)
+	if o.OperationID != "" {
+		s = fmt.Sprintf("%s (%s)", s, o.OperationID)
+	}
+	return s
+}
+
+// Load loads an OpenAPI 3 document from path.
+func Load(path string) (*Document, error) {
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	doc, err := loader.LoadFromFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return New(loader.Context, doc)
+}
+
+// New returns a new document from the loaded OpenAPI document.
+func New(ctx context.Context, doc *openapi3.T) (*Document, error) {
+	if err := doc.Validate(ctx); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI document: %w", err)
+	}
+
+	// The requests are sent to arbitrary hosts such as local servers in testing,
+	// so only the base paths of the servers are used to find the operations.
+	servers := openapi3.Servers{}
+	seen := map[string]bool{}
+	for _, s := range doc.Servers {
+		bp, err := s.BasePath()
+		if err != nil {
+			return nil, fmt.Errorf("invalid server URL %q: %w", s.URL, err)
+		}
+		if seen[bp] {
+			continue
+		}
+		seen[bp] = true
+		servers = append(servers, &openapi3.Server{URL: bp})
+	}
+	doc.Servers = servers
+
+	router, err := legacy.NewRouter(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create router: %w", err)
+	}
+
+	ops := []*Operation{}
+	for _, path := range doc.Paths.InMatchingOrder() {
+		for method, op := range doc.Paths.Value(path).Operations() {
+			ops = append(ops, &Operation{
+				Method:      method,
+				Path:        path,
+				OperationID: op.OperationID,
+			})
+		}
+	}
+	sort.Slice(ops, func(i, j int) bool {
+		if ops[i].Path == ops[j].Path {
+			return ops[i].Method < ops[j].Method
+		}
+		return ops[i].Path < ops[j].Path
+	})
+
+	return &Document{
+		doc:       doc,
+		router:    router,
+		ops:       ops,
+		exercised: map[*openapi3.Operation]int{},
+	}, nil
+}
+
+// ValidateResponse validates the response against the operation that matches the request.
+// It returns nil without validation if the document has no operation for the request.
+// The returned error has the path of the invalid part of the response such as ".code", ".header.X-Rate-Limit", and ".body.items[0].id".
+func (d *Document) ValidateResponse(ctx context.Context, req *http.Request, status int, header http.Header, body []byte) error {
+	// clear the scheme and host to match the request with the base paths of the servers
+	r := req.Clone(ctx)
+	r.URL = &url.URL{
+		Path:     req.URL.Path,
+		RawPath:  req.URL.RawPath,
+		RawQuery: req.URL.RawQuery,
+	}
+	route, pathParams, err := d.router.FindRoute(r)
+	if err != nil {
+		return nil //nolint:nilerr
+	}
+
+	d.m.Lock()
+	d.exercised[route.Operation]++
+	d.m.Unlock()
+
+	input := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: &openapi3filter.RequestValidationInput{
+			Request:    r,
+			PathParams: pathParams,
+			Route:      route,
+		},
+		Status: status,
+		Header: header,
+		Options: &openapi3filter.Options{
+			IncludeResponseStatus: true,
+		},
+	}
+	input.SetBodyBytes(body)
+	if err := openapi3filter.ValidateResponse(ctx, input); err != nil {
+		return convertError(err, route, status)
+	}
+	return nil
+}
+
+var headerReasonPattern = regexp.MustCompile(`header "([^"]+)"`)
+
+func convertError(err error, route *routers.Route, status int) error {
+	var re *openapi3filter.ResponseError
+	if !errors.As(err, &re) {
+		return errors.Wrapf(err, "failed to validate response by OpenAPI operation %s %s", route.Method, route.Path)
+	}
+	msg := fmt.Sprintf("response doesn't match OpenAPI operation %s %s", route.Method, route.Path)
+	switch {
+	case re.Reason == "status is not supported":
+		return errors.ErrorPathf("code", "%s: status %d is not defined", msg, status)
+	case strings.Contains(re.Reason, "Content-Type"):
+		return errors.ErrorQueryf(query.New().Key("header").Key("Content-Type"), "%s: %s", msg, re.Reason)
+	case strings.HasPrefix(re.Reason, "response body"), strings.HasPrefix(re.Reason, "failed to decode response body"):
+		var se *openapi3.SchemaError
+		if errors.As(re.Err, &se) {
+			q := query.New().Key("body")
+			for _, p := range se.JSONPointer() {
+				if i, err := strconv.Atoi(p); err == nil {
+					q = q.Index(i)
+				} else {
+					q = q.Key(p)
+				}
+			}
+			return errors.ErrorQueryf(q, "%s: %s", msg, se.Reason)
+		}
+		return errors.ErrorPathf("body", "%s: %s", msg, re.Error())
+	}
+	if m := headerReasonPattern.FindStringSubmatch(re.Reason); m != nil {
+		return errors.ErrorQueryf(query.New().Key("header").Key(m[1]), "%s: %s", msg, re.Error())
+	}
+	return errors.Errorf("%s: %s", msg, re.Error())
+}
+
+// Coverage returns the coverage of the operations.
+func (d *Document) Coverage() *Coverage {
+	d.m.Lock()
+	defer d.m.Unlock()
+	c := &Coverage{}
+	for _, o := range d.ops {
+		op := d.doc.Paths.Value(o.Path).GetOperation(o.Method)
+		c.Operations = append(c.Operations, &OperationCoverage{
+			Operation: *o,
+			Count:     d.exercised[op],
+		})
+	}
+	return c
+}
+
+// Coverage represents how many times each operation has been exercised.
+type Coverage struct {
+	Operations []*OperationCoverage
+}
+
+// OperationCoverage represents the coverage of an operation.
+type OperationCoverage struct {
+	Operation
+	Count int
+}
+
+// Uncovered returns the operations that have never been exercised.
+func (c *Coverage) Uncovered() []*Operation {
+	ops := []*Operation{}
+	for _, o := range c.Operations {
+		if o.Count == 0 {
+			op := o.Operation
+			ops = append(ops, &op)
+		}
+	}
+	return ops
+}
+
+// WriteSummary writes the coverage summary to w.
+func (c *Coverage) WriteSummary(w io.Writer) error {
+	var b bytes.Buffer
+	total := len(c.Operations)
+	uncovered := c.Uncovered()
+	covered := total - len(uncovered)
+	percent := 100.0
+	if total > 0 {
+		percent = float64(covered) / float64(total) * 100
+	}
+	fmt.Fprintf(&b, "OpenAPI coverage: %d/%d operations (%.1f%%)\n", covered, total, percent)
+	if len(uncovered) > 0 {
+		fmt.Fprintln(&b, "not exercised operations:")
+		for _, o := range uncovered {
+			fmt.Fprintf(&b, "  %s\n", o)
+		}
+	}
+	_, err := w.Write(b.Bytes())
+	return err
+}
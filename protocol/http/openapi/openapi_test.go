@@ -0,0 +1,160 @@
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/zoncoen/scenarigo/errors"
+)
+
+func TestDocument_ValidateResponse(t *testing.T) {
+	tests := map[string]struct {
+		method string
+		url    string
+		status int
+		header http.Header
+		body   string
+		path   string
+	}{
+		"valid": {
+			method: http.MethodGet,
+			url:    "http://localhost:8080/v1/pets",
+			status: http.StatusOK,
+			header: http.Header{
+				"Content-Type":  []string{"application/json"},
+				"X-Total-Count": []string{"1"},
+			},
+			body: `[{"id": 1, "name": "Tama"}]`,
+		},
+		"valid (path parameter)": {
+			method: http.MethodGet,
+			url:    "http://localhost:8080/v1/pets/1",
+			status: http.StatusNotFound,
+		},
+		"unknown operation": {
+			method: http.MethodDelete,
+			url:    "http://localhost:8080/v1/pets/1",
+			status: http.StatusNoContent,
+		},
+		"undefined status": {
+			method: http.MethodGet,
+			url:    "http://localhost:8080/v1/pets/1",
+			status: http.StatusInternalServerError,
+			path:   ".code",
+		},
+		"missing header": {
+			method: http.MethodGet,
+			url:    "http://localhost:8080/v1/pets",
+			status: http.StatusOK,
+			header: http.Header{
+				"Content-Type": []string{"application/json"},
+			},
+			body: `[]`,
+			path: ".header.X-Total-Count",
+		},
+		"invalid content type": {
+			method: http.MethodGet,
+			url:    "http://localhost:8080/v1/pets/1",
+			status: http.StatusOK,
+			header: http.Header{
+				"Content-Type": []string{"text/plain"},
+			},
+			body: `Tama`,
+			path: ".header.Content-Type",
+		},
+		"invalid body": {
+			method: http.MethodGet,
+			url:    "http://localhost:8080/v1/pets",
+			status: http.StatusOK,
+			header: http.Header{
+				"Content-Type":  []string{"application/json"},
+				"X-Total-Count": []string{"1"},
+			},
+			body: `[{"id": 1, "name": 2}]`,
+			path: ".body[0].name",
+		},
+		"missing property": {
+			method: http.MethodGet,
+			url:    "http://localhost:8080/v1/pets/1",
+			status: http.StatusOK,
+			header: http.Header{
+				"Content-Type": []string{"application/json"},
+			},
+			body: `{"id": 1}`,
+			path: ".body.name",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			doc, err := Load("testdata/petstore.yaml")
+			if err != nil {
+				t.Fatalf("failed to load: %s", err)
+			}
+			req, err := http.NewRequest(test.method, test.url, nil)
+			if err != nil {
+				t.Fatalf("failed to create request: %s", err)
+			}
+			err = doc.ValidateResponse(context.Background(), req, test.status, test.header, []byte(test.body))
+			if test.path == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("no error")
+			}
+			var pe *errors.PathError
+			if !errors.As(err, &pe) {
+				t.Fatalf("expected PathError but got %T: %s", err, err)
+			}
+			if got, expect := pe.Path, test.path; got != expect {
+				t.Errorf("expected path %q but got %q: %s", expect, got, err)
+			}
+		})
+	}
+}
+
+func TestDocument_Coverage(t *testing.T) {
+	doc, err := Load("testdata/petstore.yaml")
+	if err != nil {
+		t.Fatalf("failed to load: %s", err)
+	}
+	for _, u := range []string{"http://localhost/v1/pets/1", "http://localhost/v1/pets/2", "http://localhost/v2/pets"} {
+		req, err := http.NewRequest(http.MethodGet, u, nil)
+		if err != nil {
+			t.Fatalf("failed to create request: %s", err)
+		}
+		_ = doc.ValidateResponse(context.Background(), req, http.StatusNotFound, nil, nil)
+	}
+
+	cov := doc.Coverage()
+	counts := map[string]int{}
+	for _, o := range cov.Operations {
+		counts[o.Operation.String()] = o.Count
+	}
+	if diff := cmp.Diff(map[string]int{
+		"GET /pets (listPets)":       0,
+		"POST /pets (createPet)":     0,
+		"GET /pets/{petId} (getPet)": 2,
+	}, counts); diff != "" {
+		t.Errorf("coverage differs (-want +got):\n%s", diff)
+	}
+
+	var b bytes.Buffer
+	if err := cov.WriteSummary(&b); err != nil {
+		t.Fatalf("failed to write summary: %s", err)
+	}
+	expect := `OpenAPI coverage: 1/3 operations (33.3%)
+not exercised operations:
+  GET /pets (listPets)
+  POST /pets (createPet)
+`
+	if diff := cmp.Diff(expect, b.String()); diff != "" {
+		t.Errorf("summary differs (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,331 @@
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"dario.cat/mergo"
+	"github.com/goccy/go-yaml"
+
+	"github.com/zoncoen/scenarigo/context"
+	"github.com/zoncoen/scenarigo/errors"
+	"github.com/zoncoen/scenarigo/internal/filepathutil"
+	"github.com/zoncoen/scenarigo/internal/reflectutil"
+	grpcproto "github.com/zoncoen/scenarigo/protocol/grpc/proto"
+)
+
+var tlsVers = map[string]uint16{
+	tls.VersionName(tls.VersionTLS10): tls.VersionTLS10,
+	tls.VersionName(tls.VersionTLS11): tls.VersionTLS11,
+	tls.VersionName(tls.VersionTLS12): tls.VersionTLS12,
+	tls.VersionName(tls.VersionTLS13): tls.VersionTLS13,
+	tls.VersionName(tls.VersionSSL30): tls.VersionSSL30,
+}
+
+// RequestOptions represents request options.
+type RequestOptions struct {
+	// BaseURL is prepended to the request URL unless it is an absolute URL.
+	BaseURL string `yaml:"baseURL,omitempty"`
+
+	// Header is the default request header.
+	// The values of the request header take precedence over it.
+	Header interface{} `yaml:"header,omitempty"`
+
+	// Timeout is the time limit for the request such as "10s".
+	Timeout string `yaml:"timeout,omitempty"`
+
+	// Proxy is the URL of the proxy server.
+	// By default, the proxy is determined by the environment variables such as HTTP_PROXY.
+	Proxy string `yaml:"proxy,omitempty"`
+
+	Redirect *RedirectOption `yaml:"redirect,omitempty"`
+	TLS      *TLSOption      `yaml:"tls,omitempty"`
+
+	// CookieJar enables the cookie jar shared across the HTTP requests of the scenario.
+	// The cookies set by the responses are stored and sent with the following requests.
+	CookieJar *bool `yaml:"cookieJar,omitempty"`
+
+	Proto *ProtoOption `yaml:"proto,omitempty"`
+}
+
+// ProtoOption represents a protocol buffers option to encode and decode the bodies of "application/x-protobuf".
+type ProtoOption struct {
+	Imports []string `yaml:"imports,omitempty"`
+	Files   []string `yaml:"files,omitempty"`
+
+	// Request and Response are the full names of the message types.
+	// They are used if the Content-Type header has no proto parameter.
+	Request  string `yaml:"request,omitempty"`
+	Response string `yaml:"response,omitempty"`
+}
+
+// RedirectOption represents a redirect policy.
+type RedirectOption struct {
+	// Disabled disables following redirects.
+	// The redirect response is returned as it is.
+	Disabled bool `yaml:"disabled,omitempty"`
+
+	// Max is the maximum number of redirects to follow.
+	// By default, 10 redirects are followed at most.
+	Max int `yaml:"max,omitempty"`
+}
+
+// TLSOption represents a TLS option.
+type TLSOption struct {
+	// MinVersion contains the minimum TLS version that is acceptable.
+	// By default, TLS 1.2 is currently used as the minimum.
+	MinVersion string `yaml:"minVersion,omitempty"`
+
+	// MaxVersion contains the maximum TLS version that is acceptable.
+	// By default, TLS 1.3 is currently used as the maximum.
+	MaxVersion string `yaml:"maxVersion,omitempty"`
+
+	// Certificate is the file path of the CA certificates to verify the server certificate.
+	Certificate string `yaml:"certificate,omitempty"`
+
+	// ClientCertificate and ClientKey are the file paths of the client certificate and key for mutual TLS.
+	ClientCertificate string `yaml:"clientCertificate,omitempty"`
+	ClientKey         string `yaml:"clientKey,omitempty"`
+
+	Skip bool `yaml:"skip,omitempty"`
+}
+
+// resolveOptions returns the request options merged with the options of the protocol.
+// The request options take precedence over the options of the protocol.
+func resolveOptions(ctx *context.Context, reqOpts *RequestOptions) (*RequestOptions, error) {
+	opts := &RequestOptions{}
+	if reqOpts != nil {
+		// copy not to modify the options of the request by merging the options of the protocol
+		var err error
+		opts, err = reqOpts.clone()
+		if err != nil {
+			return nil, errors.WithPath(err, "options")
+		}
+	}
+	if pOpt := httpProtocol.getOption(); pOpt != nil && pOpt.Request != nil {
+		if err := mergo.Merge(opts, pOpt.Request, mergo.WithoutDereference); err != nil {
+			return nil, errors.WrapPath(err, "options", "failed to apply options")
+		}
+	}
+	opts, err := context.ExecuteTemplate(ctx, opts)
+	if err != nil {
+		return nil, errors.WrapPath(err, "options", "failed to execute template")
+	}
+	return opts, nil
+}
+
+// clone returns a deep copy of o.
+func (o *RequestOptions) clone() (*RequestOptions, error) {
+	b, err := yaml.Marshal(o)
+	if err != nil {
+		return nil, errors.Errorf("failed to marshal options: %s", err)
+	}
+	var clone RequestOptions
+	if err := yaml.Unmarshal(b, &clone); err != nil {
+		return nil, errors.Errorf("failed to unmarshal options: %s", err)
+	}
+	return &clone, nil
+}
+
+// header returns the default request header.
+func (o *RequestOptions) header() (http.Header, error) {
+	header := http.Header{}
+	if o.Header == nil {
+		return header, nil
+	}
+	hdr, err := reflectutil.ConvertStringsMap(reflect.ValueOf(o.Header))
+	if err != nil {
+		return nil, errors.WrapPathf(err, "header", "failed to set header")
+	}
+	for k, vs := range hdr {
+		for _, v := range vs {
+			header.Add(k, v)
+		}
+	}
+	return header, nil
+}
+
+func (o *RequestOptions) cookieJarEnabled() bool {
+	if o != nil && o.CookieJar != nil {
+		return *o.CookieJar
+	}
+	return false
+}
+
+func (o *RequestOptions) timeout() (time.Duration, error) {
+	if o.Timeout == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(o.Timeout)
+	if err != nil {
+		return 0, errors.WrapPath(err, "timeout", "invalid timeout")
+	}
+	return d, nil
+}
+
+// url joins the base URL and the request URL.
+func (o *RequestOptions) url(urlStr string) (string, error) {
+	if o.BaseURL == "" {
+		return urlStr, nil
+	}
+	u, err := url.Parse(urlStr)
+	if err != nil {
+		return "", errors.WrapPathf(err, "url", "invalid url: %s", urlStr)
+	}
+	if u.IsAbs() {
+		return urlStr, nil
+	}
+	if urlStr == "" {
+		return o.BaseURL, nil
+	}
+	return fmt.Sprintf("%s/%s", strings.TrimSuffix(o.BaseURL, "/"), strings.TrimPrefix(urlStr, "/")), nil
+}
+
+func (o *RedirectOption) checkRedirect() func(*http.Request, []*http.Request) error {
+	if o == nil {
+		return nil
+	}
+	return func(_ *http.Request, via []*http.Request) error {
+		if o.Disabled {
+			return http.ErrUseLastResponse
+		}
+		limit := o.Max
+		if limit == 0 {
+			limit = 10
+		}
+		if len(via) >= limit {
+			return errors.Errorf("stopped after %d redirects", limit)
+		}
+		return nil
+	}
+}
+
+func (o *TLSOption) config() (*tls.Config, error) {
+	//nolint:exhaustruct
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if o.MinVersion != "" {
+		v, ok := tlsVers[o.MinVersion]
+		if !ok {
+			return nil, errors.ErrorPathf("minVersion", "invalid minimum TLS version %s", o.MinVersion)
+		}
+		cfg.MinVersion = v
+	}
+	if o.MaxVersion != "" {
+		v, ok := tlsVers[o.MaxVersion]
+		if !ok {
+			return nil, errors.ErrorPathf("maxVersion", "invalid maximum TLS version %s", o.MaxVersion)
+		}
+		cfg.MaxVersion = v
+	}
+	if o.Certificate != "" {
+		b, err := os.ReadFile(o.Certificate)
+		if err != nil {
+			return nil, errors.WrapPath(err, "certificate", "failed to read certificate")
+		}
+		cp := x509.NewCertPool()
+		if !cp.AppendCertsFromPEM(b) {
+			return nil, errors.ErrorPath("certificate", "failed to append certificate")
+		}
+		cfg.RootCAs = cp
+	}
+	if o.ClientCertificate != "" || o.ClientKey != "" {
+		cert, err := tls.LoadX509KeyPair(o.ClientCertificate, o.ClientKey)
+		if err != nil {
+			return nil, errors.WrapPath(err, "clientCertificate", "failed to load client certificate")
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	if o.Skip {
+		cfg.InsecureSkipVerify = true
+	}
+	return cfg, nil
+}
+
+// messageResolver returns the resolver of the message types defined in the proto files.
+// The relative file paths are resolved from the directory of the scenario file.
+// The compiled files are cached.
+func (p *HTTP) messageResolver(ctx *context.Context, opts *ProtoOption) (grpcproto.MessageDescriptorResolver, error) {
+	if opts == nil || len(opts.Files) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+	dir := filepath.Dir(ctx.ScenarioFilepath())
+	imports := make([]string, len(opts.Imports))
+	for i, f := range opts.Imports {
+		imports[i] = filepathutil.From(dir, f)
+	}
+	files := make([]string, len(opts.Files))
+	for i, f := range opts.Files {
+		// If import paths present and not empty, then all file paths to find are assumed to be relative to one of these paths.
+		if len(imports) == 0 {
+			f = filepathutil.From(dir, f)
+		}
+		files[i] = f
+	}
+	key := fmt.Sprintf("imports=%s:files=%s", strings.Join(imports, ","), strings.Join(files, ","))
+
+	p.m.Lock()
+	defer p.m.Unlock()
+	if fds, ok := p.fds[key]; ok {
+		return fds, nil
+	}
+	fds, err := grpcproto.NewCompiler(imports).Compile(ctx.RequestContext(), files)
+	if err != nil {
+		return nil, err
+	}
+	if p.fds == nil {
+		p.fds = map[string]grpcproto.FileDescriptors{}
+	}
+	p.fds[key] = fds
+	return fds, nil
+}
+
+// transport returns the transport for the proxy and TLS options.
+// The transports are cached to reuse the connections.
+func (p *HTTP) transport(opts *RequestOptions) (http.RoundTripper, error) {
+	if opts.Proxy == "" && opts.TLS == nil {
+		return http.DefaultTransport, nil
+	}
+	key, err := yaml.Marshal(struct {
+		Proxy string     `yaml:"proxy"`
+		TLS   *TLSOption `yaml:"tls"`
+	}{opts.Proxy, opts.TLS})
+	if err != nil {
+		return nil, errors.Errorf("failed to marshal options: %s", err)
+	}
+
+	p.m.Lock()
+	defer p.m.Unlock()
+	if t, ok := p.transports[string(key)]; ok {
+		return t, nil
+	}
+	t := http.DefaultTransport.(*http.Transport).Clone() //nolint:forcetypeassert
+	if opts.Proxy != "" {
+		u, err := url.Parse(opts.Proxy)
+		if err != nil {
+			return nil, errors.WrapPathf(err, "proxy", "invalid proxy url: %s", opts.Proxy)
+		}
+		t.Proxy = http.ProxyURL(u)
+	}
+	if opts.TLS != nil {
+		cfg, err := opts.TLS.config()
+		if err != nil {
+			return nil, errors.WithPath(err, "tls")
+		}
+		t.TLSClientConfig = cfg
+	}
+	if p.transports == nil {
+		p.transports = map[string]*http.Transport{}
+	}
+	p.transports[string(key)] = t
+	return t, nil
+}
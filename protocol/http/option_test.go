@@ -0,0 +1,447 @@
+package http
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/zoncoen/scenarigo/context"
+)
+
+func TestRequest_Invoke_Options(t *testing.T) {
+	m := http.NewServeMux()
+	m.HandleFunc("/api/echo", func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"foo": %q, "bar": %q}`, req.Header.Get("X-Foo"), req.Header.Get("X-Bar"))))
+	})
+	m.HandleFunc("/redirect/", func(w http.ResponseWriter, req *http.Request) {
+		var n int
+		if _, err := fmt.Sscanf(strings.TrimPrefix(req.URL.Path, "/redirect/"), "%d", &n); err != nil || n == 0 {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		http.Redirect(w, req, fmt.Sprintf("/redirect/%d", n-1), http.StatusFound)
+	})
+	m.HandleFunc("/slow", func(w http.ResponseWriter, req *http.Request) {
+		select {
+		case <-req.Context().Done():
+		case <-time.After(time.Second):
+		}
+	})
+	srv := httptest.NewServer(m)
+	defer srv.Close()
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"proxied": %q}`, req.URL.String())))
+	}))
+	defer proxy.Close()
+
+	tests := map[string]struct {
+		option      *Option
+		request     *Request
+		expectCode  int
+		expectBody  any
+		expectError string
+	}{
+		"base URL": {
+			option: &Option{
+				Request: &RequestOptions{
+					BaseURL: srv.URL + "/api",
+				},
+			},
+			request: &Request{
+				URL: "/echo",
+			},
+			expectCode: http.StatusOK,
+			expectBody: map[string]any{"foo": "", "bar": ""},
+		},
+		"absolute URL with base URL": {
+			option: &Option{
+				Request: &RequestOptions{
+					BaseURL: "http://example.invalid",
+				},
+			},
+			request: &Request{
+				URL: srv.URL + "/api/echo",
+			},
+			expectCode: http.StatusOK,
+			expectBody: map[string]any{"foo": "", "bar": ""},
+		},
+		"default header": {
+			option: &Option{
+				Request: &RequestOptions{
+					Header: map[string]any{
+						"X-Foo": "default",
+						"X-Bar": "default",
+					},
+				},
+			},
+			request: &Request{
+				URL: srv.URL + "/api/echo",
+				Header: map[string]any{
+					"X-Bar": "bar",
+				},
+			},
+			expectCode: http.StatusOK,
+			expectBody: map[string]any{"foo": "default", "bar": "bar"},
+		},
+		"request options take precedence": {
+			option: &Option{
+				Request: &RequestOptions{
+					BaseURL: "http://example.invalid",
+				},
+			},
+			request: &Request{
+				URL: "/api/echo",
+				Options: &RequestOptions{
+					BaseURL: srv.URL,
+				},
+			},
+			expectCode: http.StatusOK,
+			expectBody: map[string]any{"foo": "", "bar": ""},
+		},
+		"timeout": {
+			option: &Option{
+				Request: &RequestOptions{
+					Timeout: "10ms",
+				},
+			},
+			request: &Request{
+				URL: srv.URL + "/slow",
+			},
+			expectError: "Client.Timeout exceeded",
+		},
+		"invalid timeout": {
+			request: &Request{
+				URL: srv.URL + "/slow",
+				Options: &RequestOptions{
+					Timeout: "1",
+				},
+			},
+			expectError: ".options.timeout: invalid timeout",
+		},
+		"follow redirects": {
+			request: &Request{
+				URL: srv.URL + "/redirect/3",
+			},
+			expectCode: http.StatusOK,
+		},
+		"redirect disabled": {
+			option: &Option{
+				Request: &RequestOptions{
+					Redirect: &RedirectOption{
+						Disabled: true,
+					},
+				},
+			},
+			request: &Request{
+				URL: srv.URL + "/redirect/3",
+			},
+			expectCode: http.StatusFound,
+			expectBody: "<a href=\"/redirect/2\">Found</a>.\n\n",
+		},
+		"too many redirects": {
+			option: &Option{
+				Request: &RequestOptions{
+					Redirect: &RedirectOption{
+						Max: 2,
+					},
+				},
+			},
+			request: &Request{
+				URL: srv.URL + "/redirect/3",
+			},
+			expectError: "stopped after 2 redirects",
+		},
+		"proxy": {
+			option: &Option{
+				Request: &RequestOptions{
+					Proxy: proxy.URL,
+				},
+			},
+			request: &Request{
+				URL: "http://example.invalid/foo",
+			},
+			expectCode: http.StatusOK,
+			expectBody: map[string]any{"proxied": "http://example.invalid/foo"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if test.option != nil {
+				httpProtocol.option = *test.option
+				defer func() {
+					httpProtocol.option = Option{}
+				}()
+			}
+			_, res, err := test.request.Invoke(context.FromT(t))
+			if test.expectError != "" {
+				if err == nil {
+					t.Fatal("no error")
+				}
+				if !strings.Contains(err.Error(), test.expectError) {
+					t.Fatalf("expect error %q but got %q", test.expectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to invoke: %s", err)
+			}
+			resp, ok := res.(response)
+			if !ok {
+				t.Fatalf("failed to convert from %T to response", res)
+			}
+			if got, expect := resp.StatusCode, test.expectCode; got != expect {
+				t.Errorf("expect status code %d but got %d", expect, got)
+			}
+			if diff := cmp.Diff(test.expectBody, resp.Body); diff != "" {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRequest_Invoke_OptionsNotModified(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"foo": %q, "bar": %q}`, req.Header.Get("X-Foo"), req.Header.Get("X-Bar"))))
+	}))
+	defer srv.Close()
+
+	httpProtocol.option = Option{
+		Request: &RequestOptions{
+			Header: map[string]any{
+				"X-Foo": "default",
+				"X-Bar": "default",
+			},
+			TLS: &TLSOption{
+				MinVersion: "TLS 1.3",
+			},
+		},
+	}
+	defer func() {
+		httpProtocol.option = Option{}
+	}()
+	req := &Request{
+		URL: srv.URL,
+		Options: &RequestOptions{
+			Header: map[string]any{
+				"X-Bar": "bar",
+			},
+			TLS: &TLSOption{},
+		},
+	}
+	for range 2 {
+		_, res, err := req.Invoke(context.FromT(t))
+		if err != nil {
+			t.Fatalf("failed to invoke: %s", err)
+		}
+		resp, ok := res.(response)
+		if !ok {
+			t.Fatalf("failed to convert from %T to response", res)
+		}
+		if diff := cmp.Diff(map[string]any{"foo": "default", "bar": "bar"}, resp.Body); diff != "" {
+			t.Errorf("differs: (-want +got)\n%s", diff)
+		}
+	}
+	expect := &RequestOptions{
+		Header: map[string]any{
+			"X-Bar": "bar",
+		},
+		TLS: &TLSOption{},
+	}
+	if diff := cmp.Diff(expect, req.Options); diff != "" {
+		t.Errorf("request options are modified: (-want +got)\n%s", diff)
+	}
+}
+
+func TestRequest_Invoke_TLS(t *testing.T) {
+	caPath, certPath, keyPath := generateCert(t)
+	b, err := os.ReadFile(caPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cp := x509.NewCertPool()
+	if !cp.AppendCertsFromPEM(b) {
+		t.Fatal("failed to append certificate")
+	}
+	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, _ = io.WriteString(w, req.TLS.PeerCertificates[0].DNSNames[0])
+	}))
+	//nolint:exhaustruct
+	srv.TLS = &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    cp,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}
+	srv.Config.ErrorLog = log.New(io.Discard, "", 0)
+	srv.StartTLS()
+	defer srv.Close()
+
+	tests := map[string]struct {
+		tls         *TLSOption
+		expectError string
+	}{
+		"mutual TLS": {
+			tls: &TLSOption{
+				Certificate:       caPath,
+				ClientCertificate: certPath,
+				ClientKey:         keyPath,
+			},
+		},
+		"no client certificate": {
+			tls: &TLSOption{
+				Certificate: caPath,
+			},
+			expectError: "failed to send request",
+		},
+		"unknown authority": {
+			tls: &TLSOption{
+				ClientCertificate: certPath,
+				ClientKey:         keyPath,
+			},
+			expectError: "certificate signed by unknown authority",
+		},
+		"skip verification": {
+			tls: &TLSOption{
+				ClientCertificate: certPath,
+				ClientKey:         keyPath,
+				Skip:              true,
+			},
+		},
+		"invalid version": {
+			tls: &TLSOption{
+				MinVersion: "TLS 0.1",
+			},
+			expectError: ".options.tls.minVersion: invalid minimum TLS version TLS 0.1",
+		},
+		"certificate not found": {
+			tls: &TLSOption{
+				Certificate: "not-found.crt",
+			},
+			expectError: ".options.tls.certificate: failed to read certificate",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := &Request{
+				URL: srv.URL,
+				Options: &RequestOptions{
+					TLS: test.tls,
+				},
+			}
+			_, res, err := req.Invoke(context.FromT(t))
+			if test.expectError != "" {
+				if err == nil {
+					t.Fatal("no error")
+				}
+				if !strings.Contains(err.Error(), test.expectError) {
+					t.Fatalf("expect error %q but got %q", test.expectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to invoke: %s", err)
+			}
+			if diff := cmp.Diff("localhost", res.(response).Body); diff != "" {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func generateCert(t *testing.T) (string, string, string) {
+	t.Helper()
+	tmp := t.TempDir()
+	now := time.Now()
+	validityPeriod := time.Hour
+
+	ca := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             now,
+		NotAfter:              now.Add(validityPeriod),
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+	caPub, caPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate private key: %s", err)
+	}
+	caBytes, err := x509.CreateCertificate(rand.Reader, ca, ca, caPub, caPriv)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %s", err)
+	}
+	caPEM, err := os.Create(filepath.Join(tmp, "ca.crt"))
+	if err != nil {
+		t.Fatalf("failed to create ca.crt: %s", err)
+	}
+	defer caPEM.Close()
+	if err := pem.Encode(caPEM, &pem.Block{Type: "CERTIFICATE", Bytes: caBytes}); err != nil {
+		t.Fatalf("failed to encode PEM: %s", err)
+	}
+
+	cert := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             now,
+		NotAfter:              now.Add(validityPeriod),
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1"), net.IPv6loopback},
+	}
+	certPub, certPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate private key: %s", err)
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, cert, ca, certPub, caPriv)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %s", err)
+	}
+	certPEM, err := os.Create(filepath.Join(tmp, "server.crt"))
+	if err != nil {
+		t.Fatalf("failed to create server.crt: %s", err)
+	}
+	defer certPEM.Close()
+	if err := pem.Encode(certPEM, &pem.Block{Type: "CERTIFICATE", Bytes: certBytes}); err != nil {
+		t.Fatalf("failed to encode PEM: %s", err)
+	}
+	certKeyPEM, err := os.OpenFile(filepath.Join(tmp, "server.key"), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		t.Fatalf("failed to create server.key: %s", err)
+	}
+	defer certKeyPEM.Close()
+	privBytes, err := x509.MarshalPKCS8PrivateKey(certPriv)
+	if err != nil {
+		t.Fatalf("unable to marshal private key: %s", err)
+	}
+	if err := pem.Encode(certKeyPEM, &pem.Block{Type: "PRIVATE KEY", Bytes: privBytes}); err != nil {
+		t.Fatalf("failed to encode PEM: %s", err)
+	}
+
+	return caPEM.Name(), certPEM.Name(), certKeyPEM.Name()
+}
@@ -8,6 +8,7 @@ import (
 	"mime"
 	"net/http"
 	"net/url"
+	"path/filepath"
 	"reflect"
 	"strings"
 
@@ -17,21 +18,31 @@ import (
 	"github.com/zoncoen/scenarigo/errors"
 	"github.com/zoncoen/scenarigo/internal/queryutil"
 	"github.com/zoncoen/scenarigo/internal/reflectutil"
+	"github.com/zoncoen/scenarigo/internal/tracing"
+	"github.com/zoncoen/scenarigo/protocol/http/cookie"
 	"github.com/zoncoen/scenarigo/protocol/http/marshaler"
 	"github.com/zoncoen/scenarigo/protocol/http/unmarshaler"
 	"github.com/zoncoen/scenarigo/version"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var defaultUserAgent = fmt.Sprintf("scenarigo/%s", version.String())
 
 // Request represents a request.
 type Request struct {
-	Client string      `yaml:"client,omitempty"`
-	Method string      `yaml:"method,omitempty"`
-	URL    string      `yaml:"url,omitempty"`
-	Query  interface{} `yaml:"query,omitempty"`
-	Header interface{} `yaml:"header,omitempty"`
-	Body   interface{} `yaml:"body,omitempty"`
+	Client  string          `yaml:"client,omitempty"`
+	Method  string          `yaml:"method,omitempty"`
+	URL     string          `yaml:"url,omitempty"`
+	Query   interface{}     `yaml:"query,omitempty"`
+	Header  interface{}     `yaml:"header,omitempty"`
+	Body    interface{}     `yaml:"body,omitempty"`
+	Options *RequestOptions `yaml:"options,omitempty"`
+
+	// Stream is the option to receive the events of "text/event-stream" responses.
+	Stream *StreamOption `yaml:"stream,omitempty"`
 }
 
 // RequestExtractor represents a request dump.
@@ -55,6 +66,14 @@ type response struct {
 	StatusCode int                 `yaml:"statusCode,omitempty"`
 	Header     map[string][]string `yaml:"header,omitempty"`
 	Body       interface{}         `yaml:"body,omitempty"`
+
+	// for validation by OpenAPI documents
+	req     *http.Request
+	rawBody []byte
+
+	// cookies are the cookies in the cookie jar for the request URL.
+	// If the cookie jar is disabled, they are the cookies set by the response.
+	cookies map[string]string
 }
 
 // ResponseExtractor represents a response dump.
@@ -92,14 +111,34 @@ func (r *Request) addIndent(s string, indentNum int) string {
 
 // Invoke implements protocol.Invoker interface.
 func (r *Request) Invoke(ctx *context.Context) (*context.Context, interface{}, error) {
-	client, err := r.buildClient(ctx)
+	opts, err := resolveOptions(ctx, r.Options)
 	if err != nil {
-		return ctx, nil, errors.WithPath(err, "client")
+		return ctx, nil, err
+	}
+
+	client, err := r.buildClient(ctx, opts)
+	if err != nil {
+		return ctx, nil, err
 	}
-	req, reqBody, err := r.buildRequest(ctx)
+	req, reqBody, err := r.buildRequest(ctx, opts)
 	if err != nil {
 		return ctx, nil, err
 	}
+	receiver, err := r.Stream.Build(ctx)
+	if err != nil {
+		return ctx, nil, errors.WithPath(err, "stream")
+	}
+
+	spanCtx, span := tracing.Start(req.Context(), fmt.Sprintf("HTTP %s", req.Method),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("url.full", redactURL(req.URL)),
+		),
+	)
+	defer span.End()
+	req = req.WithContext(spanCtx)
+	tracing.Inject(spanCtx, propagation.HeaderCarrier(req.Header))
 
 	//nolint:exhaustruct
 	reqDump := &Request{
@@ -117,13 +156,27 @@ func (r *Request) Invoke(ctx *context.Context) (*context.Context, interface{}, e
 
 	resp, err := client.Do(req)
 	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
 		return ctx, nil, errors.Errorf("failed to send request: %s", err)
 	}
 	defer resp.Body.Close()
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, resp.Status)
+	}
 
-	b, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return ctx, nil, errors.Errorf("failed to read response body: %s", err)
+	var events []*Event
+	var b []byte
+	if isEventStream(resp.Header) {
+		events, b, err = receiveEvents(ctx, resp.Body, receiver)
+		if err != nil {
+			return ctx, nil, err
+		}
+	} else {
+		b, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return ctx, nil, errors.Errorf("failed to read response body: %s", err)
+		}
 	}
 
 	rvalue := response{
@@ -131,12 +184,40 @@ func (r *Request) Invoke(ctx *context.Context) (*context.Context, interface{}, e
 		StatusCode: resp.StatusCode,
 		Header:     resp.Header,
 		Body:       nil,
+		req:        req,
+		rawBody:    b,
+		cookies:    map[string]string{},
+	}
+	if jar, ok := client.Jar.(*cookie.Jar); ok && opts.cookieJarEnabled() {
+		rvalue.cookies = jar.Values(req.URL)
+	} else {
+		for _, c := range resp.Cookies() {
+			rvalue.cookies[c.Name] = c.Value
+		}
 	}
-	if len(b) > 0 {
-		unmarshaler := unmarshaler.Get(resp.Header.Get("Content-Type"))
+	if events != nil {
+		rvalue.Body = events
+	} else if len(b) > 0 {
+		um := unmarshaler.Get(resp.Header.Get("Content-Type"))
 		var respBody interface{}
-		if err := unmarshaler.Unmarshal(b, &respBody); err != nil {
-			return ctx, nil, errors.Errorf("failed to unmarshal response body as %s: %s: %s", unmarshaler.MediaType(), string(b), err)
+		if cum, ok := um.(unmarshaler.ContentTypeUnmarshaler); ok {
+			umOpts := &unmarshaler.UnmarshalOptions{
+				ContentType: resp.Header.Get("Content-Type"),
+			}
+			if opts.Proto != nil {
+				resolver, err := httpProtocol.messageResolver(ctx, opts.Proto)
+				if err != nil {
+					return ctx, nil, errors.WrapPath(err, "options.proto", "failed to compile proto files")
+				}
+				umOpts.MessageResolver = resolver
+				umOpts.MessageType = opts.Proto.Response
+			}
+			err = cum.UnmarshalWithContentType(b, &respBody, umOpts)
+		} else {
+			err = um.Unmarshal(b, &respBody)
+		}
+		if err != nil {
+			return ctx, nil, errors.Errorf("failed to unmarshal response body as %s: %s: %s", um.MediaType(), string(b), err)
 		}
 		rvalue.Body = respBody
 	}
@@ -149,22 +230,66 @@ func (r *Request) Invoke(ctx *context.Context) (*context.Context, interface{}, e
 	return ctx, rvalue, nil
 }
 
-func (r *Request) buildClient(ctx *context.Context) (*http.Client, error) {
-	client := &http.Client{
-		Transport: &charsetRoundTripper{
-			base: &encodingRoundTripper{
-				base: http.DefaultTransport,
-			},
-		},
+// redactURL returns the URL to record in the traces.
+// The user info is removed and the query values are redacted because they may have credentials.
+func redactURL(u *url.URL) string {
+	redacted := *u
+	redacted.User = nil
+	if redacted.RawQuery != "" {
+		q := redacted.Query()
+		for _, vs := range q {
+			for i := range vs {
+				vs[i] = "REDACTED"
+			}
+		}
+		redacted.RawQuery = q.Encode()
 	}
+	return redacted.String()
+}
+
+// buildClient returns the HTTP client to send the request.
+// The proxy and TLS options are ignored if the client is specified.
+func (r *Request) buildClient(ctx *context.Context, opts *RequestOptions) (*http.Client, error) {
+	var client *http.Client
 	if r.Client != "" {
 		x, err := ctx.ExecuteTemplate(r.Client)
 		if err != nil {
-			return nil, errors.Wrapf(err, "failed to get client")
+			return nil, errors.WrapPathf(err, "client", "failed to get client")
 		}
-		var ok bool
-		if client, ok = x.(*http.Client); !ok {
-			return nil, errors.Errorf(`client must be "*http.Client" but got "%T"`, x)
+		c, ok := x.(*http.Client)
+		if !ok {
+			return nil, errors.ErrorPathf("client", `client must be "*http.Client" but got "%T"`, x)
+		}
+		// copy not to modify the client of the plugin
+		cc := *c
+		client = &cc
+	} else {
+		transport, err := httpProtocol.transport(opts)
+		if err != nil {
+			return nil, errors.WithPath(err, "options")
+		}
+		//nolint:exhaustruct
+		client = &http.Client{
+			Transport: &charsetRoundTripper{
+				base: &encodingRoundTripper{
+					base: transport,
+				},
+			},
+		}
+	}
+	timeout, err := opts.timeout()
+	if err != nil {
+		return nil, errors.WithPath(err, "options")
+	}
+	if timeout > 0 {
+		client.Timeout = timeout
+	}
+	if opts.Redirect != nil {
+		client.CheckRedirect = opts.Redirect.checkRedirect()
+	}
+	if opts.cookieJarEnabled() {
+		if jar := ctx.CookieJar(); jar != nil {
+			client.Jar = jar
 		}
 	}
 	return client, nil
@@ -225,13 +350,13 @@ func (rt *encodingRoundTripper) RoundTrip(req *http.Request) (*http.Response, er
 	return resp, err
 }
 
-func (r *Request) buildRequest(ctx *context.Context) (*http.Request, interface{}, error) {
+func (r *Request) buildRequest(ctx *context.Context, opts *RequestOptions) (*http.Request, interface{}, error) {
 	method := http.MethodGet
 	if r.Method != "" {
 		method = r.Method
 	}
 
-	urlStr, err := r.buildURL(ctx)
+	urlStr, err := r.buildURL(ctx, opts)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -253,6 +378,16 @@ func (r *Request) buildRequest(ctx *context.Context) (*http.Request, interface{}
 			}
 		}
 	}
+	defaultHeader, err := opts.header()
+	if err != nil {
+		return nil, nil, errors.WithPath(err, "options")
+	}
+	for k, vs := range defaultHeader {
+		if len(header.Values(k)) > 0 {
+			continue
+		}
+		header[k] = vs
+	}
 	if header.Get("User-Agent") == "" {
 		header.Set("User-Agent", defaultUserAgent)
 	}
@@ -266,10 +401,31 @@ func (r *Request) buildRequest(ctx *context.Context) (*http.Request, interface{}
 		}
 		body = x
 
-		marshaler := marshaler.Get(header.Get("Content-Type"))
-		b, err := marshaler.Marshal(body)
+		m := marshaler.Get(header.Get("Content-Type"))
+		var b []byte
+		if cm, ok := m.(marshaler.ContentTypeMarshaler); ok {
+			mOpts := &marshaler.MarshalOptions{
+				ContentType: header.Get("Content-Type"),
+				BaseDir:     filepath.Dir(ctx.ScenarioFilepath()),
+			}
+			if opts.Proto != nil {
+				resolver, err := httpProtocol.messageResolver(ctx, opts.Proto)
+				if err != nil {
+					return nil, nil, errors.WrapPath(err, "options.proto", "failed to compile proto files")
+				}
+				mOpts.MessageResolver = resolver
+				mOpts.MessageType = opts.Proto.Request
+			}
+			var contentType string
+			b, contentType, err = cm.MarshalWithContentType(body, mOpts)
+			if err == nil {
+				header.Set("Content-Type", contentType)
+			}
+		} else {
+			b, err = m.Marshal(body)
+		}
 		if err != nil {
-			return nil, nil, errors.ErrorPathf("body", "failed to marshal request body as %s: %#v: %s", marshaler.MediaType(), body, err)
+			return nil, nil, errors.ErrorPathf("body", "failed to marshal request body as %s: %#v: %s", m.MediaType(), body, err)
 		}
 		reader = bytes.NewReader(b)
 	}
@@ -290,7 +446,7 @@ func (r *Request) buildRequest(ctx *context.Context) (*http.Request, interface{}
 	return req, body, nil
 }
 
-func (r *Request) buildURL(ctx *context.Context) (string, error) {
+func (r *Request) buildURL(ctx *context.Context, opts *RequestOptions) (string, error) {
 	x, err := ctx.ExecuteTemplate(r.URL)
 	if err != nil {
 		return "", errors.WrapPathf(err, "url", "failed to get URL")
@@ -299,6 +455,10 @@ func (r *Request) buildURL(ctx *context.Context) (string, error) {
 	if !ok {
 		return "", errors.ErrorPathf("url", `URL must be "string" but got "%T"`, x)
 	}
+	urlStr, err = opts.url(urlStr)
+	if err != nil {
+		return "", err
+	}
 
 	if r.Query != nil {
 		u, err := url.Parse(urlStr)
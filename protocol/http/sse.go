@@ -0,0 +1,187 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/zoncoen/scenarigo/context"
+	"github.com/zoncoen/scenarigo/errors"
+	"github.com/zoncoen/scenarigo/internal/streamutil"
+	"github.com/zoncoen/scenarigo/protocol/http/unmarshaler"
+)
+
+const (
+	mediaTypeEventStream = "text/event-stream"
+	defaultEventType     = "message"
+
+	maxEventLineSize = 16 * 1024 * 1024
+)
+
+// StreamOption represents options for receiving the events of "text/event-stream" responses.
+type StreamOption = streamutil.Option
+
+// Event represents a server-sent event.
+// The data is decoded if it is a JSON object or array.
+type Event struct {
+	ID    string      `yaml:"id,omitempty"`
+	Event string      `yaml:"event,omitempty"`
+	Data  interface{} `yaml:"data,omitempty"`
+}
+
+func isEventStream(header http.Header) bool {
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+	return mediaType == mediaTypeEventStream
+}
+
+// receivedEvent is an event with the lines of the event stream which compose it.
+type receivedEvent struct {
+	event *Event
+	raw   []byte
+}
+
+// receiveEvents reads the events from the stream until the number of events reaches the count, an event matches the until condition, the stream ends, or the timeout elapses.
+// It returns the received events and their lines for the validation by OpenAPI documents.
+func receiveEvents(ctx *context.Context, body io.Reader, receiver *streamutil.Receiver) ([]*Event, []byte, error) {
+	received := make(chan receivedEvent)
+	readErr := make(chan error, 1)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		readErr <- parseEvents(body, func(ev receivedEvent) bool {
+			select {
+			case received <- ev:
+				return true
+			case <-done:
+				return false
+			}
+		})
+	}()
+
+	events := []*Event{}
+	var raw bytes.Buffer
+	err := streamutil.Receive(ctx, receiver, received, readErr,
+		func(ev receivedEvent) (interface{}, bool, error) {
+			events = append(events, ev.event)
+			raw.Write(ev.raw)
+			return ev.event, false, nil
+		},
+		func(err error) error {
+			if err != nil {
+				return errors.Errorf("failed to read event stream: %s", err)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return events, raw.Bytes(), nil
+}
+
+// parseEvents parses the event stream and calls the dispatch function for each event.
+// It stops parsing if the dispatch function returns false.
+// See https://html.spec.whatwg.org/multipage/server-sent-events.html#event-stream-interpretation.
+func parseEvents(r io.Reader, dispatch func(receivedEvent) bool) error {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxEventLineSize)
+	s.Split(scanLines)
+	var (
+		lastEventID string
+		eventType   string
+		data        []string
+		hasData     bool
+		raw         []byte
+	)
+	for s.Scan() {
+		line := s.Text()
+		raw = append(append(raw, line...), '\n')
+		if line == "" {
+			if hasData {
+				ev := &Event{
+					ID:    lastEventID,
+					Event: eventType,
+					Data:  decodeEventData(strings.Join(data, "\n")),
+				}
+				if ev.Event == "" {
+					ev.Event = defaultEventType
+				}
+				if !dispatch(receivedEvent{event: ev, raw: raw}) {
+					return nil
+				}
+			}
+			eventType = ""
+			data = nil
+			hasData = false
+			raw = nil
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			eventType = value
+		case "data":
+			data = append(data, value)
+			hasData = true
+		case "id":
+			if !strings.Contains(value, "\x00") {
+				lastEventID = value
+			}
+		}
+	}
+	return s.Err()
+}
+
+// scanLines is a split function which splits the stream by CRLF, LF, or CR.
+func scanLines(data []byte, atEOF bool) (int, []byte, error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		if data[i] == '\r' {
+			if i+1 < len(data) {
+				if data[i+1] == '\n' {
+					return i + 2, data[:i], nil
+				}
+				return i + 1, data[:i], nil
+			}
+			if !atEOF {
+				// wait for the next byte to check whether it is LF
+				return 0, nil, nil
+			}
+		}
+		return i + 1, data[:i], nil
+	}
+	if atEOF {
+		// The incomplete line at the end of the stream is discarded.
+		return len(data), nil, nil
+	}
+	return 0, nil, nil
+}
+
+// decodeEventData decodes the data as JSON if it is a JSON object or array.
+func decodeEventData(data string) interface{} {
+	s := strings.TrimSpace(data)
+	if !strings.HasPrefix(s, "{") && !strings.HasPrefix(s, "[") {
+		return data
+	}
+	if !json.Valid([]byte(s)) {
+		return data
+	}
+	var v interface{}
+	if err := unmarshaler.Get("application/json").Unmarshal([]byte(s), &v); err != nil {
+		return data
+	}
+	return v
+}
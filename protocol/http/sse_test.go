@@ -0,0 +1,94 @@
+package http
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseEvents(t *testing.T) {
+	tests := map[string]struct {
+		stream string
+		expect []*Event
+	}{
+		"empty": {
+			stream: "",
+			expect: nil,
+		},
+		"LF": {
+			stream: "event: greeting\ndata: hello\n\n",
+			expect: []*Event{
+				{Event: "greeting", Data: "hello"},
+			},
+		},
+		"CRLF": {
+			stream: "data: hello\r\n\r\ndata: world\r\n\r\n",
+			expect: []*Event{
+				{Event: "message", Data: "hello"},
+				{Event: "message", Data: "world"},
+			},
+		},
+		"CR": {
+			stream: "data: hello\r\rdata: world\r\r",
+			expect: []*Event{
+				{Event: "message", Data: "hello"},
+				{Event: "message", Data: "world"},
+			},
+		},
+		"comments and unknown fields": {
+			stream: ": ping\n\nretry: 1000\nfoo: bar\ndata:hello\n\n",
+			expect: []*Event{
+				{Event: "message", Data: "hello"},
+			},
+		},
+		"multi-line data": {
+			stream: "data: {\ndata:   \"text\": \"hello\"\ndata: }\n\n",
+			expect: []*Event{
+				{Event: "message", Data: map[string]interface{}{"text": "hello"}},
+			},
+		},
+		"not JSON": {
+			stream: "data: {hello}\n\ndata: 1\n\n",
+			expect: []*Event{
+				{Event: "message", Data: "{hello}"},
+				{Event: "message", Data: "1"},
+			},
+		},
+		"event ID": {
+			stream: "id: 1\ndata: a\n\ndata: b\n\nid\ndata: c\n\n",
+			expect: []*Event{
+				{ID: "1", Event: "message", Data: "a"},
+				{ID: "1", Event: "message", Data: "b"},
+				{ID: "", Event: "message", Data: "c"},
+			},
+		},
+		"no data": {
+			stream: "event: ping\n\ndata\n\n",
+			expect: []*Event{
+				{Event: "message", Data: ""},
+			},
+		},
+		"incomplete event": {
+			stream: "data: hello\n\ndata: world\n",
+			expect: []*Event{
+				{Event: "message", Data: "hello"},
+			},
+		},
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			var got []*Event
+			if err := parseEvents(strings.NewReader(test.stream), func(ev receivedEvent) bool {
+				got = append(got, ev.event)
+				return true
+			}); err != nil {
+				t.Fatalf("failed to parse: %s", err)
+			}
+			if diff := cmp.Diff(test.expect, got); diff != "" {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package unmarshaler
+
+import (
+	"reflect"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+func init() {
+	if err := Register(&cborUnmarshaler{}); err != nil {
+		panic(err)
+	}
+}
+
+//nolint:exhaustruct
+var cborDecMode, _ = cbor.DecOptions{
+	DefaultMapType: reflect.TypeOf(map[string]interface{}{}),
+}.DecMode()
+
+type cborUnmarshaler struct{}
+
+// MediaType implements ResponseUnmarshaler interface.
+func (um *cborUnmarshaler) MediaType() string {
+	return "application/cbor"
+}
+
+// Unmarshal implements ResponseUnmarshaler interface.
+// The maps are decoded into map[string]interface{}, so the keys must be strings.
+func (um *cborUnmarshaler) Unmarshal(data []byte, v interface{}) error {
+	return cborDecMode.Unmarshal(data, v)
+}
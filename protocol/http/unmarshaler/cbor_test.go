@@ -0,0 +1,77 @@
+package unmarshaler
+
+import (
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCBOR_Unmarshal(t *testing.T) {
+	um := &cborUnmarshaler{}
+	t.Run("success", func(t *testing.T) {
+		tests := map[string]struct {
+			v      interface{}
+			expect interface{}
+		}{
+			"map": {
+				v: map[string]interface{}{
+					"id":    1,
+					"score": -1,
+					"rate":  0.5,
+					"data":  []byte{0x00, 0x01},
+					"tags":  []string{"a", "b"},
+					"child": map[string]interface{}{"ok": true},
+				},
+				expect: map[string]interface{}{
+					"id":    uint64(1),
+					"score": int64(-1),
+					"rate":  0.5,
+					"data":  []byte{0x00, 0x01},
+					"tags":  []interface{}{"a", "b"},
+					"child": map[string]interface{}{"ok": true},
+				},
+			},
+			"nil": {
+				v:      nil,
+				expect: nil,
+			},
+		}
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				b, err := cbor.Marshal(test.v)
+				if err != nil {
+					t.Fatalf("failed to encode: %s", err)
+				}
+				var v interface{}
+				if err := um.Unmarshal(b, &v); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if diff := cmp.Diff(test.expect, v); diff != "" {
+					t.Errorf("differs (-want +got):\n%s", diff)
+				}
+			})
+		}
+	})
+	t.Run("failure", func(t *testing.T) {
+		tests := map[string]struct {
+			v interface{}
+		}{
+			"not string key": {
+				v: map[int]string{1: "a"},
+			},
+		}
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				b, err := cbor.Marshal(test.v)
+				if err != nil {
+					t.Fatalf("failed to encode: %s", err)
+				}
+				var v interface{}
+				if err := um.Unmarshal(b, &v); err == nil {
+					t.Fatal("no error")
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,32 @@
+package unmarshaler
+
+import (
+	"bytes"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func init() {
+	for _, mediaType := range []string{"application/msgpack", "application/x-msgpack"} {
+		if err := Register(&msgpackUnmarshaler{mediaType: mediaType}); err != nil {
+			panic(err)
+		}
+	}
+}
+
+type msgpackUnmarshaler struct {
+	mediaType string
+}
+
+// MediaType implements ResponseUnmarshaler interface.
+func (um *msgpackUnmarshaler) MediaType() string {
+	return um.mediaType
+}
+
+// Unmarshal implements ResponseUnmarshaler interface.
+// The integers are decoded into int64 or uint64 regardless of their encoded sizes, and the binary data is decoded into string.
+func (um *msgpackUnmarshaler) Unmarshal(data []byte, v interface{}) error {
+	d := msgpack.NewDecoder(bytes.NewReader(data))
+	d.UseLooseInterfaceDecoding(true)
+	return d.Decode(v)
+}
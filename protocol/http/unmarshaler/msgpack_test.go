@@ -0,0 +1,58 @@
+package unmarshaler
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestMsgpack_Unmarshal(t *testing.T) {
+	um := &msgpackUnmarshaler{mediaType: "application/msgpack"}
+	t.Run("success", func(t *testing.T) {
+		tests := map[string]struct {
+			v      interface{}
+			expect interface{}
+		}{
+			"map": {
+				v: map[string]interface{}{
+					"id":    int8(1),
+					"score": -1,
+					"rate":  0.5,
+					"tags":  []string{"a", "b"},
+				},
+				expect: map[string]interface{}{
+					"id":    int64(1),
+					"score": int64(-1),
+					"rate":  0.5,
+					"tags":  []interface{}{"a", "b"},
+				},
+			},
+			"nil": {
+				v:      nil,
+				expect: nil,
+			},
+		}
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				b, err := msgpack.Marshal(test.v)
+				if err != nil {
+					t.Fatalf("failed to encode: %s", err)
+				}
+				var v interface{}
+				if err := um.Unmarshal(b, &v); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if diff := cmp.Diff(test.expect, v); diff != "" {
+					t.Errorf("differs (-want +got):\n%s", diff)
+				}
+			})
+		}
+	})
+	t.Run("failure", func(t *testing.T) {
+		var v interface{}
+		if err := um.Unmarshal([]byte{0xc1}, &v); err == nil {
+			t.Fatal("no error")
+		}
+	})
+}
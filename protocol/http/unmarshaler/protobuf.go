@@ -0,0 +1,200 @@
+package unmarshaler
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"mime"
+	"strconv"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+func init() {
+	for _, mediaType := range []string{"application/x-protobuf", "application/protobuf"} {
+		if err := Register(&protobufUnmarshaler{mediaType: mediaType}); err != nil {
+			panic(err)
+		}
+	}
+}
+
+type protobufUnmarshaler struct {
+	mediaType string
+}
+
+// MediaType implements ResponseUnmarshaler interface.
+func (um *protobufUnmarshaler) MediaType() string {
+	return um.mediaType
+}
+
+// Unmarshal implements ResponseUnmarshaler interface.
+// It always fails because the message type can't be resolved without the options.
+func (um *protobufUnmarshaler) Unmarshal(_ []byte, _ interface{}) error {
+	return errors.New("message type can't be resolved without proto files")
+}
+
+// UnmarshalWithContentType implements ContentTypeUnmarshaler interface.
+//
+// The message type is specified by the proto parameter of the Content-Type header such as "application/x-protobuf; proto=example.Message".
+// The message is decoded into maps with the field names in the proto files, including the unpopulated fields.
+func (um *protobufUnmarshaler) UnmarshalWithContentType(data []byte, v interface{}, opts *UnmarshalOptions) error {
+	_, params, err := mime.ParseMediaType(opts.ContentType)
+	if err != nil {
+		return fmt.Errorf("invalid Content-Type: %w", err)
+	}
+	name := params["proto"]
+	if name == "" {
+		name = opts.MessageType
+	}
+	if name == "" {
+		return errors.New(`message type must be specified by the "proto" parameter of the Content-Type header or the option`)
+	}
+	if opts.MessageResolver == nil {
+		return errors.New("message type can't be resolved without proto files")
+	}
+	md, err := opts.MessageResolver.ResolveMessage(protoreflect.FullName(name))
+	if err != nil {
+		return err
+	}
+
+	msg := dynamicpb.NewMessage(md)
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return fmt.Errorf("failed to decode as %s: %w", name, err)
+	}
+	val, err := messageValue(msg)
+	if err != nil {
+		return err
+	}
+	if p, ok := v.(*interface{}); ok {
+		*p = val
+		return nil
+	}
+	b, err := json.Marshal(val)
+	if err != nil {
+		return err
+	}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	return d.Decode(v)
+}
+
+// messageValue converts the message into the plain value which consists of maps, slices, and scalars.
+// Unlike the JSON mapping of protocol buffers, the unpopulated fields are also included and the 64-bit integers are kept as numbers
+// so that the message can be asserted in the same way as the gRPC protocol.
+// The well-known types are converted by the JSON mapping.
+func messageValue(msg protoreflect.Message) (interface{}, error) {
+	md := msg.Descriptor()
+	if md.FullName().Parent() == "google.protobuf" {
+		b, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg.Interface()) //nolint:exhaustruct
+		if err != nil {
+			return nil, err
+		}
+		d := json.NewDecoder(bytes.NewReader(b))
+		d.UseNumber()
+		var v interface{}
+		if err := d.Decode(&v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+	m := map[string]interface{}{}
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.ContainingOneof() != nil && !msg.Has(fd) {
+			continue
+		}
+		v, err := fieldValue(fd, msg)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", fd.Name(), err)
+		}
+		m[string(fd.Name())] = v
+	}
+	return m, nil
+}
+
+func fieldValue(fd protoreflect.FieldDescriptor, msg protoreflect.Message) (interface{}, error) {
+	switch {
+	case fd.IsList():
+		l := msg.Get(fd).List()
+		s := make([]interface{}, l.Len())
+		for i := 0; i < l.Len(); i++ {
+			v, err := singularValue(fd, l.Get(i))
+			if err != nil {
+				return nil, err
+			}
+			s[i] = v
+		}
+		return s, nil
+	case fd.IsMap():
+		mv := msg.Get(fd).Map()
+		m := make(map[string]interface{}, mv.Len())
+		var err error
+		mv.Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			var vv interface{}
+			vv, err = singularValue(fd.MapValue(), v)
+			if err != nil {
+				return false
+			}
+			m[k.String()] = vv
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+		return m, nil
+	case fd.Message() != nil && !msg.Has(fd):
+		return nil, nil
+	default:
+		return singularValue(fd, msg.Get(fd))
+	}
+}
+
+func singularValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) (interface{}, error) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return v.Bool(), nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return json.Number(strconv.FormatInt(v.Int(), 10)), nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return json.Number(strconv.FormatUint(v.Uint(), 10)), nil
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		f := v.Float()
+		switch {
+		case math.IsNaN(f):
+			return "NaN", nil
+		case math.IsInf(f, 1):
+			return "Infinity", nil
+		case math.IsInf(f, -1):
+			return "-Infinity", nil
+		}
+		bitSize := 64
+		if fd.Kind() == protoreflect.FloatKind {
+			bitSize = 32
+		}
+		return json.Number(strconv.FormatFloat(f, 'g', -1, bitSize)), nil
+	case protoreflect.StringKind:
+		return v.String(), nil
+	case protoreflect.BytesKind:
+		return base64.StdEncoding.EncodeToString(v.Bytes()), nil
+	case protoreflect.EnumKind:
+		if fd.Enum().FullName() == "google.protobuf.NullValue" {
+			return nil, nil
+		}
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name()), nil
+		}
+		return json.Number(strconv.FormatInt(int64(v.Enum()), 10)), nil
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return messageValue(v.Message())
+	default:
+		return nil, fmt.Errorf("unsupported kind %s", fd.Kind())
+	}
+}
@@ -0,0 +1,168 @@
+package unmarshaler
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	grpcproto "github.com/zoncoen/scenarigo/protocol/grpc/proto"
+)
+
+func TestProtobuf_UnmarshalWithContentType(t *testing.T) {
+	fds, err := grpcproto.NewCompiler(nil).Compile(context.Background(), []string{"../testdata/item.proto"})
+	if err != nil {
+		t.Fatalf("failed to compile: %s", err)
+	}
+	md, err := fds.ResolveMessage("scenarigo.testdata.http.Item")
+	if err != nil {
+		t.Fatalf("failed to resolve message: %s", err)
+	}
+	encode := func(t *testing.T, s string) []byte {
+		t.Helper()
+		msg := dynamicpb.NewMessage(md)
+		if err := protojson.Unmarshal([]byte(s), msg); err != nil {
+			t.Fatalf("failed to unmarshal: %s", err)
+		}
+		data, err := proto.Marshal(msg)
+		if err != nil {
+			t.Fatalf("failed to marshal: %s", err)
+		}
+		return data
+	}
+	data := encode(t, `{"id":"1","displayName":"foo","count":2,"tags":["a","b"]}`)
+
+	um := &protobufUnmarshaler{mediaType: "application/x-protobuf"}
+	t.Run("success", func(t *testing.T) {
+		tests := map[string]struct {
+			data   []byte
+			opts   *UnmarshalOptions
+			expect interface{}
+		}{
+			"proto parameter": {
+				data: data,
+				opts: &UnmarshalOptions{
+					ContentType:     "application/x-protobuf; proto=scenarigo.testdata.http.Item",
+					MessageResolver: fds,
+				},
+				expect: map[string]interface{}{
+					"id":           "1",
+					"display_name": "foo",
+					"count":        json.Number("2"),
+					"tags":         []interface{}{"a", "b"},
+					"total":        json.Number("0"),
+				},
+			},
+			"message type option": {
+				data: data,
+				opts: &UnmarshalOptions{
+					ContentType:     "application/x-protobuf",
+					MessageResolver: fds,
+					MessageType:     "scenarigo.testdata.http.Item",
+				},
+				expect: map[string]interface{}{
+					"id":           "1",
+					"display_name": "foo",
+					"count":        json.Number("2"),
+					"tags":         []interface{}{"a", "b"},
+					"total":        json.Number("0"),
+				},
+			},
+			"zero values": {
+				data: encode(t, `{"id":"1","count":0}`),
+				opts: &UnmarshalOptions{
+					ContentType:     "application/x-protobuf; proto=scenarigo.testdata.http.Item",
+					MessageResolver: fds,
+				},
+				expect: map[string]interface{}{
+					"id":           "1",
+					"display_name": "",
+					"count":        json.Number("0"),
+					"tags":         []interface{}{},
+					"total":        json.Number("0"),
+				},
+			},
+			"int64": {
+				data: encode(t, `{"id":"1","total":"9007199254740993"}`),
+				opts: &UnmarshalOptions{
+					ContentType:     "application/x-protobuf; proto=scenarigo.testdata.http.Item",
+					MessageResolver: fds,
+				},
+				expect: map[string]interface{}{
+					"id":           "1",
+					"display_name": "",
+					"count":        json.Number("0"),
+					"tags":         []interface{}{},
+					"total":        json.Number("9007199254740993"),
+				},
+			},
+		}
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				var v interface{}
+				if err := um.UnmarshalWithContentType(test.data, &v, test.opts); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if diff := cmp.Diff(test.expect, v); diff != "" {
+					t.Errorf("differs (-want +got):\n%s", diff)
+				}
+			})
+		}
+	})
+	t.Run("failure", func(t *testing.T) {
+		tests := map[string]struct {
+			data   []byte
+			opts   *UnmarshalOptions
+			expect string
+		}{
+			"no message type": {
+				data: data,
+				opts: &UnmarshalOptions{
+					ContentType:     "application/x-protobuf",
+					MessageResolver: fds,
+				},
+				expect: `message type must be specified by the "proto" parameter of the Content-Type header or the option`,
+			},
+			"no proto files": {
+				data: data,
+				opts: &UnmarshalOptions{
+					ContentType: "application/x-protobuf; proto=scenarigo.testdata.http.Item",
+				},
+				expect: "message type can't be resolved without proto files",
+			},
+			"message not found": {
+				data: data,
+				opts: &UnmarshalOptions{
+					ContentType:     "application/x-protobuf; proto=scenarigo.testdata.http.Unknown",
+					MessageResolver: fds,
+				},
+				expect: `message "scenarigo.testdata.http.Unknown" not found`,
+			},
+			"invalid data": {
+				data: []byte{0xff},
+				opts: &UnmarshalOptions{
+					ContentType:     "application/x-protobuf; proto=scenarigo.testdata.http.Item",
+					MessageResolver: fds,
+				},
+				expect: "failed to decode as scenarigo.testdata.http.Item",
+			},
+		}
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				var v interface{}
+				err := um.UnmarshalWithContentType(test.data, &v, test.opts)
+				if err == nil {
+					t.Fatal("no error")
+				}
+				if got := err.Error(); !strings.Contains(got, test.expect) {
+					t.Errorf("expect %q but got %q", test.expect, got)
+				}
+			})
+		}
+	})
+}
@@ -6,6 +6,8 @@ import (
 	"sync"
 
 	"github.com/pkg/errors"
+
+	grpcproto "github.com/zoncoen/scenarigo/protocol/grpc/proto"
 )
 
 // Default is the default response unmarshaler.
@@ -50,3 +52,19 @@ type ResponseUnmarshaler interface {
 	MediaType() string
 	Unmarshal(data []byte, v interface{}) error
 }
+
+// ContentTypeUnmarshaler is the interface that unmarshals the HTTP response body with the Content-Type header value.
+// ResponseUnmarshaler implements it if it requires the parameters of the header value or the options.
+type ContentTypeUnmarshaler interface {
+	UnmarshalWithContentType(data []byte, v interface{}, opts *UnmarshalOptions) error
+}
+
+// UnmarshalOptions represents the options to unmarshal the HTTP response body.
+type UnmarshalOptions struct {
+	// ContentType is the Content-Type header value of the response.
+	ContentType string
+	// MessageResolver resolves the protocol buffers message types.
+	MessageResolver grpcproto.MessageDescriptorResolver
+	// MessageType is the protocol buffers message type used if the Content-Type header value doesn't specify it.
+	MessageType string
+}
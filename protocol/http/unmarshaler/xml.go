@@ -0,0 +1,160 @@
+package unmarshaler
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+func init() {
+	for _, mediaType := range []string{"application/xml", "text/xml"} {
+		if err := Register(&xmlUnmarshaler{mediaType: mediaType}); err != nil {
+			panic(err)
+		}
+	}
+}
+
+type xmlUnmarshaler struct {
+	mediaType string
+}
+
+// MediaType implements ResponseUnmarshaler interface.
+func (um *xmlUnmarshaler) MediaType() string {
+	return um.mediaType
+}
+
+// Unmarshal implements ResponseUnmarshaler interface.
+//
+// The document is decoded into a map which has the root element.
+// An element which has neither attributes nor child elements is decoded into XMLText.
+// The others are decoded into XMLElement.
+func (um *xmlUnmarshaler) Unmarshal(data []byte, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr {
+		return errors.New("v must be a pointer")
+	}
+	if rv.IsNil() {
+		return errors.New("v is nil")
+	}
+	rv = rv.Elem()
+	if !rv.CanSet() {
+		return errors.New("v is not settable")
+	}
+
+	d := xml.NewDecoder(bytes.NewReader(data))
+	// the body has already been decoded according to the charset parameter of the Content-Type header
+	d.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return errors.New("no root element")
+			}
+			return err
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			elm, err := decodeXMLElement(d, start)
+			if err != nil {
+				return err
+			}
+			rv.Set(reflect.ValueOf(XMLElement{start.Name.Local: elm}))
+			return nil
+		}
+	}
+}
+
+func decodeXMLElement(d *xml.Decoder, start xml.StartElement) (interface{}, error) {
+	elm := XMLElement{}
+	for _, attr := range start.Attr {
+		if attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" {
+			continue
+		}
+		elm["@"+attr.Name.Local] = attr.Value
+	}
+	var text strings.Builder
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			child, err := decodeXMLElement(d, t)
+			if err != nil {
+				return nil, err
+			}
+			// a child is decoded into XMLText or XMLElement, so a list means repeated elements
+			name := t.Name.Local
+			switch v := elm[name].(type) {
+			case nil:
+				elm[name] = child
+			case []interface{}:
+				elm[name] = append(v, child)
+			default:
+				elm[name] = []interface{}{v, child}
+			}
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			if len(elm) == 0 {
+				return XMLText(text.String()), nil
+			}
+			if s := strings.TrimSpace(text.String()); s != "" {
+				elm["#text"] = s
+			}
+			return elm, nil
+		}
+	}
+}
+
+// XMLElement represents an XML element which has attributes or child elements.
+// The keys are the local names of the child elements, "@" followed by the attribute names, and "#text" for the text content.
+// The repeated child elements are decoded into a list.
+// A single child element can also be accessed by the index 0 as well as the repeated ones.
+type XMLElement map[string]interface{}
+
+// ExtractByIndex implements query.IndexExtractor interface.
+// It returns the element itself by the index 0 because the element may be a single one of the repeated elements.
+func (e XMLElement) ExtractByIndex(index int) (interface{}, bool) {
+	if index != 0 {
+		return nil, false
+	}
+	return e, true
+}
+
+// ExtractByKey implements query.KeyExtractor interface.
+// The keys are compared case-insensitively if no key matches exactly.
+func (e XMLElement) ExtractByKey(key string) (interface{}, bool) {
+	if v, ok := e[key]; ok {
+		return v, true
+	}
+	keys := make([]string, 0, len(e))
+	for k := range e {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if strings.EqualFold(k, key) {
+			return e[k], true
+		}
+	}
+	return nil, false
+}
+
+// XMLText represents the text content of an XML element which has neither attributes nor child elements.
+type XMLText string
+
+// ExtractByIndex implements query.IndexExtractor interface.
+// It returns the text itself by the index 0 because the element may be a single one of the repeated elements.
+func (t XMLText) ExtractByIndex(index int) (interface{}, bool) {
+	if index != 0 {
+		return nil, false
+	}
+	return t, true
+}
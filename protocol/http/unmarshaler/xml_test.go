@@ -0,0 +1,146 @@
+package unmarshaler
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/zoncoen/query-go"
+
+	"github.com/zoncoen/scenarigo/internal/queryutil"
+)
+
+func TestXML_Unmarshal(t *testing.T) {
+	um := &xmlUnmarshaler{mediaType: "application/xml"}
+	t.Run("success", func(t *testing.T) {
+		tests := map[string]struct {
+			data   string
+			expect interface{}
+		}{
+			"text": {
+				data:   `<message>hello</message>`,
+				expect: XMLElement{"message": XMLText("hello")},
+			},
+			"empty": {
+				data:   `<?xml version="1.0" encoding="UTF-8"?><message/>`,
+				expect: XMLElement{"message": XMLText("")},
+			},
+			"attributes and children": {
+				data: `<?xml version="1.0" encoding="Shift_JIS"?>
+<items count="2">
+  <item id="1">foo</item>
+  <item id="2"><name>bar</name></item>
+  <total>2</total>
+</items>`,
+				expect: XMLElement{
+					"items": XMLElement{
+						"@count": "2",
+						"item": []interface{}{
+							XMLElement{"@id": "1", "#text": "foo"},
+							XMLElement{"@id": "2", "name": XMLText("bar")},
+						},
+						"total": XMLText("2"),
+					},
+				},
+			},
+			"namespaces": {
+				data: `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/" xmlns="http://example.com/">
+  <soap:Body>
+    <GetResponse><Result>ok</Result></GetResponse>
+  </soap:Body>
+</soap:Envelope>`,
+				expect: XMLElement{
+					"Envelope": XMLElement{
+						"Body": XMLElement{
+							"GetResponse": XMLElement{
+								"Result": XMLText("ok"),
+							},
+						},
+					},
+				},
+			},
+		}
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				var v interface{}
+				if err := um.Unmarshal([]byte(test.data), &v); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if diff := cmp.Diff(test.expect, v); diff != "" {
+					t.Errorf("differs (-want +got):\n%s", diff)
+				}
+			})
+		}
+	})
+	t.Run("failure", func(t *testing.T) {
+		tests := map[string]struct {
+			data   string
+			expect string
+		}{
+			"no root element": {
+				data:   `<?xml version="1.0"?>`,
+				expect: "no root element",
+			},
+			"unclosed element": {
+				data:   `<message>hello`,
+				expect: "unexpected EOF",
+			},
+		}
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				var v interface{}
+				err := um.Unmarshal([]byte(test.data), &v)
+				if err == nil {
+					t.Fatal("no error")
+				}
+				if got := err.Error(); !strings.Contains(got, test.expect) {
+					t.Errorf("expect %q but got %q", test.expect, got)
+				}
+			})
+		}
+	})
+}
+
+func TestXMLElement_Extract(t *testing.T) {
+	var v interface{}
+	um := &xmlUnmarshaler{mediaType: "text/xml"}
+	if err := um.Unmarshal([]byte(`<Envelope><Body><Item>1</Item><Item>2</Item><Single><Name>foo</Name></Single></Body></Envelope>`), &v); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tests := map[string]struct {
+		query  string
+		expect interface{}
+	}{
+		"exact": {
+			query:  "Envelope.Body.Item[1]",
+			expect: XMLText("2"),
+		},
+		"case-insensitive": {
+			query:  "envelope.body.item[0]",
+			expect: XMLText("1"),
+		},
+		"single element": {
+			query:  "envelope.body.single[0].name",
+			expect: XMLText("foo"),
+		},
+		"single text": {
+			query:  "envelope.body.single.name[0]",
+			expect: XMLText("foo"),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			q, err := query.ParseString(test.query, queryutil.Options()...)
+			if err != nil {
+				t.Fatalf("failed to parse query: %s", err)
+			}
+			got, err := q.Extract(v)
+			if err != nil {
+				t.Fatalf("failed to extract: %s", err)
+			}
+			if diff := cmp.Diff(test.expect, got); diff != "" {
+				t.Errorf("differs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/zoncoen/scenarigo/assert"
 	"github.com/zoncoen/scenarigo/context"
 	"github.com/zoncoen/scenarigo/internal/queryutil"
+	"github.com/zoncoen/scenarigo/schema/jsonschema"
 )
 
 var (
@@ -34,6 +35,17 @@ func Unregister(name string) {
 	delete(registry, strings.ToLower(name))
 }
 
+// All returns all registered protocols.
+func All() map[string]Protocol {
+	m.Lock()
+	defer m.Unlock()
+	protocols := map[string]Protocol{}
+	for name, p := range registry {
+		protocols[name] = p
+	}
+	return protocols
+}
+
 // Get returns the protocol registered with the given name.
 func Get(name string) Protocol {
 	m.Lock()
@@ -67,3 +79,12 @@ type AssertionBuilder interface {
 type QueryOptionsProvider interface {
 	QueryOptions() []query.Option
 }
+
+// SchemaProvider is the interface that provides JSON Schemas of the protocol-specific fields.
+// The schemas are used to generate the JSON Schema of scenario and configuration files.
+// A method can return nil if the field accepts any value.
+type SchemaProvider interface {
+	OptionSchema() *jsonschema.Schema
+	RequestSchema() *jsonschema.Schema
+	ExpectSchema() *jsonschema.Schema
+}
@@ -0,0 +1,73 @@
+package websocket
+
+import (
+	"github.com/goccy/go-yaml"
+
+	"github.com/zoncoen/scenarigo/assert"
+	"github.com/zoncoen/scenarigo/context"
+	"github.com/zoncoen/scenarigo/errors"
+	"github.com/zoncoen/scenarigo/internal/assertutil"
+)
+
+// Expect represents expected response values.
+type Expect struct {
+	Header   yaml.MapSlice `yaml:"header,omitempty"`
+	Messages []interface{} `yaml:"messages,omitempty"`
+	Close    interface{}   `yaml:"close,omitempty"`
+}
+
+// Build implements protocol.AssertionBuilder interface.
+func (e *Expect) Build(ctx *context.Context) (assert.Assertion, error) {
+	headerAssertion, err := assertutil.BuildHeaderAssertion(ctx, e.Header)
+	if err != nil {
+		return nil, errors.WrapPathf(err, "header", "invalid expect header")
+	}
+
+	var msgsAssertion assert.Assertion
+	if e.Messages != nil {
+		msgsAssertion, err = assert.Build(ctx.RequestContext(), e.Messages, assert.FromTemplate(ctx))
+		if err != nil {
+			return nil, errors.WrapPathf(err, "messages", "invalid expect messages")
+		}
+	}
+
+	var closeAssertion assert.Assertion
+	if e.Close != nil {
+		closeAssertion, err = assert.Build(ctx.RequestContext(), e.Close, assert.FromTemplate(ctx))
+		if err != nil {
+			return nil, errors.WrapPathf(err, "close", "invalid expect close status")
+		}
+	}
+
+	return assert.AssertionFunc(func(v interface{}) error {
+		resp, ok := v.(*response)
+		if !ok {
+			return errors.Errorf("expected response but got %T", v)
+		}
+		if err := headerAssertion.Assert(resp.Header); err != nil {
+			return errors.WithPath(err, "header")
+		}
+		if err := e.assertMessages(msgsAssertion, resp.Messages); err != nil {
+			return errors.WithPath(err, "messages")
+		}
+		if closeAssertion != nil {
+			if resp.Close == nil {
+				return errors.ErrorPath("close", "connection was not closed by the server")
+			}
+			if err := closeAssertion.Assert(resp.Close); err != nil {
+				return errors.WithPath(err, "close")
+			}
+		}
+		return nil
+	}), nil
+}
+
+func (e *Expect) assertMessages(assertion assert.Assertion, msgs []*Message) error {
+	if assertion == nil {
+		return nil
+	}
+	if expect, got := len(e.Messages), len(msgs); expect != got {
+		return errors.Errorf("expected %d messages but got %d", expect, got)
+	}
+	return assertion.Assert(msgs)
+}
@@ -0,0 +1,111 @@
+package websocket
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/zoncoen/scenarigo/context"
+)
+
+func TestExpect_Build(t *testing.T) {
+	resp := &response{
+		Header: map[string][]string{
+			"Sec-Websocket-Protocol": {"echo"},
+		},
+		Messages: []*Message{
+			{Type: MessageTypeText, Data: "hello"},
+			{Type: MessageTypeText, Data: map[string]any{"id": 1}},
+		},
+		Close: &CloseStatus{Code: 1000},
+	}
+	tests := map[string]struct {
+		expect      *Expect
+		expectError string
+	}{
+		"default": {
+			expect: &Expect{},
+		},
+		"header": {
+			expect: &Expect{
+				Header: yaml.MapSlice{{Key: "Sec-Websocket-Protocol", Value: "echo"}},
+			},
+		},
+		"messages": {
+			expect: &Expect{
+				Messages: []any{
+					yaml.MapSlice{{Key: "data", Value: "hello"}},
+					yaml.MapSlice{{Key: "data", Value: yaml.MapSlice{{Key: "id", Value: 1}}}},
+				},
+			},
+		},
+		"close": {
+			expect: &Expect{
+				Close: yaml.MapSlice{{Key: "code", Value: 1000}},
+			},
+		},
+		"wrong header": {
+			expect: &Expect{
+				Header: yaml.MapSlice{{Key: "Sec-Websocket-Protocol", Value: "chat"}},
+			},
+			expectError: `.header.Sec-Websocket-Protocol`,
+		},
+		"wrong message": {
+			expect: &Expect{
+				Messages: []any{
+					yaml.MapSlice{{Key: "data", Value: "hello"}},
+					yaml.MapSlice{{Key: "data", Value: yaml.MapSlice{{Key: "id", Value: 2}}}},
+				},
+			},
+			expectError: `.messages[1].data.id: expected 2 but got 1`,
+		},
+		"wrong number of messages": {
+			expect: &Expect{
+				Messages: []any{
+					yaml.MapSlice{{Key: "data", Value: "hello"}},
+				},
+			},
+			expectError: `.messages: expected 1 messages but got 2`,
+		},
+		"wrong close code": {
+			expect: &Expect{
+				Close: yaml.MapSlice{{Key: "code", Value: 1001}},
+			},
+			expectError: `.close.code: expected 1001 but got 1000`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.FromT(t)
+			assertion, err := test.expect.Build(ctx)
+			if err != nil {
+				t.Fatalf("failed to build assertion: %s", err)
+			}
+			err = assertion.Assert(resp)
+			if test.expectError == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("no error")
+			}
+			if got := err.Error(); !strings.Contains(got, test.expectError) {
+				t.Errorf("%q doesn't contain %q", got, test.expectError)
+			}
+		})
+	}
+
+	t.Run("not closed", func(t *testing.T) {
+		e := &Expect{Close: yaml.MapSlice{{Key: "code", Value: 1000}}}
+		assertion, err := e.Build(context.FromT(t))
+		if err != nil {
+			t.Fatalf("failed to build assertion: %s", err)
+		}
+		if err := assertion.Assert(&response{}); err == nil {
+			t.Fatal("no error")
+		}
+	})
+}
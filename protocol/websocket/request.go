@@ -0,0 +1,257 @@
+package websocket
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/gorilla/websocket"
+
+	"github.com/zoncoen/scenarigo/context"
+	"github.com/zoncoen/scenarigo/errors"
+	"github.com/zoncoen/scenarigo/internal/streamutil"
+	"github.com/zoncoen/scenarigo/internal/wsutil"
+	"github.com/zoncoen/scenarigo/protocol/http/marshaler"
+	"github.com/zoncoen/scenarigo/protocol/http/unmarshaler"
+)
+
+const (
+	// MessageTypeText represents a text data message.
+	MessageTypeText = "text"
+	// MessageTypeBinary represents a binary data message.
+	MessageTypeBinary = "binary"
+)
+
+// Request represents a request.
+type Request struct {
+	URL          string         `yaml:"url,omitempty"`
+	Header       interface{}    `yaml:"header,omitempty"`
+	Subprotocols []string       `yaml:"subprotocols,omitempty"`
+	ContentType  string         `yaml:"contentType,omitempty"`
+	Messages     []*Message     `yaml:"messages,omitempty"`
+	Receive      *ReceiveOption `yaml:"receive,omitempty"`
+}
+
+// Message represents a WebSocket data message.
+type Message struct {
+	Type string      `yaml:"type,omitempty"`
+	Data interface{} `yaml:"data,omitempty"`
+}
+
+// ReceiveOption represents options for receiving messages.
+type ReceiveOption = streamutil.Option
+
+// CloseStatus represents a close frame sent by the server.
+type CloseStatus struct {
+	Code int    `yaml:"code"`
+	Text string `yaml:"text,omitempty"`
+}
+
+type response struct {
+	Status      string              `yaml:"status,omitempty"`
+	StatusCode  int                 `yaml:"statusCode,omitempty"`
+	Header      map[string][]string `yaml:"header,omitempty"`
+	Subprotocol string              `yaml:"subprotocol,omitempty"`
+	Messages    []*Message          `yaml:"messages,omitempty"`
+	Close       *CloseStatus        `yaml:"close,omitempty"`
+}
+
+const (
+	indentNum = 2
+)
+
+func (r *Request) addIndent(s string, indentNum int) string {
+	indent := strings.Repeat(" ", indentNum)
+	lines := []string{}
+	for _, line := range strings.Split(s, "\n") {
+		if line == "" {
+			lines = append(lines, line)
+		} else {
+			lines = append(lines, fmt.Sprintf("%s%s", indent, line))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Invoke implements protocol.Invoker interface.
+func (r *Request) Invoke(ctx *context.Context) (*context.Context, interface{}, error) {
+	urlStr, err := r.buildURL(ctx)
+	if err != nil {
+		return ctx, nil, err
+	}
+	header, err := wsutil.BuildHeader(ctx, r.Header, nil)
+	if err != nil {
+		return ctx, nil, err
+	}
+	msgs, frames, err := r.buildMessages(ctx)
+	if err != nil {
+		return ctx, nil, err
+	}
+	receiver, err := r.Receive.Build(ctx)
+	if err != nil {
+		return ctx, nil, errors.WithPath(err, "receive")
+	}
+
+	//nolint:exhaustruct
+	reqDump := &Request{
+		URL:          urlStr,
+		Header:       header,
+		Subprotocols: r.Subprotocols,
+		ContentType:  r.ContentType,
+		Messages:     msgs,
+	}
+	ctx = ctx.WithRequest(reqDump)
+	if b, err := yaml.Marshal(reqDump); err == nil {
+		ctx.Reporter().Logf("request:\n%s", r.addIndent(string(b), indentNum))
+	} else {
+		ctx.Reporter().Logf("failed to dump request:\n%s", err)
+	}
+
+	conn, resp, err := wsutil.Dial(ctx, urlStr, &wsutil.DialOptions{ //nolint:exhaustruct
+		Header:       header,
+		Subprotocols: r.Subprotocols,
+	})
+	if err != nil {
+		return ctx, nil, err
+	}
+	defer conn.Close()
+
+	rvalue := &response{
+		Status:      resp.Status,
+		StatusCode:  resp.StatusCode,
+		Header:      resp.Header,
+		Subprotocol: conn.Subprotocol(),
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	received, readErr := wsutil.Read(conn, done)
+
+	for i, f := range frames {
+		if err := conn.WriteMessage(f.Type, f.Data); err != nil {
+			return ctx, nil, errors.ErrorPathf(fmt.Sprintf("messages[%d]", i), "failed to send message: %s", err)
+		}
+	}
+
+	err = streamutil.Receive(ctx, receiver, received, readErr,
+		func(f wsutil.Frame) (interface{}, bool, error) {
+			msg, err := r.decodeMessage(f)
+			if err != nil {
+				return nil, false, err
+			}
+			rvalue.Messages = append(rvalue.Messages, msg)
+			return msg, false, nil
+		},
+		func(err error) error {
+			var closeErr *websocket.CloseError
+			if !errors.As(err, &closeErr) {
+				return errors.Errorf("failed to receive message: %s", err)
+			}
+			rvalue.Close = &CloseStatus{
+				Code: closeErr.Code,
+				Text: closeErr.Text,
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return ctx, nil, err
+	}
+
+	if rvalue.Close == nil {
+		wsutil.Close(conn)
+	}
+
+	ctx = ctx.WithResponse(rvalue)
+	if b, err := yaml.Marshal(rvalue); err == nil {
+		ctx.Reporter().Logf("response:\n%s", r.addIndent(string(b), indentNum))
+	} else {
+		ctx.Reporter().Logf("failed to dump response:\n%s", err)
+	}
+	return ctx, rvalue, nil
+}
+
+func (r *Request) buildURL(ctx *context.Context) (string, error) {
+	x, err := ctx.ExecuteTemplate(r.URL)
+	if err != nil {
+		return "", errors.WrapPathf(err, "url", "failed to get URL")
+	}
+	urlStr, ok := x.(string)
+	if !ok {
+		return "", errors.ErrorPathf("url", `URL must be "string" but got "%T"`, x)
+	}
+	return urlStr, nil
+}
+
+func (r *Request) buildMessages(ctx *context.Context) ([]*Message, []wsutil.Frame, error) {
+	msgs := make([]*Message, 0, len(r.Messages))
+	frames := make([]wsutil.Frame, 0, len(r.Messages))
+	for i, m := range r.Messages {
+		if m == nil {
+			return nil, nil, errors.ErrorPathf(fmt.Sprintf("messages[%d]", i), "message must not be null")
+		}
+		typ := websocket.TextMessage
+		switch m.Type {
+		case "", MessageTypeText:
+		case MessageTypeBinary:
+			typ = websocket.BinaryMessage
+		default:
+			return nil, nil, errors.ErrorPathf(fmt.Sprintf("messages[%d].type", i), "unknown message type %q", m.Type)
+		}
+		data, err := ctx.ExecuteTemplate(m.Data)
+		if err != nil {
+			return nil, nil, errors.WrapPathf(err, fmt.Sprintf("messages[%d].data", i), "failed to create message")
+		}
+		b, err := r.encodeData(data)
+		if err != nil {
+			return nil, nil, errors.WithPath(err, fmt.Sprintf("messages[%d].data", i))
+		}
+		msgs = append(msgs, &Message{
+			Type: m.Type,
+			Data: data,
+		})
+		frames = append(frames, wsutil.Frame{Type: typ, Data: b})
+	}
+	return msgs, frames, nil
+}
+
+func (r *Request) encodeData(data interface{}) ([]byte, error) {
+	if r.ContentType == "" {
+		switch v := data.(type) {
+		case string:
+			return []byte(v), nil
+		case []byte:
+			return v, nil
+		}
+	}
+	m := marshaler.Get(r.ContentType)
+	b, err := m.Marshal(data)
+	if err != nil {
+		return nil, errors.Errorf("failed to marshal message as %s: %#v: %s", m.MediaType(), data, err)
+	}
+	return b, nil
+}
+
+func (r *Request) decodeMessage(f wsutil.Frame) (*Message, error) {
+	msg := &Message{
+		Type: MessageTypeText,
+	}
+	if f.Type == websocket.BinaryMessage {
+		msg.Type = MessageTypeBinary
+	}
+	if r.ContentType == "" {
+		if f.Type == websocket.BinaryMessage {
+			msg.Data = f.Data
+		} else {
+			msg.Data = string(f.Data)
+		}
+		return msg, nil
+	}
+	u := unmarshaler.Get(r.ContentType)
+	var data interface{}
+	if err := u.Unmarshal(f.Data, &data); err != nil {
+		return nil, errors.Errorf("failed to unmarshal message as %s: %s: %s", u.MediaType(), string(f.Data), err)
+	}
+	msg.Data = data
+	return msg, nil
+}
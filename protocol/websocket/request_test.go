@@ -0,0 +1,261 @@
+package websocket
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/websocket"
+
+	"github.com/zoncoen/scenarigo/context"
+)
+
+func startTestServer(t *testing.T) string {
+	t.Helper()
+	upgrader := websocket.Upgrader{
+		Subprotocols: []string{"echo"},
+	}
+	m := http.NewServeMux()
+	m.HandleFunc("/echo", func(w http.ResponseWriter, req *http.Request) {
+		conn, err := upgrader.Upgrade(w, req, http.Header{"X-Token": {req.Header.Get("Token")}})
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			typ, b, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if err := conn.WriteMessage(typ, b); err != nil {
+				return
+			}
+		}
+	})
+	m.HandleFunc("/stream", func(w http.ResponseWriter, req *http.Request) {
+		conn, err := upgrader.Upgrade(w, req, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for _, s := range []string{`{"id":1}`, `{"id":2}`, `{"id":3}`} {
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(s)); err != nil {
+				return
+			}
+		}
+		_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, "bye"))
+	})
+	m.HandleFunc("/invalid", func(w http.ResponseWriter, req *http.Request) {
+		conn, err := upgrader.Upgrade(w, req, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_ = conn.WriteMessage(websocket.TextMessage, []byte("{"))
+	})
+	srv := httptest.NewServer(m)
+	t.Cleanup(srv.Close)
+	return "ws" + strings.TrimPrefix(srv.URL, "http")
+}
+
+func TestRequest_Invoke(t *testing.T) {
+	url := startTestServer(t)
+	tests := map[string]struct {
+		vars   any
+		req    *Request
+		expect *response
+	}{
+		"text messages": {
+			vars: map[string]any{"name": "bob"},
+			req: &Request{
+				URL:    url + "/echo",
+				Header: map[string]string{"Token": "secret"},
+				Messages: []*Message{
+					{Data: "hello"},
+					{Data: "{{vars.name}}"},
+				},
+				Receive: &ReceiveOption{Count: 2},
+			},
+			expect: &response{
+				Status:     "101 Switching Protocols",
+				StatusCode: http.StatusSwitchingProtocols,
+				Messages: []*Message{
+					{Type: MessageTypeText, Data: "hello"},
+					{Type: MessageTypeText, Data: "bob"},
+				},
+			},
+		},
+		"binary message": {
+			req: &Request{
+				URL: url + "/echo",
+				Messages: []*Message{
+					{Type: MessageTypeBinary, Data: "hello"},
+				},
+				Receive: &ReceiveOption{Count: 1},
+			},
+			expect: &response{
+				Status:     "101 Switching Protocols",
+				StatusCode: http.StatusSwitchingProtocols,
+				Messages: []*Message{
+					{Type: MessageTypeBinary, Data: []byte("hello")},
+				},
+			},
+		},
+		"json messages": {
+			req: &Request{
+				URL:         url + "/echo",
+				ContentType: "application/json",
+				Messages: []*Message{
+					{Data: yaml.MapSlice{{Key: "id", Value: 1}}},
+				},
+				Receive: &ReceiveOption{Count: 1},
+			},
+			expect: &response{
+				Status:     "101 Switching Protocols",
+				StatusCode: http.StatusSwitchingProtocols,
+				Messages: []*Message{
+					{Type: MessageTypeText, Data: map[string]any{"id": json.Number("1")}},
+				},
+			},
+		},
+		"subprotocol": {
+			req: &Request{
+				URL:          url + "/echo",
+				Subprotocols: []string{"echo"},
+				Receive:      &ReceiveOption{Timeout: "10ms"},
+			},
+			expect: &response{
+				Status:      "101 Switching Protocols",
+				StatusCode:  http.StatusSwitchingProtocols,
+				Subprotocol: "echo",
+			},
+		},
+		"receive until": {
+			req: &Request{
+				URL:         url + "/stream",
+				ContentType: "application/json",
+				Receive: &ReceiveOption{
+					Until: yaml.MapSlice{
+						{Key: "data", Value: yaml.MapSlice{{Key: "id", Value: 2}}},
+					},
+				},
+			},
+			expect: &response{
+				Status:     "101 Switching Protocols",
+				StatusCode: http.StatusSwitchingProtocols,
+				Messages: []*Message{
+					{Type: MessageTypeText, Data: map[string]any{"id": json.Number("1")}},
+					{Type: MessageTypeText, Data: map[string]any{"id": json.Number("2")}},
+				},
+			},
+		},
+		"closed by server": {
+			req: &Request{
+				URL: url + "/stream",
+			},
+			expect: &response{
+				Status:     "101 Switching Protocols",
+				StatusCode: http.StatusSwitchingProtocols,
+				Messages: []*Message{
+					{Type: MessageTypeText, Data: `{"id":1}`},
+					{Type: MessageTypeText, Data: `{"id":2}`},
+					{Type: MessageTypeText, Data: `{"id":3}`},
+				},
+				Close: &CloseStatus{
+					Code: websocket.CloseGoingAway,
+					Text: "bye",
+				},
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.FromT(t)
+			if test.vars != nil {
+				ctx = ctx.WithVars(test.vars)
+			}
+			_, result, err := test.req.Invoke(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			resp, ok := result.(*response)
+			if !ok {
+				t.Fatalf("expected *response but got %T", result)
+			}
+			if h, ok := test.req.Header.(map[string]string); ok {
+				if got, expect := http.Header(resp.Header).Get("X-Token"), h["Token"]; got != expect {
+					t.Errorf("expected header %q but got %q", expect, got)
+				}
+			}
+			resp.Header = nil
+			if diff := cmp.Diff(test.expect, resp); diff != "" {
+				t.Errorf("differs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRequest_Invoke_Error(t *testing.T) {
+	url := startTestServer(t)
+	tests := map[string]struct {
+		req    *Request
+		expect string
+	}{
+		"failed to execute template": {
+			req: &Request{
+				URL: "{{vars.url}}",
+			},
+			expect: `.url: failed to get URL: failed to execute: {{vars.url}}: ".vars.url" not found`,
+		},
+		"failed to connect": {
+			req: &Request{
+				URL: strings.Replace(url, "ws", "http", 1) + "/echo",
+			},
+			expect: `failed to connect: malformed ws or wss URL`,
+		},
+		"bad handshake": {
+			req: &Request{
+				URL: url + "/unknown",
+			},
+			expect: `failed to connect: websocket: bad handshake: 404 Not Found`,
+		},
+		"unknown message type": {
+			req: &Request{
+				URL: url + "/echo",
+				Messages: []*Message{
+					{Type: "ping"},
+				},
+			},
+			expect: `.messages[0].type: unknown message type "ping"`,
+		},
+		"invalid timeout": {
+			req: &Request{
+				URL:     url + "/echo",
+				Receive: &ReceiveOption{Timeout: "1"},
+			},
+			expect: `.receive.timeout: invalid timeout`,
+		},
+		"failed to unmarshal": {
+			req: &Request{
+				URL:         url + "/invalid",
+				ContentType: "application/json",
+			},
+			expect: `failed to unmarshal message as application/json: {`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := test.req.Invoke(context.FromT(t))
+			if err == nil {
+				t.Fatal("no error")
+			}
+			if got := err.Error(); !strings.Contains(got, test.expect) {
+				t.Errorf("%q doesn't contain %q", got, test.expect)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+// Package websocket provides the WebSocket protocol for scenarigo steps.
+package websocket
+
+import (
+	"bytes"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/zoncoen/scenarigo/protocol"
+	"github.com/zoncoen/scenarigo/schema/jsonschema"
+)
+
+// Register registers websocket protocol.
+func Register() {
+	protocol.Register(&WebSocket{})
+}
+
+// WebSocket is a protocol type for the scenarigo step.
+type WebSocket struct{}
+
+// Name implements protocol.Protocol interface.
+func (p *WebSocket) Name() string {
+	return "websocket"
+}
+
+// UnmarshalOption implements protocol.Protocol interface.
+func (p *WebSocket) UnmarshalOption(_ []byte) error {
+	return nil
+}
+
+// UnmarshalRequest implements protocol.Protocol interface.
+func (p *WebSocket) UnmarshalRequest(b []byte) (protocol.Invoker, error) {
+	var r Request
+	if err := yaml.UnmarshalWithOptions(b, &r, yaml.Strict()); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// UnmarshalExpect implements protocol.Protocol interface.
+func (p *WebSocket) UnmarshalExpect(b []byte) (protocol.AssertionBuilder, error) {
+	var e Expect
+	if b == nil {
+		return &e, nil
+	}
+	decoder := yaml.NewDecoder(bytes.NewBuffer(b), yaml.UseOrderedMap(), yaml.Strict())
+	if err := decoder.Decode(&e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// OptionSchema implements protocol.SchemaProvider interface.
+func (p *WebSocket) OptionSchema() *jsonschema.Schema {
+	return nil
+}
+
+// RequestSchema implements protocol.SchemaProvider interface.
+func (p *WebSocket) RequestSchema() *jsonschema.Schema {
+	s := jsonschema.Reflect(Request{})
+	if msg := s.Property("messages").Items; msg != nil {
+		msg.SetProperty("type", &jsonschema.Schema{
+			Type: jsonschema.Types{jsonschema.TypeString},
+			Enum: []any{MessageTypeText, MessageTypeBinary},
+		})
+	}
+	return s
+}
+
+// ExpectSchema implements protocol.SchemaProvider interface.
+func (p *WebSocket) ExpectSchema() *jsonschema.Schema {
+	return jsonschema.Reflect(Expect{})
+}
@@ -73,6 +73,9 @@ type testContext struct {
 	enabledTestSummary bool
 	testSummary        *testSummary
 
+	// events writes the test events if it is not nil
+	events *eventWriter
+
 	// for FromT
 	matcher *matcher
 }
@@ -0,0 +1,111 @@
+package reporter
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// EventAction represents the kind of a test event.
+type EventAction string
+
+// Actions of test events.
+const (
+	EventActionRun   EventAction = "run"   // the test has started
+	EventActionPause EventAction = "pause" // the test has been paused to run in parallel
+	EventActionCont  EventAction = "cont"  // the test has continued running
+	EventActionLog   EventAction = "log"   // the test has logged a message
+	EventActionError EventAction = "error" // the test has logged an error message
+	EventActionRetry EventAction = "retry" // the test has failed and will be retried
+	EventActionPass  EventAction = "pass"  // the test has passed
+	EventActionFail  EventAction = "fail"  // the test has failed
+	EventActionSkip  EventAction = "skip"  // the test has been skipped
+)
+
+// Event represents a test event.
+// The events are written in JSON Lines format as they happen.
+type Event struct {
+	Time   time.Time   `json:"time"`
+	Action EventAction `json:"action"`
+	// Test is the full name of the test such as "file.yaml/scenario/step".
+	// It is empty for the events of the root test.
+	Test string `json:"test,omitempty"`
+	// Name is the name of the test.
+	Name string `json:"name,omitempty"`
+	// Depth is the nesting depth of the test.
+	// The test files are 1, the scenarios are 2, and the steps are 3.
+	Depth int `json:"depth,omitempty"`
+	// Attempt is the number of the retry attempt starting from 1.
+	// It is set only if the test has a retry policy.
+	Attempt int `json:"attempt,omitempty"`
+	// Elapsed is the duration of the test in seconds.
+	// It is set for the pass, fail, and skip events.
+	Elapsed *float64 `json:"elapsed,omitempty"`
+	// Output is the log message.
+	Output string `json:"output,omitempty"`
+}
+
+// WithEventWriter returns an option to write the test events to w in JSON Lines format.
+func WithEventWriter(w io.Writer) Option {
+	return func(ctx *testContext) {
+		ctx.events = &eventWriter{
+			enc: json.NewEncoder(w),
+			now: time.Now,
+		}
+	}
+}
+
+type eventWriter struct {
+	m   sync.Mutex
+	enc *json.Encoder
+	now func() time.Time
+}
+
+func (w *eventWriter) write(e *Event) {
+	w.m.Lock()
+	defer w.m.Unlock()
+	e.Time = w.now()
+	// the event stream is an auxiliary output, so the errors are ignored not to break the tests
+	_ = w.enc.Encode(e)
+}
+
+func (r *reporter) emit(action EventAction, output string) {
+	r.emitAttempt(action, output, r.attempt)
+}
+
+func (r *reporter) emitAttempt(action EventAction, output string, attempt int) {
+	if r.context == nil || r.context.events == nil {
+		return
+	}
+	e := &Event{
+		Action:  action,
+		Test:    r.goTestName,
+		Name:    r.name,
+		Depth:   r.depth,
+		Attempt: attempt,
+		Output:  output,
+	}
+	switch action {
+	case EventActionPass, EventActionFail, EventActionSkip:
+		elapsed := r.durationMeasurer.getDuration().Seconds()
+		e.Elapsed = &elapsed
+	default:
+	}
+	r.context.events.write(e)
+}
+
+func (r *reporter) emitResult() {
+	// The results of retry attempts are reported by the retry events and the result of the parent.
+	if r.retryable {
+		return
+	}
+	switch {
+	case r.Failed():
+		r.emit(EventActionFail, "")
+	case r.Skipped():
+		r.emit(EventActionSkip, "")
+	default:
+		r.emit(EventActionPass, "")
+	}
+}
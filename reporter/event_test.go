@@ -0,0 +1,106 @@
+package reporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestWithEventWriter(t *testing.T) {
+	retryPolicy := &constantRetryPolicy{
+		interval:   time.Microsecond,
+		maxRetries: 1,
+	}
+	tests := map[string]struct {
+		f      func(Reporter)
+		expect []Event
+	}{
+		"nest": {
+			f: func(r Reporter) {
+				r.Run("a", func(r Reporter) {
+					r.Run("b", func(r Reporter) {
+						r.Log("log")
+					})
+					r.Run("c", func(r Reporter) {
+						r.Error("error")
+					})
+					r.Run("d", func(r Reporter) {
+						r.Skip("skip")
+					})
+				})
+			},
+			expect: []Event{
+				{Action: EventActionRun},
+				{Action: EventActionRun, Test: "a", Name: "a", Depth: 1},
+				{Action: EventActionRun, Test: "a/b", Name: "b", Depth: 2},
+				{Action: EventActionLog, Test: "a/b", Name: "b", Depth: 2, Output: "log"},
+				{Action: EventActionPass, Test: "a/b", Name: "b", Depth: 2},
+				{Action: EventActionRun, Test: "a/c", Name: "c", Depth: 2},
+				{Action: EventActionError, Test: "a/c", Name: "c", Depth: 2, Output: "error"},
+				{Action: EventActionFail, Test: "a/c", Name: "c", Depth: 2},
+				{Action: EventActionRun, Test: "a/d", Name: "d", Depth: 2},
+				{Action: EventActionLog, Test: "a/d", Name: "d", Depth: 2, Output: "skip"},
+				{Action: EventActionSkip, Test: "a/d", Name: "d", Depth: 2},
+				{Action: EventActionFail, Test: "a", Name: "a", Depth: 1},
+				{Action: EventActionFail},
+			},
+		},
+		"retry": {
+			f: func(r Reporter) {
+				var i int
+				RunWithRetry(context.Background(), r, "a", func(r Reporter) {
+					i++
+					if i < 2 {
+						r.Error("error")
+					}
+				}, retryPolicy)
+			},
+			expect: []Event{
+				{Action: EventActionRun},
+				{Action: EventActionRun, Test: "a", Name: "a", Depth: 1},
+				{Action: EventActionError, Test: "a", Name: "a", Depth: 1, Attempt: 1, Output: "error"},
+				{Action: EventActionRetry, Test: "a", Name: "a", Depth: 1, Attempt: 1, Output: "retry after 1µs"},
+				{Action: EventActionLog, Test: "a", Name: "a", Depth: 1, Output: "retry after 1µs"},
+				{Action: EventActionPass, Test: "a", Name: "a", Depth: 1},
+				{Action: EventActionPass},
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var b bytes.Buffer
+			Run(test.f, WithWriter(io.Discard), WithEventWriter(&b))
+			var got []Event
+			dec := json.NewDecoder(&b)
+			for {
+				var e Event
+				if err := dec.Decode(&e); err != nil {
+					if err == io.EOF {
+						break
+					}
+					t.Fatalf("failed to decode: %s", err)
+				}
+				if e.Time.IsZero() {
+					t.Errorf("time is not set: %+v", e)
+				}
+				switch e.Action {
+				case EventActionPass, EventActionFail, EventActionSkip:
+					if e.Elapsed == nil {
+						t.Errorf("elapsed is not set: %+v", e)
+					}
+				default:
+				}
+				got = append(got, e)
+			}
+			if diff := cmp.Diff(test.expect, got, cmpopts.IgnoreFields(Event{}, "Time", "Elapsed")); diff != "" {
+				t.Errorf("events mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
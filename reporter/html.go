@@ -0,0 +1,82 @@
+package reporter
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+//go:embed templates/report.html.tmpl
+var htmlTemplateText string
+
+var (
+	htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+		"duration":  formatDuration,
+		"join":      func(logs []string) string { return stripANSI(strings.Join(logs, "\n")) },
+		"stripANSI": stripANSI,
+	}).Parse(htmlTemplateText))
+
+	ansiPattern = regexp.MustCompile("\x1b\\[[0-9;]*m")
+)
+
+// WriteHTML writes the report as a self-contained HTML document to w.
+// The logs are written as recorded, so the secrets are masked in the same way as the terminal output.
+func (r *TestReport) WriteHTML(w io.Writer) error {
+	return htmlTemplate.Execute(w, &htmlReport{
+		TestReport: r,
+		Summary:    r.htmlSummary(),
+	})
+}
+
+type htmlReport struct {
+	*TestReport
+	Summary []htmlSummaryRow
+}
+
+type htmlSummaryRow struct {
+	Kind    string
+	Total   int
+	Passed  int
+	Failed  int
+	Skipped int
+}
+
+func (row *htmlSummaryRow) count(result TestResult) {
+	row.Total++
+	switch result {
+	case TestResultPassed:
+		row.Passed++
+	case TestResultFailed:
+		row.Failed++
+	case TestResultSkipped:
+		row.Skipped++
+	default:
+	}
+}
+
+func (r *TestReport) htmlSummary() []htmlSummaryRow {
+	files := htmlSummaryRow{Kind: "files"}
+	scenarios := htmlSummaryRow{Kind: "scenarios"}
+	steps := htmlSummaryRow{Kind: "steps"}
+	for _, f := range r.Files {
+		files.count(f.Result)
+		for _, scn := range f.Scenarios {
+			scenarios.count(scn.Result)
+			for _, step := range scn.Steps {
+				steps.count(step.Result)
+			}
+		}
+	}
+	return []htmlSummaryRow{files, scenarios, steps}
+}
+
+func formatDuration(d TestDuration) string {
+	return time.Duration(d).Round(time.Millisecond).String()
+}
+
+func stripANSI(s string) string {
+	return ansiPattern.ReplaceAllString(s, "")
+}
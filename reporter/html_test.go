@@ -0,0 +1,91 @@
+package reporter
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTestReport_WriteHTML(t *testing.T) {
+	skipMsg := "skip"
+	report := &TestReport{
+		Result: TestResultFailed,
+		Files: []ScenarioFileReport{
+			{
+				Name:     "file1.yaml",
+				Result:   TestResultFailed,
+				Duration: TestDuration(123 * time.Millisecond),
+				Scenarios: []ScenarioReport{
+					{
+						Name:     "passed scenario",
+						File:     "file1.yaml",
+						Result:   TestResultPassed,
+						Duration: TestDuration(100 * time.Millisecond),
+						Steps: []StepReport{
+							{
+								Name:     "passed step",
+								Result:   TestResultPassed,
+								Duration: TestDuration(100 * time.Millisecond),
+								Logs: ReportLogs{
+									Info: []string{
+										"request:\n  header:\n    Authorization:\n    - {{secrets.token}}",
+									},
+								},
+								SubSteps: []SubStepReport{
+									{
+										Name:     "included step",
+										Result:   TestResultPassed,
+										Duration: TestDuration(50 * time.Millisecond),
+									},
+								},
+							},
+						},
+					},
+					{
+						Name:     "failed scenario",
+						File:     "file1.yaml",
+						Result:   TestResultFailed,
+						Duration: TestDuration(23 * time.Millisecond),
+						Steps: []StepReport{
+							{
+								Name:     "failed step",
+								Result:   TestResultFailed,
+								Duration: TestDuration(3 * time.Millisecond),
+								Logs: ReportLogs{
+									Info: []string{
+										"info",
+									},
+									Error: []string{
+										"expected \x1b[32m\"<b>\"\x1b[0m but got \x1b[31m\"<i>\"\x1b[0m",
+									},
+								},
+							},
+							{
+								Name:   "skipped step",
+								Result: TestResultSkipped,
+								Logs: ReportLogs{
+									Skip: &skipMsg,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	var b bytes.Buffer
+	if err := report.WriteHTML(&b); err != nil {
+		t.Fatalf("failed to write HTML: %s", err)
+	}
+	expected, err := os.ReadFile("testdata/report.html")
+	if err != nil {
+		t.Fatalf("failed to read: %s", err)
+	}
+	if diff := cmp.Diff(string(expected), b.String()); diff != "" {
+		t.Errorf("result mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -31,7 +31,7 @@ func (r *logRecorder) setReplacer(rep LogReplacer) {
 	}
 }
 
-func (r *logRecorder) log(s string) {
+func (r *logRecorder) log(s string) string {
 	if r.replacer != nil {
 		s = r.replacer.ReplaceAll(s)
 	}
@@ -39,9 +39,10 @@ func (r *logRecorder) log(s string) {
 	defer r.m.Unlock()
 	r.strs = append(r.strs, s)
 	r.infoIdxs = append(r.infoIdxs, len(r.strs)-1)
+	return s
 }
 
-func (r *logRecorder) error(s string) {
+func (r *logRecorder) error(s string) string {
 	if r.replacer != nil {
 		s = r.replacer.ReplaceAll(s)
 	}
@@ -49,9 +50,10 @@ func (r *logRecorder) error(s string) {
 	defer r.m.Unlock()
 	r.strs = append(r.strs, s)
 	r.errorIdxs = append(r.errorIdxs, len(r.strs)-1)
+	return s
 }
 
-func (r *logRecorder) skip(s string) {
+func (r *logRecorder) skip(s string) string {
 	if r.replacer != nil {
 		s = r.replacer.ReplaceAll(s)
 	}
@@ -60,6 +62,7 @@ func (r *logRecorder) skip(s string) {
 	r.strs = append(r.strs, s)
 	i := len(r.strs) - 1
 	r.skipIdx = &i
+	return s
 }
 
 func (r *logRecorder) all() []string {
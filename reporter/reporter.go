@@ -78,6 +78,7 @@ func Run(f func(r Reporter), opts ...Option) bool {
 func run(f func(r Reporter), opts ...Option) *reporter {
 	r := newReporter()
 	r.context = newTestContext(opts...)
+	r.emit(EventActionRun, "")
 	go r.run(f)
 	<-r.done
 	return r
@@ -115,6 +116,7 @@ type reporter struct {
 	testing              bool
 	retryPolicy          RetryPolicy
 	retryable            bool
+	attempt              int // the number of the retry attempt
 	noFailurePropagation bool
 }
 
@@ -157,26 +159,26 @@ func (r *reporter) FailNow() {
 // and records the text in the log.
 // The text will be printed only if the test fails or the --verbose flag is set.
 func (r *reporter) Log(args ...interface{}) {
-	r.logs.log(fmt.Sprint(args...))
+	r.emit(EventActionLog, r.logs.log(fmt.Sprint(args...)))
 }
 
 // Logf formats its arguments according to the format, analogous to fmt.Printf, and
 // records the text in the log.
 // The text will be printed only if the test fails or the --verbose flag is set.
 func (r *reporter) Logf(format string, args ...interface{}) {
-	r.logs.log(fmt.Sprintf(format, args...))
+	r.emit(EventActionLog, r.logs.log(fmt.Sprintf(format, args...)))
 }
 
 // Error is equivalent to Log followed by Fail.
 func (r *reporter) Error(args ...interface{}) {
 	r.Fail()
-	r.logs.error(fmt.Sprint(args...))
+	r.emit(EventActionError, r.logs.error(fmt.Sprint(args...)))
 }
 
 // Errorf is equivalent to Logf followed by Fail.
 func (r *reporter) Errorf(format string, args ...interface{}) {
 	r.Fail()
-	r.logs.error(fmt.Sprintf(format, args...))
+	r.emit(EventActionError, r.logs.error(fmt.Sprintf(format, args...)))
 }
 
 // Fatal is equivalent to Log followed by FailNow.
@@ -193,13 +195,13 @@ func (r *reporter) Fatalf(format string, args ...interface{}) {
 
 // Skip is equivalent to Log followed by SkipNow.
 func (r *reporter) Skip(args ...interface{}) {
-	r.logs.skip(fmt.Sprint(args...))
+	r.emit(EventActionLog, r.logs.skip(fmt.Sprint(args...)))
 	r.SkipNow()
 }
 
 // Skipf is equivalent to Logf followed by SkipNow.
 func (r *reporter) Skipf(format string, args ...interface{}) {
-	r.logs.skip(fmt.Sprintf(format, args...))
+	r.emit(EventActionLog, r.logs.skip(fmt.Sprintf(format, args...)))
 	r.SkipNow()
 }
 
@@ -240,6 +242,7 @@ func (r *reporter) Parallel() {
 	if r.context.verbose {
 		r.context.printf("=== PAUSE %s\n", r.goTestName)
 	}
+	r.emit(EventActionPause, "")
 	r.done <- true     // Release calling test.
 	<-r.parent.barrier // Wait for the parent test to complete.
 	r.context.waitParallel()
@@ -247,6 +250,7 @@ func (r *reporter) Parallel() {
 	if r.context.verbose {
 		r.context.printf("=== CONT  %s\n", r.goTestName)
 	}
+	r.emit(EventActionCont, "")
 }
 
 func (r *reporter) printTestSummary() {
@@ -302,6 +306,7 @@ func (r *reporter) runWithRetry(name string, f func(t Reporter), policy RetryPol
 	if r.context.verbose {
 		r.context.printf("=== RUN   %s\n", child.goTestName)
 	}
+	child.emit(EventActionRun, "")
 	go child.run(f)
 	<-child.done
 	r.appendChildren(child)
@@ -341,13 +346,18 @@ func (r *reporter) run(f func(r Reporter)) {
 			r.Fatalf("invalid retry policy: %s", err)
 		}
 		defer cancel()
-		var retried bool
+		var (
+			retried bool
+			attempt int
+		)
 		child, err := backoff.RetryNotifyWithData(func() (*reporter, error) {
+			attempt++
 			child := r.spawn("retryable")
 			child.name = r.name
 			child.goTestName = r.goTestName
 			child.depth = r.depth
 			child.retryable = true
+			child.attempt = attempt
 			// Children never run in parallel.
 			// See Parallel().
 			go child.run(f)
@@ -358,6 +368,7 @@ func (r *reporter) run(f func(r Reporter)) {
 			return child, nil
 		}, b, func(err error, d time.Duration) {
 			retried = true
+			r.emitAttempt(EventActionRetry, fmt.Sprintf("retry after %s", d), attempt)
 			r.Logf("retry after %s", d)
 		})
 		r.noFailurePropagation = child.noFailurePropagation
@@ -438,6 +449,7 @@ func (r *reporter) start() func() {
 			r.context.release()
 		}
 
+		r.emitResult()
 		r.done <- true
 	}
 }
@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/goccy/go-yaml"
@@ -18,9 +19,15 @@ import (
 	"github.com/zoncoen/scenarigo/context"
 	"github.com/zoncoen/scenarigo/errors"
 	"github.com/zoncoen/scenarigo/internal/filepathutil"
+	"github.com/zoncoen/scenarigo/internal/snapshot"
+	"github.com/zoncoen/scenarigo/internal/tagutil"
+	"github.com/zoncoen/scenarigo/internal/tracing"
 	"github.com/zoncoen/scenarigo/plugin"
+	"github.com/zoncoen/scenarigo/protocol/graphql"
 	"github.com/zoncoen/scenarigo/protocol/grpc"
 	"github.com/zoncoen/scenarigo/protocol/http"
+	"github.com/zoncoen/scenarigo/protocol/http/openapi"
+	"github.com/zoncoen/scenarigo/protocol/websocket"
 	"github.com/zoncoen/scenarigo/reporter"
 	"github.com/zoncoen/scenarigo/schema"
 )
@@ -28,6 +35,8 @@ import (
 func init() {
 	http.Register()
 	grpc.Register()
+	websocket.Register()
+	graphql.Register()
 }
 
 // Runner represents a test runner.
@@ -42,8 +51,12 @@ type Runner struct {
 	enabledColor    bool
 	rootDir         string
 	inputConfig     schema.InputConfig
+	tagFilter       *tagutil.Filter
 	reportConfig    schema.ReportConfig
 	configNode      ast.Node
+	openAPI         *openapi.Document
+	tracingConfig   schema.TracingConfig
+	updateSnapshots bool
 }
 
 // NewRunner returns a new test runner.
@@ -62,6 +75,11 @@ func NewRunner(opts ...func(*Runner) error) (*Runner, error) {
 		}
 		r.rootDir = wd
 	}
+	f, err := tagutil.NewFilter(r.inputConfig.Tags, r.inputConfig.SkipTags)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tags: %w", err)
+	}
+	r.tagFilter = f
 	return r, nil
 }
 
@@ -86,6 +104,9 @@ func WithConfig(config *schema.Config) func(*Runner) error {
 		if config.PluginDirectory != "" {
 			opts = append(opts, WithPluginDir(filepath.Join(r.rootDir, config.PluginDirectory)))
 		}
+		if config.OpenAPI.File != "" {
+			opts = append(opts, WithOpenAPI(filepath.Join(r.rootDir, config.OpenAPI.File)))
+		}
 		for _, opt := range opts {
 			if err := opt(r); err != nil {
 				return err
@@ -98,6 +119,7 @@ func WithConfig(config *schema.Config) func(*Runner) error {
 		}
 		r.inputConfig = config.Input
 		r.reportConfig = config.Output.Report
+		r.tracingConfig = config.Tracing
 		r.configNode = config.Node
 		return nil
 	}
@@ -134,6 +156,18 @@ func WithPluginDir(path string) func(*Runner) error {
 	}
 }
 
+// WithOpenAPI returns a option which loads the OpenAPI 3 document to validate HTTP responses.
+func WithOpenAPI(path string) func(*Runner) error {
+	return func(r *Runner) error {
+		doc, err := openapi.Load(path)
+		if err != nil {
+			return fmt.Errorf("failed to load OpenAPI document %q: %w", path, err)
+		}
+		r.openAPI = doc
+		return nil
+	}
+}
+
 // WithScenariosFromReader returns a option which sets readers to read scenario contents.
 func WithScenariosFromReader(readers ...io.Reader) func(*Runner) error {
 	return func(r *Runner) error {
@@ -142,6 +176,32 @@ func WithScenariosFromReader(readers ...io.Reader) func(*Runner) error {
 	}
 }
 
+// WithTags returns a option which sets tag expressions to select scenarios and steps to run.
+// It overrides the tags of the configuration.
+func WithTags(exprs ...string) func(*Runner) error {
+	return func(r *Runner) error {
+		r.inputConfig.Tags = exprs
+		return nil
+	}
+}
+
+// WithSkipTags returns a option which sets tag expressions to select scenarios and steps not to run.
+// It overrides the skip tags of the configuration.
+func WithSkipTags(exprs ...string) func(*Runner) error {
+	return func(r *Runner) error {
+		r.inputConfig.SkipTags = exprs
+		return nil
+	}
+}
+
+// WithUpdateSnapshots returns a option which sets flag whether overwrites the snapshots by the actual values.
+func WithUpdateSnapshots(update bool) func(*Runner) error {
+	return func(r *Runner) error {
+		r.updateSnapshots = update
+		return nil
+	}
+}
+
 // WithOptionsFromEnv returns a option which sets flag whether accepts configuration from ENV.
 // Currently Available ENV variables are the following.
 //   - SCENARIGO_COLOR=(1|true|TRUE)
@@ -275,9 +335,28 @@ func (r *Runner) Run(ctx *context.Context) {
 		return
 	}
 
+	tp, err := newTracerProvider(ctx.RequestContext(), r.tracingConfig, r.rootDir)
+	if err != nil {
+		ctx.Reporter().Errorf("failed to set up tracing: %s", err)
+		teardown(ctx)
+		return
+	}
+	if tp != nil {
+		defer func() {
+			if err := tp.Shutdown(gocontext.Background()); err != nil {
+				ctx.Reporter().Logf("failed to export spans: %s", err)
+			}
+		}()
+		ctx = ctx.WithRequestContext(tracing.WithTracerProvider(ctx.RequestContext(), tp))
+	}
+
 	opts := []schema.LoadOption{
 		schema.WithInputConfig(r.rootDir, r.inputConfig),
 	}
+	ctx = ctx.WithTagFilter(r.tagFilter)
+	if r.openAPI != nil {
+		ctx = ctx.WithOpenAPI(r.openAPI)
+	}
 
 FILE_LOOP:
 	for _, f := range r.scenarioFiles {
@@ -291,42 +370,149 @@ FILE_LOOP:
 			}
 		}
 		ctx.Run(testName, func(ctx *context.Context) {
+			ctx, end := startSpan(ctx, spanTypeFile, testName)
+			defer end()
+			ctx = ctx.WithSnapshotFile(snapshot.New(f, r.updateSnapshots))
 			scns, err := schema.LoadScenarios(f, opts...)
 			if err != nil {
 				ctx.Reporter().Fatalf("failed to load scenarios: %s", err)
 			}
-			for _, scn := range scns {
-				scn := scn
-				ctx = ctx.WithNode(scn.Node)
-				ctx.Run(scn.Title, func(ctx *context.Context) {
-					ctx.Reporter().Parallel()
-					_ = RunScenario(ctx, scn)
-				})
+			if !runScenarios(ctx, scns) {
+				ctx.Reporter().Skip("no scenarios match the tags")
 			}
 		})
 	}
 	for i, reader := range r.scenarioReaders {
 		ctx.Run(fmt.Sprint(i), func(ctx *context.Context) {
+			ctx, end := startSpan(ctx, spanTypeFile, fmt.Sprint(i))
+			defer end()
 			scns, err := schema.LoadScenariosFromReader(reader)
 			if err != nil {
 				ctx.Reporter().Fatalf("failed to load scenarios: %s", err)
 			}
-			for _, scn := range scns {
-				scn := scn
-				ctx = ctx.WithNode(scn.Node)
-				ctx.Run(scn.Title, func(ctx *context.Context) {
-					ctx.Reporter().Parallel()
-					_ = RunScenario(ctx, scn)
-				})
+			if !runScenarios(ctx, scns) {
+				ctx.Reporter().Skip("no scenarios match the tags")
 			}
 		})
 	}
 	teardown(ctx)
 }
 
+// runScenarios runs the scenarios which match the tags.
+// It reports whether any scenarios are run.
+func runScenarios(ctx *context.Context, scns []*schema.Scenario) bool {
+	run := len(scns) == 0
+	var names snapshot.Names
+	for _, scn := range scns {
+		scn := scn
+		// the snapshot names are decided before filtering by tags so that they don't depend on the tags
+		sets := scn.ParameterSets()
+		titles := []string{scn.Title}
+		if sets != nil {
+			titles = make([]string, len(sets))
+			for i, params := range sets {
+				titles[i] = parameterizedTitle(scn.Title, params)
+			}
+		}
+		snapshotNames := make([]string, len(titles))
+		for i, title := range titles {
+			snapshotNames[i] = names.Unique(title)
+		}
+		if !matchTags(ctx.TagFilter(), scn) {
+			continue
+		}
+		run = true
+		ctx = ctx.WithNode(scn.Node)
+		if sets == nil {
+			ctx.Run(scn.Title, func(ctx *context.Context) {
+				parallel(ctx, scn)
+				ctx = withSnapshotName(ctx, snapshotNames[0])
+				ctx, end := startSpan(ctx, spanTypeScenario, scn.Title)
+				defer end()
+				_ = RunScenario(ctx, scn)
+			})
+			continue
+		}
+		// run a parameterized scenario as the separated scenarios for each parameter set
+		for i, params := range sets {
+			i, params := i, params
+			ctx.Run(titles[i], func(ctx *context.Context) {
+				parallel(ctx, scn)
+				ctx = withSnapshotName(ctx, snapshotNames[i])
+				ctx, end := startSpan(ctx, spanTypeScenario, titles[i])
+				defer end()
+				scn, err := scn.Clone()
+				if err != nil {
+					ctx.Reporter().Fatalf("failed to clone scenario: %s", err)
+				}
+				vars, err := ctx.ExecuteTemplate(params.ToMap())
+				if err != nil {
+					ctx.Reporter().Fatalf(
+						"invalid parameters: %s",
+						errors.WithNodeAndColored(
+							errors.WithPath(err, "parameters"),
+							ctx.Node(),
+							ctx.EnabledColor(),
+						),
+					)
+				}
+				_ = RunScenario(ctx.WithVars(vars), scn)
+			})
+		}
+	}
+	return run
+}
+
+// parallel signals that the scenario is to be run in parallel with other scenarios unless it opts out.
+// The scenarios that opt out run serially before the parallel scenarios in the same file start.
+func parallel(ctx *context.Context, scn *schema.Scenario) {
+	if scn.Parallel == nil || *scn.Parallel {
+		ctx.Reporter().Parallel()
+	}
+}
+
+// matchTags reports whether the scenario has steps to run.
+func matchTags(f *tagutil.Filter, scn *schema.Scenario) bool {
+	if len(scn.Steps) == 0 {
+		return f.Match(scn.Tags)
+	}
+	for _, step := range scn.Steps {
+		if f.Match(scn.StepTags(step)) {
+			return true
+		}
+	}
+	return false
+}
+
+func parameterizedTitle(title string, params schema.OrderedMap[string, any]) string {
+	kvs := make([]string, 0, params.Len())
+	for _, p := range params.ToSlice() {
+		v, ok := p.Value.(string)
+		if !ok {
+			b, err := yaml.MarshalWithOptions(p.Value, yaml.Flow(true))
+			if err != nil {
+				v = fmt.Sprint(p.Value)
+			} else {
+				v = strings.TrimSpace(string(b))
+			}
+		}
+		kvs = append(kvs, fmt.Sprintf("%s=%s", p.Key, v))
+	}
+	return strings.TrimSpace(fmt.Sprintf("%s [%s]", title, strings.Join(kvs, ", ")))
+}
+
+// OpenAPICoverage returns the coverage of the operations of the OpenAPI document.
+// It returns nil if the document is not set.
+func (r *Runner) OpenAPICoverage() *openapi.Coverage {
+	if r.openAPI == nil {
+		return nil
+	}
+	return r.openAPI.Coverage()
+}
+
 // CreateTestReport creates test reports.
 func (r *Runner) CreateTestReport(rptr reporter.Reporter) error {
-	if r.reportConfig.JSON.Filename == "" && r.reportConfig.JUnit.Filename == "" {
+	if r.reportConfig.JSON.Filename == "" && r.reportConfig.JUnit.Filename == "" && r.reportConfig.HTML.Filename == "" {
 		return nil
 	}
 
@@ -358,6 +544,16 @@ func (r *Runner) CreateTestReport(rptr reporter.Reporter) error {
 			return fmt.Errorf("failed to write JUnit test report: %w", err)
 		}
 	}
+	if r.reportConfig.HTML.Filename != "" {
+		f, err := os.Create(filepathutil.From(r.rootDir, r.reportConfig.HTML.Filename))
+		if err != nil {
+			return fmt.Errorf("failed to write HTML test report: %w", err)
+		}
+		defer f.Close()
+		if err := report.WriteHTML(f); err != nil {
+			return fmt.Errorf("failed to write HTML test report: %w", err)
+		}
+	}
 	return nil
 }
 
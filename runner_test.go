@@ -3,6 +3,7 @@ package scenarigo
 import (
 	"bytes"
 	gocontext "context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -10,8 +11,11 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/sergi/go-diff/diffmatchpatch"
@@ -79,6 +83,34 @@ func TestRunner(t *testing.T) {
 				}
 			},
 		},
+		"share cookies with included scenario": {
+			path: filepath.Join("testdata", "use_include_cookie.yaml"),
+			setup: func(ctx *context.Context) func(*context.Context) {
+				mux := http.NewServeMux()
+				mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+					http.SetCookie(w, &http.Cookie{Name: "session", Value: "xxx", Path: "/"})
+				})
+				mux.HandleFunc("/me", func(w http.ResponseWriter, r *http.Request) {
+					c, err := r.Cookie("session")
+					if err != nil {
+						w.WriteHeader(http.StatusUnauthorized)
+						return
+					}
+					w.Header().Set("Content-Type", "application/json")
+					_, _ = fmt.Fprintf(w, `{"session": %q}`, c.Value)
+				})
+
+				s := httptest.NewServer(mux)
+				if err := os.Setenv("TEST_ADDR", s.URL); err != nil {
+					ctx.Reporter().Fatalf("unexpected error: %s", err)
+				}
+
+				return func(*context.Context) {
+					s.Close()
+					os.Unsetenv("TEST_ADDR")
+				}
+			},
+		},
 		"continue on error": {
 			config: &schema.Config{
 				Scenarios: []string{
@@ -205,6 +237,722 @@ secrets:
 	}
 }
 
+func TestRunner_Parameters(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.Copy(w, r.Body)
+	}))
+	defer srv.Close()
+	t.Setenv("TEST_ADDR", srv.URL)
+
+	yml := `
+---
+title: parameters
+parameters:
+- tenant: a
+  id: 1
+- tenant: b
+  id: 2
+vars:
+  message: '{{vars.tenant}}-{{vars.id}}'
+steps:
+- title: POST /echo
+  protocol: http
+  request:
+    method: POST
+    url: "{{env.TEST_ADDR}}/echo"
+    body:
+      message: '{{vars.message}}'
+  expect:
+    code: 200
+    body:
+      message: '{{vars.tenant}}-{{vars.id}}'
+---
+title: matrix
+matrix:
+  tenant: [a, b]
+  locale: [en, ja]
+steps:
+- title: POST /echo
+  protocol: http
+  request:
+    method: POST
+    url: "{{env.TEST_ADDR}}/echo"
+    body:
+      message: '{{vars.tenant}}-{{vars.locale}}'
+  expect:
+    code: 200
+    body:
+      message: '{{request.body.message}}'
+`
+	runner, err := NewRunner(WithScenariosFromReader(strings.NewReader(yml)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		b      bytes.Buffer
+		report *reporter.TestReport
+	)
+	ok := reporter.Run(func(rptr reporter.Reporter) {
+		runner.Run(context.New(rptr))
+		report, err = reporter.GenerateTestReport(rptr)
+	}, reporter.WithWriter(&b))
+	if !ok {
+		t.Fatalf("scenario failed:\n%s", b.String())
+	}
+	if err != nil {
+		t.Fatalf("failed to generate report: %s", err)
+	}
+	var names []string
+	for _, f := range report.Files {
+		for _, s := range f.Scenarios {
+			names = append(names, s.Name)
+		}
+	}
+	expect := []string{
+		"parameters [tenant=a, id=1]",
+		"parameters [tenant=b, id=2]",
+		"matrix [tenant=a, locale=en]",
+		"matrix [tenant=a, locale=ja]",
+		"matrix [tenant=b, locale=en]",
+		"matrix [tenant=b, locale=ja]",
+	}
+	if diff := cmp.Diff(expect, names); diff != "" {
+		t.Errorf("differs (-want +got):\n%s", diff)
+	}
+}
+
+func TestRunner_Loop(t *testing.T) {
+	var (
+		m     sync.Mutex
+		polls int
+	)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/echo", func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.Copy(w, r.Body)
+	})
+	mux.HandleFunc("/job", func(w http.ResponseWriter, r *http.Request) {
+		m.Lock()
+		defer m.Unlock()
+		polls++
+		state := "running"
+		if polls >= 3 {
+			state = "done"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"state":%q}`, state)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	t.Setenv("TEST_ADDR", srv.URL)
+
+	yml := `
+---
+title: loop
+vars:
+  users:
+  - name: alice
+  - name: bob
+steps:
+- title: forEach
+  protocol: http
+  loop:
+    forEach: '{{vars.users}}'
+    as: user
+  request:
+    method: POST
+    url: "{{env.TEST_ADDR}}/echo"
+    body:
+      name: '{{vars.user.name}}'
+  expect:
+    code: 200
+    body:
+      name: '{{vars.user.name}}'
+  bind:
+    vars:
+      last: '{{response.body.name}}'
+- title: until
+  protocol: http
+  loop:
+    until: '{{response.body.state == "done"}}'
+    interval: 1ms
+  request:
+    method: GET
+    url: "{{env.TEST_ADDR}}/job"
+  expect:
+    code: 200
+- title: check bind
+  protocol: http
+  request:
+    method: POST
+    url: "{{env.TEST_ADDR}}/echo"
+    body:
+      name: '{{vars.last}}'
+  expect:
+    body:
+      name: bob
+`
+	runner, err := NewRunner(WithScenariosFromReader(strings.NewReader(yml)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		b      bytes.Buffer
+		report *reporter.TestReport
+	)
+	ok := reporter.Run(func(rptr reporter.Reporter) {
+		runner.Run(context.New(rptr))
+		report, err = reporter.GenerateTestReport(rptr)
+	}, reporter.WithWriter(&b))
+	if !ok {
+		t.Fatalf("scenario failed:\n%s", b.String())
+	}
+	if err != nil {
+		t.Fatalf("failed to generate report: %s", err)
+	}
+	iterations := map[string][]string{}
+	for _, f := range report.Files {
+		for _, scn := range f.Scenarios {
+			for _, step := range scn.Steps {
+				for _, sub := range step.SubSteps {
+					iterations[step.Name] = append(iterations[step.Name], sub.Name)
+				}
+			}
+		}
+	}
+	expect := map[string][]string{
+		"forEach": {"[0]", "[1]"},
+		"until":   {"[0]", "[1]", "[2]"},
+	}
+	if diff := cmp.Diff(expect, iterations); diff != "" {
+		t.Errorf("differs (-want +got):\n%s", diff)
+	}
+
+	t.Run("until not satisfied", func(t *testing.T) {
+		yml := `
+---
+title: loop
+steps:
+- title: until
+  protocol: http
+  loop:
+    until: '{{response.body.state == "unknown"}}'
+    maxIterations: 2
+    interval: 1ms
+  request:
+    method: GET
+    url: "{{env.TEST_ADDR}}/job"
+`
+		runner, err := NewRunner(WithScenariosFromReader(strings.NewReader(yml)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var b bytes.Buffer
+		if ok := reporter.Run(func(rptr reporter.Reporter) {
+			runner.Run(context.New(rptr))
+		}, reporter.WithWriter(&b)); ok {
+			t.Fatal("expected error but no error")
+		}
+		if expect := "condition is not satisfied after 2 iterations"; !strings.Contains(b.String(), expect) {
+			t.Errorf("expected %q but got:\n%s", expect, b.String())
+		}
+	})
+}
+
+func TestRunner_SetupTeardown(t *testing.T) {
+	var (
+		m     sync.Mutex
+		items = map[string]bool{}
+	)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/items/", func(w http.ResponseWriter, r *http.Request) {
+		m.Lock()
+		defer m.Unlock()
+		id := strings.TrimPrefix(r.URL.Path, "/items/")
+		switch r.Method {
+		case http.MethodPut:
+			items[id] = true
+		case http.MethodDelete:
+			delete(items, id)
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	t.Setenv("TEST_ADDR", srv.URL)
+
+	yml := `
+---
+title: setup and teardown
+setup:
+- title: create item
+  protocol: http
+  request:
+    method: PUT
+    url: "{{env.TEST_ADDR}}/items/{{vars.id}}"
+  bind:
+    vars:
+      created: '{{vars.id}}'
+vars:
+  id: a
+steps:
+- title: timeout
+  protocol: http
+  timeout: 10ms
+  postTimeoutWaitingLimit: 10ms
+  request:
+    method: GET
+    url: "{{env.TEST_ADDR}}/slow"
+- title: skipped
+  protocol: http
+  request:
+    method: GET
+    url: "{{env.TEST_ADDR}}/items/{{vars.created}}"
+teardown:
+- title: delete item
+  protocol: http
+  request:
+    method: DELETE
+    url: "{{env.TEST_ADDR}}/items/{{vars.created}}"
+- title: unreachable
+  protocol: http
+  request:
+    method: GET
+    url: "http://127.0.0.1:0/"
+- title: after failure
+  protocol: http
+  request:
+    method: GET
+    url: "{{env.TEST_ADDR}}/items/{{vars.created}}"
+`
+	runner, err := NewRunner(WithScenariosFromReader(strings.NewReader(yml)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		b      bytes.Buffer
+		report *reporter.TestReport
+	)
+	if ok := reporter.Run(func(rptr reporter.Reporter) {
+		runner.Run(context.New(rptr))
+		report, err = reporter.GenerateTestReport(rptr)
+	}, reporter.WithWriter(&b)); ok {
+		t.Fatal("expected error but no error")
+	}
+	if err != nil {
+		t.Fatalf("failed to generate report: %s", err)
+	}
+	if len(items) != 0 {
+		t.Errorf("items are not deleted: %v", items)
+	}
+
+	type result struct {
+		Name     string
+		Result   reporter.TestResult
+		SubSteps []result
+	}
+	var got []result
+	for _, f := range report.Files {
+		for _, scn := range f.Scenarios {
+			for _, step := range scn.Steps {
+				r := result{Name: step.Name, Result: step.Result}
+				for _, sub := range step.SubSteps {
+					r.SubSteps = append(r.SubSteps, result{Name: sub.Name, Result: sub.Result})
+				}
+				got = append(got, r)
+			}
+		}
+	}
+	expect := []result{
+		{
+			Name:   "setup",
+			Result: reporter.TestResultPassed,
+			SubSteps: []result{
+				{Name: "create item", Result: reporter.TestResultPassed},
+			},
+		},
+		{Name: "timeout", Result: reporter.TestResultFailed},
+		{Name: "skipped", Result: reporter.TestResultSkipped},
+		{
+			Name:   "teardown",
+			Result: reporter.TestResultFailed,
+			SubSteps: []result{
+				{Name: "delete item", Result: reporter.TestResultPassed},
+				{Name: "unreachable", Result: reporter.TestResultFailed},
+				{Name: "after failure", Result: reporter.TestResultPassed},
+			},
+		},
+	}
+	if diff := cmp.Diff(expect, got); diff != "" {
+		t.Errorf("differs (-want +got):\n%s", diff)
+	}
+}
+
+func TestRunner_Tags(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+	t.Setenv("TEST_ADDR", srv.URL)
+
+	yml := `
+---
+title: smoke
+tags: [smoke]
+steps:
+- title: fast
+  protocol: http
+  request:
+    url: "{{env.TEST_ADDR}}"
+- title: slow
+  tags: [slow]
+  protocol: http
+  request:
+    url: "{{env.TEST_ADDR}}"
+---
+title: api
+tags: [api]
+steps:
+- title: fast
+  protocol: http
+  request:
+    url: "{{env.TEST_ADDR}}"
+- title: smoke
+  tags: [smoke]
+  protocol: http
+  request:
+    url: "{{env.TEST_ADDR}}"
+`
+	tests := map[string]struct {
+		opts   []func(*Runner) error
+		expect map[string]map[string]string
+	}{
+		"no filter": {
+			expect: map[string]map[string]string{
+				"smoke": {"fast": "passed", "slow": "passed"},
+				"api":   {"fast": "passed", "smoke": "passed"},
+			},
+		},
+		"tags": {
+			opts: []func(*Runner) error{WithTags("smoke")},
+			expect: map[string]map[string]string{
+				"smoke": {"fast": "passed", "slow": "passed"},
+				"api":   {"fast": "skipped", "smoke": "passed"},
+			},
+		},
+		"skip tags": {
+			opts: []func(*Runner) error{WithSkipTags("slow")},
+			expect: map[string]map[string]string{
+				"smoke": {"fast": "passed", "slow": "skipped"},
+				"api":   {"fast": "passed", "smoke": "passed"},
+			},
+		},
+		"expression": {
+			opts: []func(*Runner) error{WithTags("smoke&!api"), WithSkipTags("slow")},
+			expect: map[string]map[string]string{
+				"smoke": {"fast": "passed", "slow": "skipped"},
+			},
+		},
+		"config": {
+			opts: []func(*Runner) error{WithConfig(&schema.Config{
+				Input: schema.InputConfig{
+					Tags: []string{"api"},
+				},
+			})},
+			expect: map[string]map[string]string{
+				"api": {"fast": "passed", "smoke": "passed"},
+			},
+		},
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			opts := append(test.opts, WithScenariosFromReader(strings.NewReader(yml)))
+			runner, err := NewRunner(opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var (
+				b      bytes.Buffer
+				report *reporter.TestReport
+			)
+			ok := reporter.Run(func(rptr reporter.Reporter) {
+				runner.Run(context.New(rptr))
+				report, err = reporter.GenerateTestReport(rptr)
+			}, reporter.WithWriter(&b))
+			if !ok {
+				t.Fatalf("scenario failed:\n%s", b.String())
+			}
+			if err != nil {
+				t.Fatalf("failed to generate report: %s", err)
+			}
+			got := map[string]map[string]string{}
+			for _, f := range report.Files {
+				for _, s := range f.Scenarios {
+					got[s.Name] = map[string]string{}
+					for _, step := range s.Steps {
+						got[s.Name][step.Name] = step.Result.String()
+					}
+				}
+			}
+			if diff := cmp.Diff(test.expect, got); diff != "" {
+				t.Errorf("differs (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("invalid expression", func(t *testing.T) {
+		if _, err := NewRunner(WithTags("smoke&")); err == nil {
+			t.Fatal("no error")
+		}
+	})
+}
+
+func TestRunner_OpenAPI(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Total-Count", "1")
+		switch r.URL.Path {
+		case "/v1/pets":
+			_, _ = w.Write([]byte(`[{"id": 1, "name": "Tama"}]`))
+		default:
+			_, _ = w.Write([]byte(`{"id": "1", "name": "Tama"}`))
+		}
+	}))
+	defer srv.Close()
+	t.Setenv("TEST_ADDR", srv.URL)
+
+	tests := map[string]struct {
+		yml       string
+		ok        bool
+		expectErr string
+		uncovered []string
+	}{
+		"valid": {
+			yml: `
+title: valid
+steps:
+- protocol: http
+  request:
+    url: "{{env.TEST_ADDR}}/v1/pets"
+`,
+			ok:        true,
+			uncovered: []string{"POST /pets (createPet)", "GET /pets/{petId} (getPet)"},
+		},
+		"invalid": {
+			yml: `
+title: invalid
+steps:
+- protocol: http
+  request:
+    url: "{{env.TEST_ADDR}}/v1/pets/1"
+`,
+			expectErr: `.expect.body.id: response doesn't match OpenAPI operation GET /pets/{petId}: value must be an integer`,
+			uncovered: []string{"GET /pets (listPets)", "POST /pets (createPet)"},
+		},
+		"assertion failed": {
+			yml: `
+title: assertion failed
+steps:
+- protocol: http
+  request:
+    url: "{{env.TEST_ADDR}}/v1/pets"
+  expect:
+    code: Not Found
+`,
+			expectErr: `expected "Not Found" but got "OK"`,
+			uncovered: []string{"POST /pets (createPet)", "GET /pets/{petId} (getPet)"},
+		},
+		"both assertion and validation failed": {
+			yml: `
+title: both failed
+steps:
+- protocol: http
+  request:
+    url: "{{env.TEST_ADDR}}/v1/pets/1"
+  expect:
+    body:
+      name: Pochi
+`,
+			expectErr: `2 errors occurred: expected "Pochi" but got "Tama"`,
+			uncovered: []string{"GET /pets (listPets)", "POST /pets (createPet)"},
+		},
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			runner, err := NewRunner(
+				WithOpenAPI("protocol/http/openapi/testdata/petstore.yaml"),
+				WithScenariosFromReader(strings.NewReader(test.yml)),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var b bytes.Buffer
+			ok := reporter.Run(func(rptr reporter.Reporter) {
+				runner.Run(context.New(rptr))
+			}, reporter.WithWriter(&b), reporter.WithNoColor())
+			if ok != test.ok {
+				t.Fatalf("expected %t but got %t:\n%s", test.ok, ok, b.String())
+			}
+			if !strings.Contains(b.String(), test.expectErr) {
+				t.Errorf("output does not contain %q:\n%s", test.expectErr, b.String())
+			}
+			uncovered := []string{}
+			for _, o := range runner.OpenAPICoverage().Uncovered() {
+				uncovered = append(uncovered, o.String())
+			}
+			if diff := cmp.Diff(test.uncovered, uncovered); diff != "" {
+				t.Errorf("differs (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("invalid document", func(t *testing.T) {
+		if _, err := NewRunner(WithOpenAPI("protocol/http/openapi/testdata/not-found.yaml")); err == nil {
+			t.Fatal("no error")
+		}
+	})
+}
+
+func TestRunner_Snapshot(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.Copy(w, r.Body)
+	}))
+	defer srv.Close()
+	t.Setenv("TEST_ADDR", srv.URL)
+
+	step := func(title, message string) string {
+		return fmt.Sprintf(`
+- title: %s
+  protocol: http
+  request:
+    method: POST
+    url: "{{env.TEST_ADDR}}/echo"
+    body:
+      message: %s
+  expect:
+    body: '{{assert.snapshot}}'`, title, message)
+	}
+	scenarioPath := filepath.Join(t.TempDir(), "echo.yaml")
+	yml := "title: echo\nsteps:" + step("POST /echo", "a") + step("POST /echo", "b") + step(`""`, "c") + step(`""`, "d") +
+		"\n---\ntitle: echo\nsteps:" + step("POST /echo", "e")
+	if err := os.WriteFile(scenarioPath, []byte(yml), 0o644); err != nil { //nolint:gosec
+		t.Fatalf("failed to write scenario: %s", err)
+	}
+	run := func(t *testing.T, update bool) (bool, string) {
+		t.Helper()
+		runner, err := NewRunner(WithScenarios(scenarioPath), WithUpdateSnapshots(update))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var b bytes.Buffer
+		ok := reporter.Run(func(rptr reporter.Reporter) {
+			runner.Run(context.New(rptr))
+		}, reporter.WithWriter(&b), reporter.WithNoColor())
+		return ok, b.String()
+	}
+
+	if ok, out := run(t, true); !ok {
+		t.Fatalf("failed to update snapshots:\n%s", out)
+	}
+	b, err := os.ReadFile(filepath.Join(filepath.Dir(scenarioPath), "__snapshots__", "echo.yaml.snap"))
+	if err != nil {
+		t.Fatalf("failed to read snapshot file: %s", err)
+	}
+	if got, expect := string(b), `echo/POST_/echo:
+  message: a
+"echo/POST_/echo#01":
+  message: b
+"echo/#00":
+  message: c
+"echo/#01":
+  message: d
+"echo#01/POST_/echo":
+  message: e
+`; got != expect {
+		t.Errorf("expect %q but got %q", expect, got)
+	}
+	if ok, out := run(t, false); !ok {
+		t.Errorf("snapshots don't match:\n%s", out)
+	}
+}
+
+func TestRunner_Parallel(t *testing.T) {
+	var (
+		m          sync.Mutex
+		running    int
+		maxRunning int
+		serial     []int
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		if r.URL.Query().Get("serial") == "true" {
+			serial = append(serial, running)
+		}
+		m.Unlock()
+		time.Sleep(50 * time.Millisecond)
+		m.Lock()
+		running--
+		m.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+	t.Setenv("TEST_ADDR", srv.URL)
+
+	scenario := func(title string, parallel bool) string {
+		return fmt.Sprintf(`
+---
+title: %s
+parallel: %t
+steps:
+- protocol: http
+  request:
+    url: "{{env.TEST_ADDR}}?serial=%t"
+`, title, parallel, !parallel)
+	}
+	var b strings.Builder
+	for i := 0; i < 6; i++ {
+		b.WriteString(scenario(fmt.Sprintf("parallel-%d", i), true))
+	}
+	b.WriteString(scenario("serial", false))
+	b.WriteString(scenario("serial-2", false))
+
+	runner, err := NewRunner(WithScenariosFromReader(strings.NewReader(b.String())))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	ok := reporter.Run(func(rptr reporter.Reporter) {
+		runner.Run(context.New(rptr))
+	}, reporter.WithWriter(&out), reporter.WithMaxParallel(3))
+	if !ok {
+		t.Fatalf("scenario failed:\n%s", out.String())
+	}
+	if maxRunning != 3 {
+		t.Errorf("expect max concurrency 3 but got %d", maxRunning)
+	}
+	if diff := cmp.Diff([]int{1, 1}, serial); diff != "" {
+		t.Errorf("serial scenarios ran concurrently (-want +got):\n%s", diff)
+	}
+}
+
 func TestRunnerFail(t *testing.T) {
 	tests := map[string]struct {
 		path   string
@@ -533,6 +1281,14 @@ func TestWriteTestReport(t *testing.T) {
 			},
 			files: []string{"junit.xml"},
 		},
+		"html": {
+			config: schema.ReportConfig{
+				HTML: schema.HTMLReportConfig{
+					Filename: "report.html",
+				},
+			},
+			files: []string{"report.html"},
+		},
 		"all": {
 			config: schema.ReportConfig{
 				JSON: schema.JSONReportConfig{
@@ -541,8 +1297,11 @@ func TestWriteTestReport(t *testing.T) {
 				JUnit: schema.JUnitReportConfig{
 					Filename: "junit.xml",
 				},
+				HTML: schema.HTMLReportConfig{
+					Filename: "report.html",
+				},
 			},
-			files: []string{"report.json", "junit.xml"},
+			files: []string{"report.json", "junit.xml", "report.html"},
 		},
 		"abs file path": {
 			config: schema.ReportConfig{
@@ -609,6 +1368,62 @@ func TestWriteTestReport(t *testing.T) {
 	}
 }
 
+func TestWriteTestReport_HTMLSecrets(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+	t.Setenv("TEST_ADDR", srv.URL)
+
+	yml := `
+title: secrets
+secrets:
+  token: XXXXX-SECRET-TOKEN
+steps:
+- title: get
+  protocol: http
+  request:
+    url: "{{env.TEST_ADDR}}"
+    header:
+      Authorization: "Bearer {{secrets.token}}"
+`
+	dir := t.TempDir()
+	r, err := NewRunner(
+		WithScenariosFromReader(strings.NewReader(yml)),
+		WithConfig(&schema.Config{
+			Output: schema.OutputConfig{
+				Report: schema.ReportConfig{
+					HTML: schema.HTMLReportConfig{
+						Filename: "report.html",
+					},
+				},
+			},
+			Root: dir,
+		}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create a runner: %s", err)
+	}
+	var reportErr error
+	reporter.Run(func(rptr reporter.Reporter) {
+		r.Run(context.New(rptr))
+		reportErr = r.CreateTestReport(rptr)
+	}, reporter.WithWriter(io.Discard))
+	if reportErr != nil {
+		t.Fatalf("failed to create reports: %s", reportErr)
+	}
+	b, err := os.ReadFile(filepath.Join(dir, "report.html"))
+	if err != nil {
+		t.Fatalf("failed to read report: %s", err)
+	}
+	if strings.Contains(string(b), "XXXXX-SECRET-TOKEN") {
+		t.Errorf("secret is not masked:\n%s", b)
+	}
+	if !strings.Contains(string(b), "Bearer {{secrets.token}}") {
+		t.Errorf("request log not found:\n%s", b)
+	}
+}
+
 func TestRunner_Dump(t *testing.T) {
 	wd, err := os.Getwd()
 	if err != nil {
@@ -786,3 +1601,118 @@ func parseConfig(t *testing.T, s string) *schema.Config {
 	}
 	return cfg
 }
+
+func TestRunner_Tracing(t *testing.T) {
+	var (
+		m           sync.Mutex
+		count       int
+		traceparent []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m.Lock()
+		defer m.Unlock()
+		count++
+		traceparent = append(traceparent, r.Header.Get("Traceparent"))
+		if count == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+	t.Setenv("TEST_ADDR", srv.URL)
+
+	dir := t.TempDir()
+	runner, err := NewRunner(
+		WithConfig(&schema.Config{
+			Root: dir,
+			Tracing: schema.TracingConfig{
+				File: "traces.json",
+			},
+		}),
+		WithScenariosFromReader(strings.NewReader(`
+title: tracing
+steps:
+- title: GET
+  protocol: http
+  request:
+    url: "{{env.TEST_ADDR}}"
+  expect:
+    code: OK
+  retry:
+    constant:
+      interval: 1ms
+      maxRetries: 1
+`)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b bytes.Buffer
+	if ok := reporter.Run(func(rptr reporter.Reporter) {
+		runner.Run(context.New(rptr))
+	}, reporter.WithWriter(&b)); !ok {
+		t.Fatalf("test failed:\n%s", b.String())
+	}
+
+	f, err := os.Open(filepath.Join(dir, "traces.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	type spanContext struct {
+		TraceID string
+		SpanID  string
+	}
+	type span struct {
+		Name        string
+		SpanContext spanContext
+		Parent      spanContext
+	}
+	spans := map[string]span{}
+	names := map[string]string{} // span ID => name
+	dec := json.NewDecoder(f)
+	for dec.More() {
+		var s span
+		if err := dec.Decode(&s); err != nil {
+			t.Fatalf("failed to decode span: %s", err)
+		}
+		names[s.SpanContext.SpanID] = s.Name
+		spans[s.SpanContext.SpanID] = s
+	}
+	parents := []string{}
+	for _, s := range spans {
+		parents = append(parents, fmt.Sprintf("%s <- %s", names[s.Parent.SpanID], s.Name))
+	}
+	sort.Strings(parents)
+	expect := []string{
+		" <- 0",
+		"0 <- tracing",
+		"GET <- attempt 1",
+		"GET <- attempt 2",
+		"attempt 1 <- HTTP GET",
+		"attempt 2 <- HTTP GET",
+		"tracing <- GET",
+	}
+	if diff := cmp.Diff(expect, parents); diff != "" {
+		t.Errorf("spans differ (-want +got):\n%s", diff)
+	}
+
+	if got := len(traceparent); got != 2 {
+		t.Fatalf("expect 2 requests but got %d", got)
+	}
+	for _, tp := range traceparent {
+		parts := strings.Split(tp, "-")
+		if len(parts) != 4 {
+			t.Fatalf("invalid traceparent header: %q", tp)
+		}
+		s, ok := spans[parts[2]]
+		if !ok {
+			t.Fatalf("span %s not found", parts[2])
+		}
+		if got, expect := s.Name, "HTTP GET"; got != expect {
+			t.Errorf("expect %q but got %q", expect, got)
+		}
+		if got, expect := s.SpanContext.TraceID, parts[1]; got != expect {
+			t.Errorf("expect trace ID %q but got %q", expect, got)
+		}
+	}
+}
@@ -6,9 +6,14 @@ import (
 	"path/filepath"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+
 	"github.com/zoncoen/scenarigo/context"
 	"github.com/zoncoen/scenarigo/errors"
+	"github.com/zoncoen/scenarigo/internal/snapshot"
+	"github.com/zoncoen/scenarigo/internal/tracing"
 	"github.com/zoncoen/scenarigo/plugin"
+	"github.com/zoncoen/scenarigo/protocol/http/cookie"
 	"github.com/zoncoen/scenarigo/reporter"
 	"github.com/zoncoen/scenarigo/schema"
 )
@@ -18,6 +23,10 @@ func RunScenario(ctx *context.Context, s *schema.Scenario) *context.Context {
 	ctx = ctx.WithScenarioFilepath(s.Filepath())
 	steps := context.NewSteps()
 	ctx = ctx.WithSteps(steps)
+	// the included scenarios share the cookie jar with the scenario which includes them
+	if ctx.CookieJar() == nil {
+		ctx = ctx.WithCookieJar(cookie.NewJar())
+	}
 
 	var setups setupFuncList
 	if s.Plugins != nil {
@@ -88,22 +97,93 @@ func RunScenario(ctx *context.Context, s *schema.Scenario) *context.Context {
 
 	scnCtx := ctx
 	var failed bool
-	for idx, step := range s.Steps {
+	if len(s.Setup) > 0 {
+		scnCtx, failed = runStepGroup(scnCtx, s, stepGroupSetup, s.Setup)
+	}
+	scnCtx, failed = runSteps(scnCtx, s, stepGroupSteps, s.Steps, failed)
+	if len(s.Teardown) > 0 {
+		// teardown steps must run even if the scenario has failed or timed out
+		tdCtx := scnCtx.WithRequestContext(gocontext.WithoutCancel(scnCtx.RequestContext()))
+		scnCtx, _ = runStepGroup(tdCtx, s, stepGroupTeardown, s.Teardown)
+	}
+
+	if teardown != nil {
+		teardown(scnCtx)
+	}
+
+	return scnCtx
+}
+
+// stepGroup represents a kind of the step list of a scenario.
+type stepGroup string
+
+const (
+	stepGroupSetup    stepGroup = "setup"
+	stepGroupSteps    stepGroup = "steps"
+	stepGroupTeardown stepGroup = "teardown"
+)
+
+// runStepGroup runs the steps as sub-steps of a step called the group name to report them separately.
+func runStepGroup(ctx *context.Context, s *schema.Scenario, name stepGroup, steps []*schema.Step) (*context.Context, bool) {
+	result := ctx
+	ok := ctx.Run(string(name), func(ctx *context.Context) {
+		result, _ = runSteps(ctx, s, name, steps, false)
+	})
+	// the reporter of the group has already finished
+	return result.WithReporter(ctx.Reporter()), !ok
+}
+
+// withSnapshotName returns a copy of ctx whose snapshots are named under name.
+// The name must be unique among the sibling tests not to share the snapshots.
+func withSnapshotName(ctx *context.Context, name string) *context.Context {
+	if f := ctx.SnapshotFile(); f != nil {
+		ctx = ctx.WithSnapshotFile(f.Sub(name))
+	}
+	return ctx
+}
+
+// runSteps runs the steps in order.
+// The following steps are skipped if a step fails unless the steps are teardown steps.
+// It returns the scenario context that has the bound values and whether the steps have failed.
+func runSteps(scnCtx *context.Context, s *schema.Scenario, name stepGroup, stps []*schema.Step, failed bool) (*context.Context, bool) {
+	steps := scnCtx.Steps()
+	var names snapshot.Names
+	for idx, step := range stps {
 		step := step
-		var stepCtx *context.Context
-		ok := context.RunWithRetry(scnCtx, step.Title, func(ctx *context.Context) {
-			stepCtx = ctx
+		path := fmt.Sprintf("%s[%d]", name, idx)
+		snapshotName := names.Unique(step.Title)
+		if name != stepGroupSteps {
+			// the setup and teardown steps are reported as the sub-steps of the group
+			snapshotName = fmt.Sprintf("%s/%s", name, snapshotName)
+		}
+		var (
+			stepCtx *context.Context
+			attempt int
+		)
+		spanCtx, span := tracing.Start(scnCtx.RequestContext(), spanName(spanTypeStep, step.Title), spanAttributes(spanTypeStep)...)
+		ok := context.RunWithRetry(scnCtx.WithRequestContext(spanCtx), step.Title, func(ctx *context.Context) {
+			if step.Retry != nil {
+				attempt++
+				var end func()
+				ctx, end = startSpan(ctx, spanTypeAttempt, fmt.Sprintf("attempt %d", attempt), attribute.Int("scenarigo.attempt", attempt))
+				defer end()
+			}
+			stepCtx = withSnapshotName(ctx, snapshotName)
 
 			// following steps are skipped if the previous step failed
 			if failed {
 				stepCtx.Reporter().SkipNow()
 			}
+			// the setup and teardown steps are a part of the scenario so that they are not filtered by tags
+			if name == stepGroupSteps && !ctx.TagFilter().Match(s.StepTags(step)) {
+				stepCtx.Reporter().Skip("skipped by tags")
+			}
 			if run, err := executeIf(ctx, step.If); err != nil {
 				stepCtx.Reporter().Fatal(
 					errors.WithNodeAndColored(
 						errors.WithPath(
 							err,
-							fmt.Sprintf("steps[%d].if", idx),
+							path+".if",
 						),
 						stepCtx.Node(),
 						stepCtx.EnabledColor(),
@@ -123,7 +203,11 @@ func RunScenario(ctx *context.Context, s *schema.Scenario) *context.Context {
 				stepCtx = stepCtx.WithRequestContext(reqCtx)
 			}
 
-			stepCtx = runStepWithTimeout(stepCtx, s, step, idx)
+			if step.Loop != nil {
+				stepCtx = runLoop(stepCtx, s, step, path)
+			} else {
+				stepCtx = runStepWithTimeout(stepCtx, s, step, path)
+			}
 
 			// bind values to the scenario context for enable to access from following steps
 			if step.Bind.Vars != nil {
@@ -133,7 +217,7 @@ func RunScenario(ctx *context.Context, s *schema.Scenario) *context.Context {
 						errors.WithNodeAndColored(
 							errors.WrapPath(
 								err,
-								fmt.Sprintf("steps[%d].bind.vars", idx),
+								path+".bind.vars",
 								"invalid bind",
 							),
 							stepCtx.Node(),
@@ -150,7 +234,7 @@ func RunScenario(ctx *context.Context, s *schema.Scenario) *context.Context {
 						errors.WithNodeAndColored(
 							errors.WrapPath(
 								err,
-								fmt.Sprintf("steps[%d].bind.secrets", idx),
+								path+".bind.secrets",
 								"invalid bind",
 							),
 							stepCtx.Node(),
@@ -162,12 +246,15 @@ func RunScenario(ctx *context.Context, s *schema.Scenario) *context.Context {
 				reporter.SetLogReplacer(stepCtx.Reporter(), scnCtx.Secrets())
 			}
 		}, step.Retry)
-		if !ok && !step.ContinueOnError {
+		if !ok && !step.ContinueOnError && name != stepGroupTeardown {
 			failed = true
 		}
 		if stepCtx == nil {
+			span.End()
 			continue
 		}
+		span.SetAttributes(attribute.String("scenarigo.test", stepCtx.Reporter().Name()))
+		tracing.End(span, stepCtx.Reporter())
 		if step.ID != "" {
 			steps.Add(step.ID, &context.Step{ //nolint:exhaustruct
 				Result: reporter.TestResultString(stepCtx.Reporter()),
@@ -175,11 +262,7 @@ func RunScenario(ctx *context.Context, s *schema.Scenario) *context.Context {
 		}
 	}
 
-	if teardown != nil {
-		teardown(scnCtx)
-	}
-
-	return scnCtx
+	return scnCtx, failed
 }
 
 func executeIf(ctx *context.Context, expr string) (bool, error) {
@@ -197,7 +280,7 @@ func executeIf(ctx *context.Context, expr string) (bool, error) {
 	return run, nil
 }
 
-func runStepWithTimeout(ctx *context.Context, scenario *schema.Scenario, step *schema.Step, idx int) *context.Context {
+func runStepWithTimeout(ctx *context.Context, scenario *schema.Scenario, step *schema.Step, path string) *context.Context {
 	done := make(chan *context.Context)
 	go func() {
 		var finished bool
@@ -206,7 +289,7 @@ func runStepWithTimeout(ctx *context.Context, scenario *schema.Scenario, step *s
 				done <- ctx
 			}
 		}()
-		done <- runStep(ctx, scenario, step, idx)
+		done <- runStep(ctx, scenario, step, path)
 		finished = true
 	}()
 	select {
@@ -215,7 +298,7 @@ func runStepWithTimeout(ctx *context.Context, scenario *schema.Scenario, step *s
 		ctx.Reporter().Error(
 			errors.WithNodeAndColored(
 				errors.ErrorPath(
-					fmt.Sprintf("steps[%d].timeout", idx),
+					path+".timeout",
 					"timeout exceeded",
 				),
 				ctx.Node(),
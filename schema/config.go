@@ -15,6 +15,7 @@ import (
 	"github.com/zoncoen/scenarigo/errors"
 	"github.com/zoncoen/scenarigo/internal/filepathutil"
 	"github.com/zoncoen/scenarigo/protocol"
+	"github.com/zoncoen/scenarigo/schema/jsonschema"
 )
 
 // Config represents a configuration.
@@ -26,6 +27,9 @@ type Config struct {
 	PluginDirectory string                           `yaml:"pluginDirectory,omitempty"`
 	Plugins         OrderedMap[string, PluginConfig] `yaml:"plugins,omitempty"`
 	Protocols       ProtocolOptions                  `yaml:"protocols,omitempty"`
+	Parallel        int                              `yaml:"parallel,omitempty"` // maximum number of scenarios to run in parallel
+	OpenAPI         OpenAPIConfig                    `yaml:"openapi,omitempty"`
+	Tracing         TracingConfig                    `yaml:"tracing,omitempty"`
 	Input           InputConfig                      `yaml:"input,omitempty"`
 	Output          OutputConfig                     `yaml:"output,omitempty"`
 
@@ -53,6 +57,23 @@ func (opts ProtocolOptions) MarshalYAML() ([]byte, error) {
 	return (OrderedMap[string, any])(opts).MarshalYAML()
 }
 
+// JSONSchema implements jsonschema.Provider interface.
+// The schemas of the options are provided by each protocol that implements protocol.SchemaProvider interface.
+func (opts ProtocolOptions) JSONSchema() *jsonschema.Schema {
+	s := &jsonschema.Schema{
+		Type:                 jsonschema.Types{jsonschema.TypeObject},
+		AdditionalProperties: jsonschema.Any(),
+	}
+	for name, p := range protocol.All() {
+		if sp, ok := p.(protocol.SchemaProvider); ok {
+			if opt := sp.OptionSchema(); opt != nil {
+				s.SetProperty(name, opt)
+			}
+		}
+	}
+	return s
+}
+
 // UnmarshalYAML implements yaml.BytesUnmarshaler interface.
 func (opts *ProtocolOptions) UnmarshalYAML(b []byte) error {
 	in := NewOrderedMap[string, RawMessage]()
@@ -92,9 +113,32 @@ func (opts ProtocolOptions) Set() error {
 	return nil
 }
 
+// OpenAPIConfig represents an OpenAPI configuration.
+type OpenAPIConfig struct {
+	File string `yaml:"file,omitempty"` // OpenAPI 3 document to validate HTTP responses
+}
+
+// TracingConfig represents an OpenTelemetry tracing configuration.
+// The tracing is enabled if any exporter is configured.
+type TracingConfig struct {
+	ServiceName string            `yaml:"serviceName,omitempty"` // default is "scenarigo"
+	OTLP        OTLPTracingConfig `yaml:"otlp,omitempty"`
+	File        string            `yaml:"file,omitempty"` // file to write the spans in JSON for offline use
+}
+
+// OTLPTracingConfig represents an OTLP exporter configuration.
+type OTLPTracingConfig struct {
+	Endpoint string            `yaml:"endpoint,omitempty"` // host:port of the collector
+	Protocol string            `yaml:"protocol,omitempty"` // "grpc" (default) or "http/protobuf"
+	Insecure bool              `yaml:"insecure,omitempty"`
+	Headers  map[string]string `yaml:"headers,omitempty"`
+}
+
 // InputConfig represents an input configuration.
 type InputConfig struct {
 	Excludes []Regexp        `yaml:"excludes,omitempty"`
+	Tags     []string        `yaml:"tags,omitempty"`     // tag expressions to select scenarios and steps to run
+	SkipTags []string        `yaml:"skipTags,omitempty"` // tag expressions to select scenarios and steps not to run
 	YAML     YAMLInputConfig `yaml:"yaml,omitempty"`
 }
 
@@ -121,6 +165,7 @@ type OutputConfig struct {
 type ReportConfig struct {
 	JSON  JSONReportConfig  `yaml:"json,omitempty"`
 	JUnit JUnitReportConfig `yaml:"junit,omitempty"`
+	HTML  HTMLReportConfig  `yaml:"html,omitempty"`
 }
 
 // JSONReportConfig represents a JSON report configuration.
@@ -133,6 +178,11 @@ type JUnitReportConfig struct {
 	Filename string `yaml:"filename,omitempty"`
 }
 
+// HTMLReportConfig represents a HTML report configuration.
+type HTMLReportConfig struct {
+	Filename string `yaml:"filename,omitempty"`
+}
+
 // LoadConfig loads a configuration from path.
 func LoadConfig(path string) (*Config, error) {
 	r, err := os.OpenFile(path, os.O_RDONLY, 0o400)
@@ -197,6 +247,12 @@ func LoadConfigFromReader(r io.Reader, root string) (*Config, error) {
 
 func validate(c *Config) error {
 	var errs []error
+	if c.Parallel < 0 {
+		errs = append(errs, errors.WithNodeAndColored(
+			errors.ErrorPathf("parallel", "must not be negative but got %d", c.Parallel),
+			c.Node, !color.NoColor,
+		))
+	}
 	for i, p := range c.Scenarios {
 		if err := stat(c, p, (&yaml.PathBuilder{}).Root().Child("scenarios").Index(uint(i)).Build()); err != nil {
 			errs = append(errs, err)
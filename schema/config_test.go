@@ -102,6 +102,19 @@ func TestLoadConfig(t *testing.T) {
 							},
 						},
 					},
+					Parallel: 4,
+					Tracing: TracingConfig{
+						ServiceName: "e2e",
+						OTLP: OTLPTracingConfig{
+							Endpoint: "localhost:4317",
+							Protocol: "grpc",
+							Insecure: true,
+							Headers: map[string]string{
+								"x-api-key": "secret",
+							},
+						},
+						File: "traces.json",
+					},
 					Input: InputConfig{
 						Excludes: []Regexp{
 							{
@@ -109,6 +122,8 @@ func TestLoadConfig(t *testing.T) {
 								str:    ".ytt.yaml$",
 							},
 						},
+						Tags:     []string{"smoke"},
+						SkipTags: []string{"slow"},
 						YAML: YAMLInputConfig{
 							YTT: YTTConfig{
 								Enabled: true,
@@ -128,6 +143,9 @@ func TestLoadConfig(t *testing.T) {
 							JUnit: JUnitReportConfig{
 								Filename: "junit.xml",
 							},
+							HTML: HTMLReportConfig{
+								Filename: "report.html",
+							},
 						},
 					},
 					Root:     filepath.Join(wd, "testdata/config"),
@@ -197,6 +215,14 @@ func TestLoadConfig(t *testing.T) {
        2 | scenarios:
     >  3 |   - scenarios/invalid.yaml
                ^
+`,
+			},
+			"negative parallel": {
+				path: "testdata/config/invalid-parallel.yaml",
+				expect: `1 error occurred: must not be negative but got -1
+       1 | schemaVersion: config/v1
+    >  2 | parallel: -1
+                     ^
 `,
 			},
 			"plugin src not found": {
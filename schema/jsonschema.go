@@ -0,0 +1,77 @@
+package schema
+
+import (
+	"sort"
+
+	"github.com/zoncoen/scenarigo/protocol"
+	"github.com/zoncoen/scenarigo/schema/jsonschema"
+)
+
+const stepDefinition = "step"
+
+// ScenarioJSONSchema returns the JSON Schema of scenario files.
+// The schemas of request and expect are provided by the registered protocols that implement protocol.SchemaProvider interface.
+func ScenarioJSONSchema() *jsonschema.Schema {
+	s := jsonschema.Reflect(Scenario{})
+	s.Schema = jsonschema.Draft
+	s.Title = "scenarigo scenario"
+	for _, name := range []string{"setup", "steps", "teardown"} {
+		s.Property(name).Items = &jsonschema.Schema{
+			Ref: "#/definitions/" + stepDefinition,
+		}
+	}
+	s.Definitions = map[string]*jsonschema.Schema{
+		stepDefinition: stepJSONSchema(),
+	}
+	return s
+}
+
+func stepJSONSchema() *jsonschema.Schema {
+	s := jsonschema.Reflect(stepUnmarshaller{})
+	s.Property("id").Pattern = stepIDPattern
+	for _, name := range protocolNames() {
+		sp, ok := protocol.Get(name).(protocol.SchemaProvider)
+		if !ok {
+			continue
+		}
+		then := &jsonschema.Schema{}
+		if req := sp.RequestSchema(); req != nil {
+			then.SetProperty("request", req)
+		}
+		if exp := sp.ExpectSchema(); exp != nil {
+			then.SetProperty("expect", exp)
+		}
+		if then.Properties == nil {
+			continue
+		}
+		s.AllOf = append(s.AllOf, &jsonschema.Schema{
+			If: &jsonschema.Schema{
+				Properties: map[string]*jsonschema.Schema{
+					"protocol": {Const: name},
+				},
+				Required: []string{"protocol"},
+			},
+			Then: then,
+		})
+	}
+	return s
+}
+
+// ConfigJSONSchema returns the JSON Schema of configuration files.
+// The schemas of protocol options are provided by the registered protocols that implement protocol.SchemaProvider interface.
+func ConfigJSONSchema() *jsonschema.Schema {
+	s := jsonschema.Reflect(Config{})
+	s.Schema = jsonschema.Draft
+	s.Title = "scenarigo configuration"
+	return s
+}
+
+func protocolNames() []string {
+	all := protocol.All()
+	names := make([]string, 0, len(all))
+	for name := range all {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,110 @@
+// Package jsonschema provides a minimal JSON Schema representation to describe the scenarigo file formats.
+package jsonschema
+
+import (
+	"encoding/json"
+)
+
+// Draft is the JSON Schema dialect of the generated schemas.
+// The draft-07 is used because it is widely supported by editors such as yaml-language-server.
+const Draft = "http://json-schema.org/draft-07/schema#"
+
+// Type names of JSON Schema.
+const (
+	TypeString  = "string"
+	TypeInteger = "integer"
+	TypeNumber  = "number"
+	TypeBoolean = "boolean"
+	TypeObject  = "object"
+	TypeArray   = "array"
+	TypeNull    = "null"
+)
+
+// Schema represents a JSON Schema.
+type Schema struct {
+	Schema      string             `json:"$schema,omitempty"`
+	ID          string             `json:"$id,omitempty"`
+	Ref         string             `json:"$ref,omitempty"`
+	Definitions map[string]*Schema `json:"definitions,omitempty"`
+
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	Type  Types `json:"type,omitempty"`
+	Enum  []any `json:"enum,omitempty"`
+	Const any   `json:"const,omitempty"`
+
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+
+	Items *Schema `json:"items,omitempty"`
+
+	Pattern string `json:"pattern,omitempty"`
+	Format  string `json:"format,omitempty"`
+
+	AllOf []*Schema `json:"allOf,omitempty"`
+	AnyOf []*Schema `json:"anyOf,omitempty"`
+	OneOf []*Schema `json:"oneOf,omitempty"`
+
+	If   *Schema `json:"if,omitempty"`
+	Then *Schema `json:"then,omitempty"`
+	Else *Schema `json:"else,omitempty"`
+
+	// boolean is set if the schema is a boolean schema.
+	boolean *bool
+}
+
+// True returns the boolean schema that always passes validation.
+func True() *Schema {
+	b := true
+	return &Schema{boolean: &b}
+}
+
+// False returns the boolean schema that always fails validation.
+func False() *Schema {
+	b := false
+	return &Schema{boolean: &b}
+}
+
+// Any returns the schema that allows any value.
+func Any() *Schema {
+	return &Schema{}
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	if s.boolean != nil {
+		return json.Marshal(*s.boolean)
+	}
+	type schema Schema
+	return json.Marshal((*schema)(s))
+}
+
+// Property returns the schema of the property.
+// It returns nil if the property is not defined.
+func (s *Schema) Property(name string) *Schema {
+	if s == nil || s.Properties == nil {
+		return nil
+	}
+	return s.Properties[name]
+}
+
+// SetProperty sets the schema of the property.
+func (s *Schema) SetProperty(name string, prop *Schema) {
+	if s.Properties == nil {
+		s.Properties = map[string]*Schema{}
+	}
+	s.Properties[name] = prop
+}
+
+// Types represents the "type" keyword that is a string or an array of strings.
+type Types []string
+
+// MarshalJSON implements json.Marshaler interface.
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
@@ -0,0 +1,158 @@
+package jsonschema
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+)
+
+// Provider is the interface implemented by types that describe their own JSON Schema.
+// It is useful for types that have custom YAML unmarshalers.
+type Provider interface {
+	JSONSchema() *Schema
+}
+
+var (
+	providerType = reflect.TypeOf((*Provider)(nil)).Elem()
+	mapSliceType = reflect.TypeOf(yaml.MapSlice{})
+	mapItemType  = reflect.TypeOf(yaml.MapItem{})
+)
+
+// Reflect returns the JSON Schema of v.
+// The property names are taken from the yaml struct tags
+// and unknown properties are not allowed because scenarigo decodes YAML strictly.
+func Reflect(v any) *Schema {
+	return ReflectType(reflect.TypeOf(v))
+}
+
+// ReflectType returns the JSON Schema of t.
+func ReflectType(t reflect.Type) *Schema {
+	r := &reflector{
+		visited: map[reflect.Type]bool{},
+	}
+	return r.reflect(t)
+}
+
+type reflector struct {
+	visited map[reflect.Type]bool
+}
+
+func (r *reflector) reflect(t reflect.Type) *Schema {
+	if t == nil {
+		return Any()
+	}
+	if s := provided(t); s != nil {
+		return s
+	}
+	switch t {
+	case mapSliceType:
+		return &Schema{Type: Types{TypeObject}}
+	case mapItemType:
+		return Any()
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return r.reflect(t.Elem())
+	case reflect.Bool:
+		return &Schema{Type: Types{TypeBoolean}}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: Types{TypeInteger}}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: Types{TypeNumber}}
+	case reflect.String:
+		return &Schema{Type: Types{TypeString}}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: Types{TypeString}}
+		}
+		return &Schema{
+			Type:  Types{TypeArray},
+			Items: r.reflect(t.Elem()),
+		}
+	case reflect.Map:
+		return &Schema{
+			Type:                 Types{TypeObject},
+			AdditionalProperties: r.reflect(t.Elem()),
+		}
+	case reflect.Struct:
+		// avoid infinite recursion on recursive types
+		if r.visited[t] {
+			return Any()
+		}
+		r.visited[t] = true
+		defer delete(r.visited, t)
+		s := &Schema{
+			Type:                 Types{TypeObject},
+			AdditionalProperties: False(),
+		}
+		r.reflectFields(s, t)
+		return s
+	}
+	return Any()
+}
+
+func (r *reflector) reflectFields(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() && !f.Anonymous {
+			continue
+		}
+		name, inline, skip := parseTag(f)
+		if skip {
+			continue
+		}
+		if inline {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				r.reflectFields(s, ft)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		s.SetProperty(name, r.reflect(f.Type))
+	}
+}
+
+func parseTag(f reflect.StructField) (string, bool, bool) {
+	tag := f.Tag.Get("yaml")
+	if tag == "-" {
+		return "", false, true
+	}
+	opts := strings.Split(tag, ",")
+	name := opts[0]
+	inline := f.Anonymous && name == ""
+	for _, opt := range opts[1:] {
+		if opt == "inline" {
+			inline = true
+		}
+	}
+	if name == "" {
+		// same as the default key of github.com/goccy/go-yaml
+		name = strings.ToLower(f.Name)
+	}
+	return name, inline, false
+}
+
+func provided(t reflect.Type) *Schema {
+	if t.Kind() == reflect.Interface {
+		return nil
+	}
+	if t.Implements(providerType) {
+		if t.Kind() == reflect.Ptr {
+			return reflect.New(t.Elem()).Interface().(Provider).JSONSchema() //nolint:forcetypeassert
+		}
+		return reflect.Zero(t).Interface().(Provider).JSONSchema() //nolint:forcetypeassert
+	}
+	if t.Kind() != reflect.Ptr && reflect.PointerTo(t).Implements(providerType) {
+		return reflect.New(t).Interface().(Provider).JSONSchema() //nolint:forcetypeassert
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+)
+
+type testDuration string
+
+func (d testDuration) JSONSchema() *Schema {
+	return &Schema{
+		Type:   Types{TypeString},
+		Format: "duration",
+	}
+}
+
+type testEmbedded struct {
+	Embedded string `yaml:"embedded,omitempty"`
+}
+
+type testStruct struct {
+	testEmbedded `yaml:",inline"`
+
+	Str      string            `yaml:"str,omitempty"`
+	Int      *int              `yaml:"int,omitempty"`
+	Float    float64           `yaml:"float,omitempty"`
+	Bool     bool              `yaml:"bool,omitempty"`
+	Bytes    []byte            `yaml:"bytes,omitempty"`
+	Strs     []string          `yaml:"strs,omitempty"`
+	Map      map[string]int    `yaml:"map,omitempty"`
+	Any      any               `yaml:"any,omitempty"`
+	MapSlice yaml.MapSlice     `yaml:"mapSlice,omitempty"`
+	Duration *testDuration     `yaml:"duration,omitempty"`
+	Child    *testStruct       `yaml:"child,omitempty"`
+	Children map[string]string `yaml:"-"`
+	NoTag    string
+
+	unexported string
+}
+
+func TestReflect(t *testing.T) {
+	expect := `{
+  "type": "object",
+  "properties": {
+    "any": {},
+    "bool": {
+      "type": "boolean"
+    },
+    "bytes": {
+      "type": "string"
+    },
+    "child": {},
+    "duration": {
+      "type": "string",
+      "format": "duration"
+    },
+    "embedded": {
+      "type": "string"
+    },
+    "float": {
+      "type": "number"
+    },
+    "int": {
+      "type": "integer"
+    },
+    "map": {
+      "type": "object",
+      "additionalProperties": {
+        "type": "integer"
+      }
+    },
+    "mapSlice": {
+      "type": "object"
+    },
+    "notag": {
+      "type": "string"
+    },
+    "str": {
+      "type": "string"
+    },
+    "strs": {
+      "type": "array",
+      "items": {
+        "type": "string"
+      }
+    }
+  },
+  "additionalProperties": false
+}`
+	b, err := json.MarshalIndent(Reflect(testStruct{}), "", "  ")
+	if err != nil {
+		t.Fatalf("failed to marshal: %s", err)
+	}
+	if diff := cmp.Diff(expect, string(b)); diff != "" {
+		t.Errorf("differs (-want +got):\n%s", diff)
+	}
+}
+
+func TestSchema_MarshalJSON(t *testing.T) {
+	tests := map[string]struct {
+		schema *Schema
+		expect string
+	}{
+		"true": {
+			schema: True(),
+			expect: `true`,
+		},
+		"false": {
+			schema: False(),
+			expect: `false`,
+		},
+		"any": {
+			schema: Any(),
+			expect: `{}`,
+		},
+		"multiple types": {
+			schema: &Schema{
+				Type:                 Types{TypeString, TypeNull},
+				AdditionalProperties: False(),
+			},
+			expect: `{"type":["string","null"],"additionalProperties":false}`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			b, err := json.Marshal(test.schema)
+			if err != nil {
+				t.Fatalf("failed to marshal: %s", err)
+			}
+			if got := string(b); got != test.expect {
+				t.Errorf("expect %s but got %s", test.expect, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/zoncoen/scenarigo/protocol"
+	"github.com/zoncoen/scenarigo/schema/jsonschema"
+)
+
+type schemaTestProtocol struct {
+	testProtocol
+}
+
+func (p *schemaTestProtocol) OptionSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{Type: jsonschema.Types{jsonschema.TypeObject}}
+}
+
+func (p *schemaTestProtocol) RequestSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{Type: jsonschema.Types{jsonschema.TypeString}}
+}
+
+func (p *schemaTestProtocol) ExpectSchema() *jsonschema.Schema {
+	return nil
+}
+
+func TestScenarioJSONSchema(t *testing.T) {
+	p := &schemaTestProtocol{testProtocol{name: "schema-test"}}
+	protocol.Register(p)
+	defer protocol.Unregister(p.Name())
+
+	s := ScenarioJSONSchema()
+	if got, expect := s.Schema, jsonschema.Draft; got != expect {
+		t.Errorf("expect %q but got %q", expect, got)
+	}
+	for _, name := range []string{"setup", "steps", "teardown"} {
+		if got, expect := s.Property(name).Items.Ref, "#/definitions/step"; got != expect {
+			t.Errorf("%s: expect %q but got %q", name, expect, got)
+		}
+	}
+	step, ok := s.Definitions["step"]
+	if !ok {
+		t.Fatal("step definition not found")
+	}
+	if got, expect := step.Property("id").Pattern, stepIDPattern; got != expect {
+		t.Errorf("expect %q but got %q", expect, got)
+	}
+	var found bool
+	for _, cond := range step.AllOf {
+		if cond.If.Property("protocol").Const != p.Name() {
+			continue
+		}
+		found = true
+		expect := &jsonschema.Schema{
+			Properties: map[string]*jsonschema.Schema{
+				"request": p.RequestSchema(),
+			},
+		}
+		if diff := cmp.Diff(expect, cond.Then, cmp.AllowUnexported(jsonschema.Schema{})); diff != "" {
+			t.Errorf("differs (-want +got):\n%s", diff)
+		}
+	}
+	if !found {
+		t.Errorf("schema of %s protocol not found", p.Name())
+	}
+}
+
+func TestConfigJSONSchema(t *testing.T) {
+	p := &schemaTestProtocol{testProtocol{name: "schema-test"}}
+	protocol.Register(p)
+	defer protocol.Unregister(p.Name())
+
+	s := ConfigJSONSchema()
+	if got, expect := s.Schema, jsonschema.Draft; got != expect {
+		t.Errorf("expect %q but got %q", expect, got)
+	}
+	if diff := cmp.Diff(p.OptionSchema(), s.Property("protocols").Property(p.Name()), cmp.AllowUnexported(jsonschema.Schema{})); diff != "" {
+		t.Errorf("differs (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(jsonschema.Reflect(PluginConfig{}), s.Property("plugins").AdditionalProperties, cmp.AllowUnexported(jsonschema.Schema{})); diff != "" {
+		t.Errorf("differs (-want +got):\n%s", diff)
+	}
+}
@@ -339,6 +339,7 @@ func TestLoadScenarios(t *testing.T) {
 				if diff := cmp.Diff(test.scenarios, got,
 					cmp.AllowUnexported(
 						Scenario{},
+						OrderedMap[string, []any]{},
 					),
 					cmp.FilterPath(func(path cmp.Path) bool {
 						s := path.String()
@@ -431,6 +432,51 @@ func TestLoadScenarios(t *testing.T) {
        3 | - title: foo
     >  4 |   protocol: aaa
                        ^
+`,
+			},
+			"validation error: matrix and parameters": {
+				path: "testdata/invalid-matrix-and-parameters.yaml",
+				expect: `validation error: testdata/invalid-matrix-and-parameters.yaml: matrix and parameters can't be specified at the same time
+       2 | parameters:
+       3 | - tenant: a
+       4 | matrix:
+    >  5 |   tenant:
+                   ^
+       6 |   - a
+       7 |   - b
+       8 | steps:
+`,
+			},
+			"validation error: empty matrix": {
+				path: "testdata/invalid-empty-matrix.yaml",
+				expect: `validation error: testdata/invalid-empty-matrix.yaml: no values for "tenant"
+       1 | title: test
+       2 | matrix:
+    >  3 |   tenant: []
+                     ^
+       4 | steps:
+       5 | - title: foo
+       6 |   protocol: test
+`,
+			},
+			"validation error: forEach and until": {
+				path: "testdata/invalid-loop.yaml",
+				expect: `validation error: testdata/invalid-loop.yaml: forEach and until can't be specified at the same time
+       4 |   protocol: test
+       5 |   loop:
+       6 |     forEach: [a, b]
+    >  7 |     until: '{{true}}'
+                      ^
+`,
+			},
+			"validation error: no protocol in teardown": {
+				path: "testdata/invalid-teardown.yaml",
+				expect: `validation error: testdata/invalid-teardown.yaml: no protocol
+       3 | - title: foo
+       4 |   protocol: test
+       5 | teardown:
+    >  6 | - title: bar
+                  ^
 `,
 			},
 			"ytt disabled": {
@@ -563,6 +609,7 @@ steps:
 				if diff := cmp.Diff(test.scenarios, got,
 					cmp.AllowUnexported(
 						Scenario{},
+						OrderedMap[string, []any]{},
 					),
 					cmp.FilterPath(func(path cmp.Path) bool {
 						s := path.String()
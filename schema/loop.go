@@ -0,0 +1,65 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/zoncoen/scenarigo/errors"
+)
+
+// Loop represents a loop of a step.
+// Each iteration runs the step as a sub-step.
+type Loop struct {
+	// ForEach is a list to iterate over.
+	// It can be a template that returns a list.
+	ForEach any `yaml:"forEach,omitempty"`
+	// As is the variable name to access the current element like "{{vars.item}}".
+	As string `yaml:"as,omitempty"` // default value is "item"
+
+	// Until is a condition to stop repeating the step.
+	// It is evaluated after each iteration, so the response of the iteration can be used.
+	Until         string    `yaml:"until,omitempty"`
+	MaxIterations *int      `yaml:"maxIterations,omitempty"` // default value is 10, 0 means forever
+	Interval      *Duration `yaml:"interval,omitempty"`      // default value is 1s
+}
+
+// ItemName returns the variable name of the current element.
+func (l *Loop) ItemName() string {
+	if l.As == "" {
+		return "item"
+	}
+	return l.As
+}
+
+// MaxIterationCount returns the maximum number of iterations of the until loop.
+// It returns 0 if the loop repeats forever until the condition holds.
+func (l *Loop) MaxIterationCount() int {
+	if l.MaxIterations != nil && *l.MaxIterations >= 0 {
+		return *l.MaxIterations
+	}
+	return 10
+}
+
+// IntervalDuration returns the interval between the iterations of the until loop.
+func (l *Loop) IntervalDuration() time.Duration {
+	if l.Interval != nil {
+		return time.Duration(*l.Interval)
+	}
+	return time.Second
+}
+
+// Validate validates the loop.
+func (l *Loop) Validate() error {
+	switch {
+	case l.ForEach != nil && l.Until != "":
+		return errors.ErrorPath("until", "forEach and until can't be specified at the same time")
+	case l.ForEach == nil && l.Until == "":
+		return errors.New("forEach or until must be specified")
+	case l.ForEach != nil && l.MaxIterations != nil:
+		return errors.ErrorPath("maxIterations", "maxIterations is available only with until")
+	case l.ForEach != nil && l.Interval != nil:
+		return errors.ErrorPath("interval", "interval is available only with until")
+	case l.Until != "" && l.As != "":
+		return errors.ErrorPath("as", "as is available only with forEach")
+	}
+	return nil
+}
@@ -4,6 +4,8 @@ import (
 	"regexp"
 
 	"github.com/goccy/go-yaml"
+
+	"github.com/zoncoen/scenarigo/schema/jsonschema"
 )
 
 // Regexp represents a regular expression pattern.
@@ -37,3 +39,11 @@ func (r *Regexp) UnmarshalYAML(b []byte) error {
 	r.Regexp = re
 	return nil
 }
+
+// JSONSchema implements jsonschema.Provider interface.
+func (r Regexp) JSONSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:   jsonschema.Types{jsonschema.TypeString},
+		Format: "regex",
+	}
+}
@@ -2,13 +2,17 @@
 package schema
 
 import (
+	"bytes"
 	"fmt"
 	"regexp"
 
+	"github.com/goccy/go-yaml"
 	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
 
 	"github.com/zoncoen/scenarigo/errors"
 	"github.com/zoncoen/scenarigo/protocol"
+	"github.com/zoncoen/scenarigo/schema/jsonschema"
 )
 
 const stepIDPattern = `^[a-zA-Z0-9\-_]+$`
@@ -20,11 +24,27 @@ type Scenario struct {
 	SchemaVersion string            `yaml:"schemaVersion,omitempty"`
 	Title         string            `yaml:"title,omitempty"`
 	Description   string            `yaml:"description,omitempty"`
+	Tags          []string          `yaml:"tags,omitempty"`
+	Parallel      *bool             `yaml:"parallel,omitempty"` // run in parallel with other scenarios (default true)
 	Plugins       map[string]string `yaml:"plugins,omitempty"`
 	Vars          map[string]any    `yaml:"vars,omitempty"`
 	Secrets       map[string]any    `yaml:"secrets,omitempty"`
 	Steps         []*Step           `yaml:"steps,omitempty"`
 
+	// Setup is a list of steps to run before the steps.
+	// The steps are skipped if a setup step fails.
+	Setup []*Step `yaml:"setup,omitempty"`
+	// Teardown is a list of steps to run after the steps.
+	// The teardown steps always run even if the scenario has failed.
+	Teardown []*Step `yaml:"teardown,omitempty"`
+
+	// Parameters is a list of variable sets.
+	// The scenario runs once for each set with the variables.
+	Parameters []OrderedMap[string, any] `yaml:"parameters,omitempty"`
+	// Matrix defines lists of values for variables.
+	// The scenario runs once for each combination of the values.
+	Matrix OrderedMap[string, []any] `yaml:"matrix,omitempty"`
+
 	// The strict YAML decoder fails to decode if finds an unknown field.
 	// Anchors is the field for enabling to define YAML anchors by avoiding the error.
 	// This field doesn't need to hold some data because anchors expand by the decoder.
@@ -41,33 +61,72 @@ func (s *Scenario) Filepath() string {
 
 // Validate validates a scenario.
 func (s *Scenario) Validate() error {
+	if len(s.Parameters) > 0 && s.Matrix.Len() > 0 {
+		return errors.WithNode(
+			errors.ErrorPath("matrix", "matrix and parameters can't be specified at the same time"),
+			s.Node,
+		)
+	}
+	for _, item := range s.Matrix.ToSlice() {
+		if len(item.Value) == 0 {
+			return errors.WithNode(
+				errors.ErrorPathf(fmt.Sprintf("matrix.%s", item.Key), "no values for %q", item.Key),
+				s.Node,
+			)
+		}
+	}
 	ids := map[string]struct{}{}
-	for i, stp := range s.Steps {
+	for _, group := range []struct {
+		name  string
+		steps []*Step
+	}{
+		{name: "setup", steps: s.Setup},
+		{name: "steps", steps: s.Steps},
+		{name: "teardown", steps: s.Teardown},
+	} {
+		if err := s.validateSteps(group.name, group.steps, ids); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Scenario) validateSteps(name string, steps []*Step, ids map[string]struct{}) error {
+	for i, stp := range steps {
 		if stp.ID != "" {
 			if !stepIDRegexp.MatchString(stp.ID) {
 				return errors.WithNode(
-					errors.ErrorPath(fmt.Sprintf("steps[%d].id", i), "step id must contain only alphanumeric characters, -, or _"),
+					errors.ErrorPath(fmt.Sprintf("%s[%d].id", name, i), "step id must contain only alphanumeric characters, -, or _"),
 					s.Node,
 				)
 			}
 			if _, ok := ids[stp.ID]; ok {
 				return errors.WithNode(
-					errors.ErrorPathf(fmt.Sprintf("steps[%d].id", i), "step id %q is duplicated", stp.ID),
+					errors.ErrorPathf(fmt.Sprintf("%s[%d].id", name, i), "step id %q is duplicated", stp.ID),
 					s.Node,
 				)
 			}
 			ids[stp.ID] = struct{}{}
 		}
 
+		if stp.Loop != nil {
+			if err := stp.Loop.Validate(); err != nil {
+				return errors.WithNode(
+					errors.WithPath(err, fmt.Sprintf("%s[%d].loop", name, i)),
+					s.Node,
+				)
+			}
+		}
+
 		if stp.Include == "" && stp.Ref == nil {
 			if stp.Protocol == "" {
 				return errors.WithNode(
-					errors.ErrorPath(fmt.Sprintf("steps[%d]", i), "no protocol"),
+					errors.ErrorPath(fmt.Sprintf("%s[%d]", name, i), "no protocol"),
 					s.Node,
 				)
 			} else if protocol.Get(stp.Protocol) == nil {
 				return errors.WithNode(
-					errors.ErrorPathf(fmt.Sprintf("steps[%d].protocol", i), "protocol %q not found", stp.Protocol),
+					errors.ErrorPathf(fmt.Sprintf("%s[%d].protocol", name, i), "protocol %q not found", stp.Protocol),
 					s.Node,
 				)
 			}
@@ -76,11 +135,78 @@ func (s *Scenario) Validate() error {
 	return nil
 }
 
+// ParameterSets returns the variable sets to run the scenario.
+// It returns nil if the scenario is not parameterized.
+func (s *Scenario) ParameterSets() []OrderedMap[string, any] {
+	if len(s.Parameters) > 0 {
+		return s.Parameters
+	}
+	if s.Matrix.Len() == 0 {
+		return nil
+	}
+	sets := []OrderedMap[string, any]{NewOrderedMap[string, any]()}
+	for _, item := range s.Matrix.ToSlice() {
+		product := make([]OrderedMap[string, any], 0, len(sets)*len(item.Value))
+		for _, set := range sets {
+			for _, v := range item.Value {
+				params := NewOrderedMap[string, any]()
+				for _, p := range set.ToSlice() {
+					params.Set(p.Key, p.Value)
+				}
+				params.Set(item.Key, v)
+				product = append(product, params)
+			}
+		}
+		sets = product
+	}
+	return sets
+}
+
+// Clone returns a deep copy of the scenario by decoding the YAML node again.
+// Template execution updates values in place,
+// so the scenario should be cloned to run it several times at the same time.
+func (s *Scenario) Clone() (*Scenario, error) {
+	node := s.Node
+	if node == nil {
+		b, err := yaml.Marshal(s)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal scenario: %w", err)
+		}
+		f, err := parser.ParseBytes(b, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse scenario: %w", err)
+		}
+		if len(f.Docs) == 0 {
+			return nil, errors.New("failed to parse scenario: empty document")
+		}
+		node = f.Docs[0].Body
+	}
+	var clone Scenario
+	dec := yaml.NewDecoder(&bytes.Buffer{}, yaml.UseOrderedMap(), yaml.Strict())
+	if err := dec.DecodeFromNode(node, &clone); err != nil {
+		return nil, fmt.Errorf("failed to decode YAML: %w", err)
+	}
+	clone.filepath = s.filepath
+	clone.Node = s.Node
+	return &clone, nil
+}
+
+// StepTags returns the tags of the step including the tags of the scenario.
+func (s *Scenario) StepTags(step *Step) []string {
+	if len(s.Tags) == 0 {
+		return step.Tags
+	}
+	tags := make([]string, 0, len(s.Tags)+len(step.Tags))
+	tags = append(tags, s.Tags...)
+	return append(tags, step.Tags...)
+}
+
 // Step represents a step of scenario.
 type Step struct {
 	ID                      string                    `validate:"alphanum"                      yaml:"id,omitempty"`
 	Title                   string                    `yaml:"title,omitempty"`
 	Description             string                    `yaml:"description,omitempty"`
+	Tags                    []string                  `yaml:"tags,omitempty"`
 	If                      string                    `yaml:"if,omitempty"`
 	ContinueOnError         bool                      `yaml:"continueOnError,omitempty"`
 	Vars                    map[string]any            `yaml:"vars,omitempty"`
@@ -94,6 +220,22 @@ type Step struct {
 	Timeout                 *Duration                 `yaml:"timeout,omitempty"`
 	PostTimeoutWaitingLimit *Duration                 `yaml:"postTimeoutWaitingLimit,omitempty"`
 	Retry                   *RetryPolicy              `yaml:"retry,omitempty"`
+	Loop                    *Loop                     `yaml:"loop,omitempty"`
+}
+
+// Clone returns a deep copy of the step by encoding and decoding it as YAML.
+// Template execution updates values in place,
+// so the step should be cloned to run it several times.
+func (s *Step) Clone() (*Step, error) {
+	b, err := yaml.Marshal(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal step: %w", err)
+	}
+	var clone Step
+	if err := yaml.UnmarshalWithOptions(b, &clone, yaml.UseOrderedMap(), yaml.Strict()); err != nil {
+		return nil, fmt.Errorf("failed to decode YAML: %w", err)
+	}
+	return &clone, nil
 }
 
 // RawMessage is a raw encoded YAML value.
@@ -104,6 +246,11 @@ func (r RawMessage) MarshalYAML() ([]byte, error) {
 	return []byte(r), nil
 }
 
+// JSONSchema implements jsonschema.Provider interface.
+func (r RawMessage) JSONSchema() *jsonschema.Schema {
+	return jsonschema.Any()
+}
+
 // UnmarshalYAML implements yaml.Unmarshaler interface.
 func (r *RawMessage) UnmarshalYAML(b []byte) error {
 	*r = b
@@ -114,6 +261,7 @@ type stepUnmarshaller struct {
 	ID                      string         `yaml:"id,omitempty"`
 	Title                   string         `yaml:"title,omitempty"`
 	Description             string         `yaml:"description,omitempty"`
+	Tags                    []string       `yaml:"tags,omitempty"`
 	If                      string         `yaml:"if,omitempty"`
 	ContinueOnError         bool           `yaml:"continueOnError,omitempty"`
 	Vars                    map[string]any `yaml:"vars,omitempty"`
@@ -125,6 +273,7 @@ type stepUnmarshaller struct {
 	Timeout                 *Duration      `yaml:"timeout,omitempty"`
 	PostTimeoutWaitingLimit *Duration      `yaml:"postTimeoutWaitingLimit,omitempty"`
 	Retry                   *RetryPolicy   `yaml:"retry,omitempty"`
+	Loop                    *Loop          `yaml:"loop,omitempty"`
 
 	Request RawMessage `yaml:"request,omitempty"`
 	Expect  RawMessage `yaml:"expect,omitempty"`
@@ -141,6 +290,7 @@ func (s *Step) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	s.ID = unmarshaled.ID
 	s.Title = unmarshaled.Title
 	s.Description = unmarshaled.Description
+	s.Tags = unmarshaled.Tags
 	s.If = unmarshaled.If
 	s.ContinueOnError = unmarshaled.ContinueOnError
 	s.Vars = unmarshaled.Vars
@@ -152,6 +302,7 @@ func (s *Step) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	s.Timeout = unmarshaled.Timeout
 	s.PostTimeoutWaitingLimit = unmarshaled.PostTimeoutWaitingLimit
 	s.Retry = unmarshaled.Retry
+	s.Loop = unmarshaled.Loop
 
 	p := protocol.Get(s.Protocol)
 	if p == nil {
@@ -184,6 +335,11 @@ type Bind struct {
 
 type anchors struct{}
 
+// JSONSchema implements jsonschema.Provider interface.
+func (a anchors) JSONSchema() *jsonschema.Schema {
+	return jsonschema.Any()
+}
+
 // UnmarshalYAML implements yaml.Unmarshaler interface.
 func (a anchors) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	return nil
@@ -0,0 +1,118 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestScenario_ParameterSets(t *testing.T) {
+	params := func(kvs ...any) OrderedMap[string, any] {
+		m := NewOrderedMap[string, any]()
+		for i := 0; i < len(kvs); i += 2 {
+			m.Set(kvs[i].(string), kvs[i+1])
+		}
+		return m
+	}
+	matrix := func(kvs ...any) OrderedMap[string, []any] {
+		m := NewOrderedMap[string, []any]()
+		for i := 0; i < len(kvs); i += 2 {
+			m.Set(kvs[i].(string), kvs[i+1].([]any))
+		}
+		return m
+	}
+	tests := map[string]struct {
+		scenario *Scenario
+		expect   []OrderedMap[string, any]
+	}{
+		"not parameterized": {
+			scenario: &Scenario{},
+		},
+		"parameters": {
+			scenario: &Scenario{
+				Parameters: []OrderedMap[string, any]{
+					params("tenant", "a", "locale", "en"),
+					params("tenant", "b"),
+				},
+			},
+			expect: []OrderedMap[string, any]{
+				params("tenant", "a", "locale", "en"),
+				params("tenant", "b"),
+			},
+		},
+		"matrix": {
+			scenario: &Scenario{
+				Matrix: matrix(
+					"tenant", []any{"a", "b"},
+					"locale", []any{"en", "ja", "fr"},
+				),
+			},
+			expect: []OrderedMap[string, any]{
+				params("tenant", "a", "locale", "en"),
+				params("tenant", "a", "locale", "ja"),
+				params("tenant", "a", "locale", "fr"),
+				params("tenant", "b", "locale", "en"),
+				params("tenant", "b", "locale", "ja"),
+				params("tenant", "b", "locale", "fr"),
+			},
+		},
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			got := test.scenario.ParameterSets()
+			if diff := cmp.Diff(test.expect, got, cmp.AllowUnexported(OrderedMap[string, any]{})); diff != "" {
+				t.Errorf("differs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestScenario_Clone(t *testing.T) {
+	s := &Scenario{
+		Title: "test",
+		Vars: map[string]any{
+			"message": "{{vars.tenant}}",
+		},
+		filepath: "test.yaml",
+	}
+	clone, err := s.Clone()
+	if err != nil {
+		t.Fatalf("failed to clone: %s", err)
+	}
+	s.Vars["message"] = "a"
+	if got, expect := clone.Vars["message"], "{{vars.tenant}}"; got != expect {
+		t.Errorf("expect %q but got %q", expect, got)
+	}
+	if got, expect := clone.Filepath(), s.Filepath(); got != expect {
+		t.Errorf("expect %q but got %q", expect, got)
+	}
+}
+
+func TestStep_Clone(t *testing.T) {
+	s := &Step{
+		Title: "test",
+		Vars: map[string]any{
+			"id": "{{vars.item.id}}",
+		},
+		Loop: &Loop{
+			ForEach: "{{vars.items}}",
+		},
+	}
+	clone, err := s.Clone()
+	if err != nil {
+		t.Fatalf("failed to clone: %s", err)
+	}
+	s.Vars["id"] = "a"
+	if diff := cmp.Diff(&Step{
+		Title: "test",
+		Vars: map[string]any{
+			"id": "{{vars.item.id}}",
+		},
+		Loop: &Loop{
+			ForEach: "{{vars.items}}",
+		},
+	}, clone); diff != "" {
+		t.Errorf("differs (-want +got):\n%s", diff)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"github.com/goccy/go-yaml"
+
+	"github.com/zoncoen/scenarigo/schema/jsonschema"
 )
 
 // Duration represents the elapsed time.
@@ -32,3 +34,11 @@ func (d *Duration) UnmarshalYAML(b []byte) error {
 	*d = Duration(in)
 	return nil
 }
+
+// JSONSchema implements jsonschema.Provider interface.
+func (d Duration) JSONSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:        jsonschema.Types{jsonschema.TypeString},
+		Description: `A duration string such as "300ms", "1.5s" or "2h45m".`,
+	}
+}
@@ -1,7 +1,11 @@
 package schema
 
 import (
+	"reflect"
+
 	"github.com/goccy/go-yaml"
+
+	"github.com/zoncoen/scenarigo/schema/jsonschema"
 )
 
 // NewOrderedMap creates a new order-preserving map.
@@ -142,3 +146,12 @@ func (m OrderedMap[K, V]) MarshalYAML() ([]byte, error) {
 func (m OrderedMap[K, V]) IsZero() bool {
 	return m.Len() == 0
 }
+
+// JSONSchema implements jsonschema.Provider interface.
+func (m OrderedMap[K, V]) JSONSchema() *jsonschema.Schema {
+	var v V
+	return &jsonschema.Schema{
+		Type:                 jsonschema.Types{jsonschema.TypeObject},
+		AdditionalProperties: jsonschema.ReflectType(reflect.TypeOf(&v).Elem()),
+	}
+}
@@ -1,7 +1,6 @@
 package scenarigo
 
 import (
-	"fmt"
 	"path/filepath"
 	"time"
 
@@ -13,7 +12,7 @@ import (
 	"github.com/zoncoen/scenarigo/schema"
 )
 
-func runStep(ctx *context.Context, scenario *schema.Scenario, s *schema.Step, stepIdx int) *context.Context {
+func runStep(ctx *context.Context, scenario *schema.Scenario, s *schema.Step, path string) *context.Context {
 	if s.Vars != nil {
 		vars, err := ctx.ExecuteTemplate(s.Vars)
 		if err != nil {
@@ -21,7 +20,7 @@ func runStep(ctx *context.Context, scenario *schema.Scenario, s *schema.Step, st
 				errors.WithNodeAndColored(
 					errors.WrapPath(
 						err,
-						fmt.Sprintf("steps[%d].vars", stepIdx),
+						path+".vars",
 						"invalid vars",
 					),
 					ctx.Node(),
@@ -37,7 +36,7 @@ func runStep(ctx *context.Context, scenario *schema.Scenario, s *schema.Step, st
 			ctx.Reporter().Fatalf(
 				"invalid secrets: %s",
 				errors.WithNodeAndColored(
-					errors.WithPath(err, fmt.Sprintf("steps[%d].secrets", stepIdx)),
+					errors.WithPath(err, path+".secrets"),
 					ctx.Node(),
 					ctx.EnabledColor(),
 				),
@@ -62,7 +61,8 @@ func runStep(ctx *context.Context, scenario *schema.Scenario, s *schema.Step, st
 		}
 		currentNode := ctx.Node()
 		ctx.Reporter().Run(testName, func(rptr reporter.Reporter) {
-			ctx = RunScenario(ctx.WithReporter(rptr).WithNode(scenarios[0].Node), scenarios[0])
+			// the included scenario is a part of the step so that it is not filtered by tags
+			ctx = RunScenario(withSnapshotName(ctx.WithReporter(rptr), testName).WithNode(scenarios[0].Node).WithTagFilter(nil), scenarios[0])
 		})
 		if ctx.Reporter().Failed() {
 			ctx.Reporter().FailNow()
@@ -79,7 +79,7 @@ func runStep(ctx *context.Context, scenario *schema.Scenario, s *schema.Step, st
 				errors.WithNodeAndColored(
 					errors.WrapPathf(
 						err,
-						fmt.Sprintf("steps[%d].ref", stepIdx),
+						path+".ref",
 						`failed to reference "%s" as step`, s.Ref,
 					),
 					ctx.Node(),
@@ -92,7 +92,7 @@ func runStep(ctx *context.Context, scenario *schema.Scenario, s *schema.Step, st
 			ctx.Reporter().Fatal(
 				errors.WithNodeAndColored(
 					errors.ErrorPathf(
-						fmt.Sprintf("steps[%d].ref", stepIdx),
+						path+".ref",
 						`failed to reference "%s" as step: not implement plugin.Step interface`, s.Ref,
 					),
 					ctx.Node(),
@@ -106,10 +106,10 @@ func runStep(ctx *context.Context, scenario *schema.Scenario, s *schema.Step, st
 		return ctx
 	}
 
-	return invokeAndAssert(ctx, s, stepIdx)
+	return invokeAndAssert(ctx, s, path)
 }
 
-func invokeAndAssert(ctx *context.Context, s *schema.Step, stepIdx int) *context.Context {
+func invokeAndAssert(ctx *context.Context, s *schema.Step, path string) *context.Context {
 	reqTime := time.Now()
 	newCtx, resp, err := s.Request.Invoke(ctx)
 	ctx.Reporter().Logf("elapsed time: %f sec", time.Since(reqTime).Seconds())
@@ -117,7 +117,7 @@ func invokeAndAssert(ctx *context.Context, s *schema.Step, stepIdx int) *context
 	if err != nil {
 		ctx.Reporter().Fatal(
 			errors.WithNodeAndColored(
-				errors.WithPath(err, fmt.Sprintf("steps[%d].request", stepIdx)),
+				errors.WithPath(err, path+".request"),
 				ctx.Node(),
 				ctx.EnabledColor(),
 			),
@@ -127,7 +127,7 @@ func invokeAndAssert(ctx *context.Context, s *schema.Step, stepIdx int) *context
 	if err != nil {
 		ctx.Reporter().Fatal(
 			errors.WithNodeAndColored(
-				errors.WithPath(err, fmt.Sprintf("steps[%d].expect", stepIdx)),
+				errors.WithPath(err, path+".expect"),
 				ctx.Node(),
 				ctx.EnabledColor(),
 			),
@@ -135,7 +135,7 @@ func invokeAndAssert(ctx *context.Context, s *schema.Step, stepIdx int) *context
 	}
 	if err := assertion.Assert(resp); err != nil {
 		err = errors.WithNodeAndColored(
-			errors.WithPath(err, fmt.Sprintf("steps[%d].expect", stepIdx)),
+			errors.WithPath(err, path+".expect"),
 			ctx.Node(),
 			ctx.EnabledColor(),
 		)
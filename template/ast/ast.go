@@ -106,6 +106,13 @@ type (
 		Arg     Expr
 	}
 
+	// FuncLit node represents a function literal like "x => x.id".
+	FuncLit struct {
+		Params []*Ident
+		Arrow  int
+		Body   Expr
+	}
+
 	// DefinedExpr node represents a defined() expression.
 	DefinedExpr struct {
 		DefinedPos int
@@ -129,6 +136,7 @@ func (e *IndexExpr) Pos() int       { return e.Lbrack }
 func (e *CallExpr) Pos() int        { return e.Lparen }
 func (e *LeftArrowExpr) Pos() int   { return e.Larrow }
 func (e *DefinedExpr) Pos() int     { return e.DefinedPos }
+func (e *FuncLit) Pos() int         { return e.Arrow }
 
 // exprNode implements Expr.
 func (e *BadExpr) exprNode()         {}
@@ -144,3 +152,4 @@ func (e *IndexExpr) exprNode()       {}
 func (e *LeftArrowExpr) exprNode()   {}
 func (e *DefinedExpr) exprNode()     {}
 func (e *CallExpr) exprNode()        {}
+func (e *FuncLit) exprNode()         {}
@@ -2,6 +2,8 @@ package template
 
 import (
 	"fmt"
+	"reflect"
+	"time"
 
 	"github.com/zoncoen/scenarigo/template/val"
 )
@@ -10,6 +12,57 @@ var functions = map[string]any{
 	"size": size,
 }
 
+// libraryFunctions are the built-in functions which are looked up after the data.
+// So the data can define variables that have the same names.
+var libraryFunctions = map[string]any{
+	// string functions
+	"upper":      upper,
+	"lower":      lower,
+	"trim":       trim,
+	"trimPrefix": trimPrefix,
+	"trimSuffix": trimSuffix,
+	"split":      split,
+	"join":       join,
+	"replace":    replace,
+	"format":     format,
+
+	// encoding functions
+	"base64Encode":    base64Encode,
+	"base64Decode":    base64Decode,
+	"base64URLEncode": base64URLEncode,
+	"base64URLDecode": base64URLDecode,
+	"urlEncode":       urlEncode,
+	"urlDecode":       urlDecode,
+	"hexEncode":       hexEncode,
+	"hexDecode":       hexDecode,
+	"jsonEncode":      jsonEncode,
+	"jsonDecode":      jsonDecode,
+
+	// hash functions
+	"sha256":     sha256Sum,
+	"hmacSHA256": hmacSHA256,
+
+	// random functions
+	"uuid":         newUUID,
+	"randomInt":    randomInt,
+	"randomString": randomString,
+
+	// collection functions
+	// "any" is provided as an overload of the type conversion function.
+	"map":    mapFunc,
+	"filter": filter,
+	"all":    all,
+	"find":   find,
+	"sort":   sortFunc,
+
+	// time functions
+	"now":        now,
+	"parseTime":  parseTime,
+	"formatTime": formatTime,
+	"addTime":    addTime,
+	"addDate":    addDate,
+}
+
 func size(in any) (any, error) {
 	v := val.NewValue(in)
 	if s, ok := v.(val.Sizer); ok {
@@ -23,3 +76,103 @@ func size(in any) (any, error) {
 	}
 	return nil, fmt.Errorf("size(%s) is not defined", v.Type().Name())
 }
+
+// argument is an argument of a function to report the position in errors.
+type argument struct {
+	fn  string
+	idx string
+	v   val.Value
+}
+
+func arg(fn string, idx int, in any) *argument {
+	return &argument{
+		fn:  fn,
+		idx: fmt.Sprintf("[%d]", idx),
+		v:   val.NewValue(in),
+	}
+}
+
+func (a *argument) elem(idx int, in any) *argument {
+	return &argument{
+		fn:  a.fn,
+		idx: fmt.Sprintf("%s[%d]", a.idx, idx),
+		v:   val.NewValue(in),
+	}
+}
+
+func (a *argument) errorf(expect string) error {
+	return fmt.Errorf("can't use %s as %s in arguments%s to %s", typeValue(a.v), expect, a.idx, a.fn)
+}
+
+func (a *argument) string() (string, error) {
+	if s, ok := a.v.(val.String); ok {
+		return string(s), nil
+	}
+	return "", a.errorf("string")
+}
+
+// bytes returns the argument as bytes.
+// A string is also accepted as its UTF-8 bytes.
+func (a *argument) bytes() ([]byte, error) {
+	switch v := a.v.(type) {
+	case val.Bytes:
+		return []byte(v), nil
+	case val.String:
+		return []byte(v), nil
+	}
+	return nil, a.errorf("string or bytes")
+}
+
+func (a *argument) int() (int64, error) {
+	switch v := a.v.(type) {
+	case val.Int:
+		return int64(v), nil
+	case val.Uint:
+		if i, err := val.GetType("int").Convert(v); err == nil {
+			if i, ok := i.(val.Int); ok {
+				return int64(i), nil
+			}
+		}
+	}
+	return 0, a.errorf("int")
+}
+
+func (a *argument) list() ([]any, error) {
+	rv := reflect.ValueOf(a.v.GoValue())
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, a.errorf("list")
+	}
+	list := make([]any, rv.Len())
+	for i := range list {
+		list[i] = rv.Index(i).Interface()
+	}
+	return list, nil
+}
+
+// time returns the argument as time.
+// A string is also accepted if it is formatted in RFC3339.
+func (a *argument) time() (time.Time, error) {
+	switch a.v.(type) {
+	case val.Time, val.String:
+		if v, err := val.GetType("time").Convert(a.v); err == nil {
+			if t, ok := v.GoValue().(time.Time); ok {
+				return t, nil
+			}
+		}
+	}
+	return time.Time{}, a.errorf("time")
+}
+
+// duration returns the argument as duration.
+// A string is also accepted if it is a duration string such as "1h30m".
+func (a *argument) duration() (time.Duration, error) {
+	switch a.v.(type) {
+	case val.Duration, val.String:
+		if v, err := val.GetType("duration").Convert(a.v); err == nil {
+			if d, ok := v.GoValue().(time.Duration); ok {
+				return d, nil
+			}
+		}
+	}
+	return 0, a.errorf("duration")
+}
@@ -0,0 +1,274 @@
+package template
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/zoncoen/scenarigo/template/val"
+)
+
+//nolint:exhaustruct
+var yamlMapSliceType = reflect.TypeOf(yaml.MapSlice{})
+
+// collection is a list or map to iterate over.
+// The keys are the indexes of the list or the keys of the map.
+type collection struct {
+	keys    []any
+	values  []any
+	keyType reflect.Type // nil if the collection is a list
+	ordered bool         // true if the collection is an ordered map
+}
+
+// collection returns the argument as a list or map.
+// The entries of the map are sorted by the keys unless it is an ordered map.
+func (a *argument) collection() (*collection, error) {
+	rv := reflect.ValueOf(a.v.GoValue())
+	switch {
+	case rv.IsValid() && rv.Type() == yamlMapSliceType:
+		c := &collection{
+			keyType: rv.Type(),
+			ordered: true,
+		}
+		for _, item := range rv.Interface().(yaml.MapSlice) { //nolint:forcetypeassert
+			c.keys = append(c.keys, item.Key)
+			c.values = append(c.values, item.Value)
+		}
+		return c, nil
+	case rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array:
+		if _, ok := a.v.(val.Bytes); ok {
+			break
+		}
+		c := &collection{
+			keys:   make([]any, rv.Len()),
+			values: make([]any, rv.Len()),
+		}
+		for i := range rv.Len() {
+			c.keys[i] = i
+			c.values[i] = rv.Index(i).Interface()
+		}
+		return c, nil
+	case rv.Kind() == reflect.Map:
+		keys := rv.MapKeys()
+		sort.SliceStable(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		c := &collection{
+			keys:    make([]any, len(keys)),
+			values:  make([]any, len(keys)),
+			keyType: rv.Type().Key(),
+		}
+		for i, k := range keys {
+			c.keys[i] = k.Interface()
+			c.values[i] = rv.MapIndex(k).Interface()
+		}
+		return c, nil
+	}
+	return nil, a.errorf("list or map")
+}
+
+// new returns a new collection of the same kind that has the given entries.
+func (c *collection) new(keys, values []any) any {
+	switch {
+	case c.keyType == nil:
+		return values
+	case c.ordered:
+		m := make(yaml.MapSlice, len(keys))
+		for i, k := range keys {
+			m[i] = yaml.MapItem{Key: k, Value: values[i]}
+		}
+		return m
+	default:
+		m := reflect.MakeMapWithSize(reflect.MapOf(c.keyType, reflect.TypeOf((*any)(nil)).Elem()), len(keys))
+		for i, k := range keys {
+			v := reflect.New(m.Type().Elem()).Elem()
+			if values[i] != nil {
+				v.Set(reflect.ValueOf(values[i]))
+			}
+			m.SetMapIndex(reflect.ValueOf(k), v)
+		}
+		return m.Interface()
+	}
+}
+
+// lambda returns the argument as a function literal.
+func (a *argument) lambda() (*lambda, error) {
+	if f, ok := a.v.GoValue().(*lambda); ok {
+		return f, nil
+	}
+	return nil, a.errorf("function")
+}
+
+// iterate calls the function f with the value and key of each entry of the collection.
+// It stops if yield returns false.
+func iterate(fn string, in, f any, yield func(k, v, result any) (bool, error)) (*collection, error) {
+	c, err := arg(fn, 0, in).collection()
+	if err != nil {
+		return nil, err
+	}
+	l, err := arg(fn, 1, f).lambda()
+	if err != nil {
+		return nil, err
+	}
+	for i, v := range c.values {
+		res, err := l.apply(v, c.keys[i])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", fn, err)
+		}
+		ok, err := yield(c.keys[i], v, res)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+	}
+	return c, nil
+}
+
+func predicate(fn string, res any) (bool, error) {
+	if b, ok := val.NewValue(res).(val.Bool); ok {
+		return bool(b), nil
+	}
+	return false, fmt.Errorf("%s: function must return bool but returned %s", fn, typeValue(val.NewValue(res)))
+}
+
+func mapFunc(in, f any) (any, error) {
+	values := []any{}
+	c, err := iterate("map", in, f, func(_, _, res any) (bool, error) {
+		values = append(values, res)
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c.new(c.keys, values), nil
+}
+
+func filter(in, f any) (any, error) {
+	keys, values := []any{}, []any{}
+	c, err := iterate("filter", in, f, func(k, v, res any) (bool, error) {
+		ok, err := predicate("filter", res)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			keys = append(keys, k)
+			values = append(values, v)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c.new(keys, values), nil
+}
+
+func anyFunc(in, f any) (any, error) {
+	var found bool
+	if _, err := iterate("any", in, f, func(_, _, res any) (bool, error) {
+		ok, err := predicate("any", res)
+		if err != nil {
+			return false, err
+		}
+		found = ok
+		return !ok, nil
+	}); err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+func all(in, f any) (any, error) {
+	matched := true
+	if _, err := iterate("all", in, f, func(_, _, res any) (bool, error) {
+		ok, err := predicate("all", res)
+		if err != nil {
+			return false, err
+		}
+		matched = ok
+		return ok, nil
+	}); err != nil {
+		return nil, err
+	}
+	return matched, nil
+}
+
+// find returns the first value that satisfies the function.
+// It returns nil if not found.
+func find(in, f any) (any, error) {
+	var found any
+	if _, err := iterate("find", in, f, func(_, v, res any) (bool, error) {
+		ok, err := predicate("find", res)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			found = v
+		}
+		return !ok, nil
+	}); err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// sortFunc returns the values of the collection in ascending order.
+// If the function is specified, the values are sorted by its results.
+func sortFunc(in any, f ...any) (any, error) {
+	var (
+		c   *collection
+		err error
+	)
+	keys := []any{}
+	switch len(f) {
+	case 0:
+		c, err = arg("sort", 0, in).collection()
+		if err != nil {
+			return nil, err
+		}
+		keys = c.values
+	case 1:
+		c, err = iterate("sort", in, f[0], func(_, _, res any) (bool, error) {
+			keys = append(keys, res)
+			return true, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("too many arguments to sort")
+	}
+	idxs := make([]int, len(c.values))
+	for i := range idxs {
+		idxs[i] = i
+	}
+	var cmpErr error
+	sort.SliceStable(idxs, func(i, j int) bool {
+		less, err := lessThan(keys[idxs[i]], keys[idxs[j]])
+		if err != nil && cmpErr == nil {
+			cmpErr = fmt.Errorf("sort: %w", err)
+		}
+		return less
+	})
+	if cmpErr != nil {
+		return nil, cmpErr
+	}
+	values := make([]any, len(idxs))
+	for i, idx := range idxs {
+		values[i] = c.values[idx]
+	}
+	return values, nil
+}
+
+func lessThan(x, y any) (bool, error) {
+	xv, yv := val.NewValue(x), val.NewValue(y)
+	if c, ok := xv.(val.Comparer); ok {
+		if v, err := c.Compare(yv); err == nil {
+			return v == val.Int(-1), nil
+		}
+	}
+	return false, fmt.Errorf("%s < %s not defined", typeValue(xv), typeValue(yv))
+}
@@ -0,0 +1,167 @@
+package template
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+func base64Encode(b any) (any, error) {
+	data, err := arg("base64Encode", 0, b).bytes()
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+func base64Decode(s any) (any, error) {
+	str, err := arg("base64Decode", 0, s).string()
+	if err != nil {
+		return nil, err
+	}
+	b, err := base64.StdEncoding.DecodeString(str)
+	if err != nil {
+		return nil, fmt.Errorf("base64Decode: %w", err)
+	}
+	return b, nil
+}
+
+// base64URLEncode encodes the argument with the unpadded URL-safe alphabet, as JWT does.
+func base64URLEncode(b any) (any, error) {
+	data, err := arg("base64URLEncode", 0, b).bytes()
+	if err != nil {
+		return nil, err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func base64URLDecode(s any) (any, error) {
+	str, err := arg("base64URLDecode", 0, s).string()
+	if err != nil {
+		return nil, err
+	}
+	// accept both padded and unpadded strings
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(str, "="))
+	if err != nil {
+		return nil, fmt.Errorf("base64URLDecode: %w", err)
+	}
+	return b, nil
+}
+
+func urlEncode(s any) (any, error) {
+	str, err := arg("urlEncode", 0, s).string()
+	if err != nil {
+		return nil, err
+	}
+	return url.QueryEscape(str), nil
+}
+
+func urlDecode(s any) (any, error) {
+	str, err := arg("urlDecode", 0, s).string()
+	if err != nil {
+		return nil, err
+	}
+	decoded, err := url.QueryUnescape(str)
+	if err != nil {
+		return nil, fmt.Errorf("urlDecode: %w", err)
+	}
+	return decoded, nil
+}
+
+func hexEncode(b any) (any, error) {
+	data, err := arg("hexEncode", 0, b).bytes()
+	if err != nil {
+		return nil, err
+	}
+	return hex.EncodeToString(data), nil
+}
+
+func hexDecode(s any) (any, error) {
+	str, err := arg("hexDecode", 0, s).string()
+	if err != nil {
+		return nil, err
+	}
+	b, err := hex.DecodeString(str)
+	if err != nil {
+		return nil, fmt.Errorf("hexDecode: %w", err)
+	}
+	return b, nil
+}
+
+func jsonEncode(v any) (any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("jsonEncode: %w", err)
+	}
+	return string(b), nil
+}
+
+// jsonDecode decodes a JSON text.
+// The numbers are decoded as int if possible, otherwise float.
+func jsonDecode(s any) (any, error) {
+	data, err := arg("jsonDecode", 0, s).bytes()
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("jsonDecode: %w", err)
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("jsonDecode: invalid character after top-level value")
+	}
+	return convertJSONNumbers(v), nil
+}
+
+func convertJSONNumbers(v any) any {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	case map[string]any:
+		for k, e := range v {
+			v[k] = convertJSONNumbers(e)
+		}
+	case []any:
+		for i, e := range v {
+			v[i] = convertJSONNumbers(e)
+		}
+	}
+	return v
+}
+
+func sha256Sum(b any) (any, error) {
+	data, err := arg("sha256", 0, b).bytes()
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	return sum[:], nil
+}
+
+func hmacSHA256(key, b any) (any, error) {
+	k, err := arg("hmacSHA256", 0, key).bytes()
+	if err != nil {
+		return nil, err
+	}
+	data, err := arg("hmacSHA256", 1, b).bytes()
+	if err != nil {
+		return nil, err
+	}
+	mac := hmac.New(sha256.New, k)
+	mac.Write(data)
+	return mac.Sum(nil), nil
+}
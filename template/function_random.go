@@ -0,0 +1,51 @@
+package template
+
+import (
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/google/uuid"
+)
+
+const randomStringLetters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// newUUID returns a random (version 4) UUID string.
+func newUUID() (any, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, fmt.Errorf("uuid: %w", err)
+	}
+	return id.String(), nil
+}
+
+// randomInt returns a random integer in [minimum, maximum).
+func randomInt(minimum, maximum any) (any, error) {
+	lo, err := arg("randomInt", 0, minimum).int()
+	if err != nil {
+		return nil, err
+	}
+	hi, err := arg("randomInt", 1, maximum).int()
+	if err != nil {
+		return nil, err
+	}
+	if hi <= lo {
+		return nil, fmt.Errorf("randomInt: maximum %d must be greater than minimum %d", hi, lo)
+	}
+	return lo + rand.Int64N(hi-lo), nil //nolint:gosec
+}
+
+// randomString returns a random alphanumeric string of length n.
+func randomString(n any) (any, error) {
+	l, err := arg("randomString", 0, n).int()
+	if err != nil {
+		return nil, err
+	}
+	if l < 0 {
+		return nil, fmt.Errorf("randomString: length must not be negative but got %d", l)
+	}
+	b := make([]byte, l)
+	for i := range b {
+		b[i] = randomStringLetters[rand.IntN(len(randomStringLetters))] //nolint:gosec
+	}
+	return string(b), nil
+}
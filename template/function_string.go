@@ -0,0 +1,117 @@
+package template
+
+import (
+	"fmt"
+	"strings"
+)
+
+func upper(s any) (any, error) {
+	str, err := arg("upper", 0, s).string()
+	if err != nil {
+		return nil, err
+	}
+	return strings.ToUpper(str), nil
+}
+
+func lower(s any) (any, error) {
+	str, err := arg("lower", 0, s).string()
+	if err != nil {
+		return nil, err
+	}
+	return strings.ToLower(str), nil
+}
+
+func trim(s any) (any, error) {
+	str, err := arg("trim", 0, s).string()
+	if err != nil {
+		return nil, err
+	}
+	return strings.TrimSpace(str), nil
+}
+
+func trimPrefix(s, prefix any) (any, error) {
+	str, err := arg("trimPrefix", 0, s).string()
+	if err != nil {
+		return nil, err
+	}
+	p, err := arg("trimPrefix", 1, prefix).string()
+	if err != nil {
+		return nil, err
+	}
+	return strings.TrimPrefix(str, p), nil
+}
+
+func trimSuffix(s, suffix any) (any, error) {
+	str, err := arg("trimSuffix", 0, s).string()
+	if err != nil {
+		return nil, err
+	}
+	p, err := arg("trimSuffix", 1, suffix).string()
+	if err != nil {
+		return nil, err
+	}
+	return strings.TrimSuffix(str, p), nil
+}
+
+func split(s, sep any) (any, error) {
+	str, err := arg("split", 0, s).string()
+	if err != nil {
+		return nil, err
+	}
+	sp, err := arg("split", 1, sep).string()
+	if err != nil {
+		return nil, err
+	}
+	strs := strings.Split(str, sp)
+	list := make([]any, len(strs))
+	for i, s := range strs {
+		list[i] = s
+	}
+	return list, nil
+}
+
+func join(list, sep any) (any, error) {
+	a := arg("join", 0, list)
+	elems, err := a.list()
+	if err != nil {
+		return nil, err
+	}
+	strs := make([]string, len(elems))
+	for i, e := range elems {
+		s, err := a.elem(i, e).string()
+		if err != nil {
+			return nil, err
+		}
+		strs[i] = s
+	}
+	sp, err := arg("join", 1, sep).string()
+	if err != nil {
+		return nil, err
+	}
+	return strings.Join(strs, sp), nil
+}
+
+func replace(s, old, new any) (any, error) { //nolint:predeclared
+	str, err := arg("replace", 0, s).string()
+	if err != nil {
+		return nil, err
+	}
+	o, err := arg("replace", 1, old).string()
+	if err != nil {
+		return nil, err
+	}
+	n, err := arg("replace", 2, new).string()
+	if err != nil {
+		return nil, err
+	}
+	return strings.ReplaceAll(str, o, n), nil
+}
+
+// format formats args according to a format specifier like fmt.Sprintf.
+func format(f any, args ...any) (any, error) {
+	str, err := arg("format", 0, f).string()
+	if err != nil {
+		return nil, err
+	}
+	return fmt.Sprintf(str, args...), nil
+}
@@ -0,0 +1,548 @@
+package template
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-yaml"
+)
+
+func TestStringFunctions(t *testing.T) {
+	runExecute(t, map[string]executeTestCase{
+		"upper": {
+			str:    `{{upper("foo")}}`,
+			expect: "FOO",
+		},
+		"lower": {
+			str:    `{{lower("FOO")}}`,
+			expect: "foo",
+		},
+		"trim": {
+			str:    `{{trim("  foo ")}}`,
+			expect: "foo",
+		},
+		"trimPrefix": {
+			str:    `{{trimPrefix("Bearer xxx", "Bearer ")}}`,
+			expect: "xxx",
+		},
+		"trimSuffix": {
+			str:    `{{trimSuffix("foo.yaml", ".yaml")}}`,
+			expect: "foo",
+		},
+		"split": {
+			str:    `{{split("a,b,c", ",")}}`,
+			expect: []any{"a", "b", "c"},
+		},
+		"join": {
+			str: `{{join(v, "-")}}`,
+			data: map[string]any{
+				"v": []string{"a", "b", "c"},
+			},
+			expect: "a-b-c",
+		},
+		"split and join": {
+			str:    `{{join(split("a,b,c", ","), "")}}`,
+			expect: "abc",
+		},
+		"replace": {
+			str:    `{{replace("a-b-c", "-", "_")}}`,
+			expect: "a_b_c",
+		},
+		"format": {
+			str:    `{{format("%s-%03d", "id", 7)}}`,
+			expect: "id-007",
+		},
+		"format without args": {
+			str:    `{{format("100%%")}}`,
+			expect: "100%",
+		},
+		"data can shadow functions": {
+			str: `{{upper}}`,
+			data: map[string]any{
+				"upper": "data",
+			},
+			expect: "data",
+		},
+		"upper: invalid argument": {
+			str:         `{{upper(1)}}`,
+			expectError: "failed to execute: {{upper(1)}}: can't use int(1) as string in arguments[0] to upper",
+		},
+		"replace: invalid argument": {
+			str:         `{{replace("a", "b", true)}}`,
+			expectError: "can't use bool(true) as string in arguments[2] to replace",
+		},
+		"join: invalid list": {
+			str:         `{{join("a", ",")}}`,
+			expectError: `can't use string(a) as list in arguments[0] to join`,
+		},
+		"join: invalid element": {
+			str: `{{join(v, ",")}}`,
+			data: map[string]any{
+				"v": []any{"a", 1},
+			},
+			expectError: "can't use int(1) as string in arguments[0][1] to join",
+		},
+		"format: invalid argument": {
+			str: `{{format(v)}}`,
+			data: map[string]any{
+				"v": nil,
+			},
+			expectError: "can't use nil as string in arguments[0] to format",
+		},
+	})
+}
+
+func TestCollectionFunctions(t *testing.T) {
+	items := map[string]any{
+		"items": []any{
+			map[string]any{"id": int64(1), "name": "foo", "active": true},
+			map[string]any{"id": int64(2), "name": "bar", "active": false},
+			map[string]any{"id": int64(3), "name": "baz", "active": true},
+		},
+		"scores": map[string]any{
+			"alice": int64(80),
+			"bob":   int64(60),
+		},
+		"ordered": yaml.MapSlice{
+			{Key: "b", Value: int64(2)},
+			{Key: "a", Value: int64(1)},
+		},
+		"id": int64(3),
+	}
+	runExecute(t, map[string]executeTestCase{
+		"call function literal": {
+			str:    `{{(x => x + 1)(1)}}`,
+			expect: int64(2),
+		},
+		"call function literal without parameters": {
+			str:    `{{(() => "foo")()}}`,
+			expect: "foo",
+		},
+		"call function literal with variable": {
+			str:    `{{(x => x + id)(1)}}`,
+			data:   items,
+			expect: int64(4),
+		},
+		"call function defined in data": {
+			str: `{{vars.inc(1)}}`,
+			data: map[string]any{
+				"vars": map[string]any{
+					"inc": "{{x => x + 1}}",
+				},
+			},
+			expect: int64(2),
+		},
+		"any as type conversion": {
+			str:    `{{any(1)}}`,
+			expect: int64(1),
+		},
+		"parameter shadows variable": {
+			str:    `{{(id => id)(1)}}`,
+			data:   items,
+			expect: int64(1),
+		},
+		"map": {
+			str:    `{{map(items, x => x.name)}}`,
+			data:   items,
+			expect: []any{"foo", "bar", "baz"},
+		},
+		"map with index": {
+			str:    `{{map(items, (x, i) => i)}}`,
+			data:   items,
+			expect: []any{0, 1, 2},
+		},
+		"map over map": {
+			str:  `{{map(scores, (v, k) => k + ":" + string(v))}}`,
+			data: items,
+			expect: map[string]any{
+				"alice": "alice:80",
+				"bob":   "bob:60",
+			},
+		},
+		"map over ordered map": {
+			str:  `{{map(ordered, v => v * 10)}}`,
+			data: items,
+			expect: yaml.MapSlice{
+				{Key: "b", Value: int64(20)},
+				{Key: "a", Value: int64(10)},
+			},
+		},
+		"filter": {
+			str:  `{{filter(items, x => x.active)}}`,
+			data: items,
+			expect: []any{
+				map[string]any{"id": int64(1), "name": "foo", "active": true},
+				map[string]any{"id": int64(3), "name": "baz", "active": true},
+			},
+		},
+		"filter: not matched": {
+			str:    `{{filter(items, x => x.id > 3)}}`,
+			data:   items,
+			expect: []any{},
+		},
+		"filter over map": {
+			str:  `{{filter(scores, v => v >= 70)}}`,
+			data: items,
+			expect: map[string]any{
+				"alice": int64(80),
+			},
+		},
+		"any": {
+			str:    `{{any(items, x => x.name == "bar")}}`,
+			data:   items,
+			expect: true,
+		},
+		"any: not matched": {
+			str:    `{{any(items, x => x.name == "qux")}}`,
+			data:   items,
+			expect: false,
+		},
+		"all": {
+			str:    `{{all(items, x => x.id > 0)}}`,
+			data:   items,
+			expect: true,
+		},
+		"all: not matched": {
+			str:    `{{all(items, x => x.active)}}`,
+			data:   items,
+			expect: false,
+		},
+		"find": {
+			str:    `{{find(items, x => x.id == id)}}`,
+			data:   items,
+			expect: map[string]any{"id": int64(3), "name": "baz", "active": true},
+		},
+		"find: not found": {
+			str:    `{{find(items, x => x.id == 4) ?? "none"}}`,
+			data:   items,
+			expect: "none",
+		},
+		"find and select": {
+			str:    `{{find(items, x => x.id == id).name}}`,
+			data:   items,
+			expect: "baz",
+		},
+		"find and select: not found": {
+			str:    `{{find(items, x => x.id == 4).name ?? "none"}}`,
+			data:   items,
+			expect: "none",
+		},
+		"filter and index": {
+			str:    `{{filter(items, x => !x.active)[0].name}}`,
+			data:   items,
+			expect: "bar",
+		},
+		"sort": {
+			str:    `{{sort(split("b,c,a", ","))}}`,
+			expect: []any{"a", "b", "c"},
+		},
+		"sort by key": {
+			str:    `{{map(sort(items, x => x.name), x => x.id)}}`,
+			data:   items,
+			expect: []any{int64(2), int64(3), int64(1)},
+		},
+		"sort map": {
+			str:    `{{sort(scores)}}`,
+			data:   items,
+			expect: []any{int64(60), int64(80)},
+		},
+		"nested function literals": {
+			str:    `{{size(filter(items, x => any(split("foo,baz", ","), n => n == x.name)))}}`,
+			data:   items,
+			expect: int64(2),
+		},
+		"map: invalid collection": {
+			str:         `{{map("a", x => x)}}`,
+			expectError: "can't use string(a) as list or map in arguments[0] to map",
+		},
+		"map: invalid function": {
+			str:         `{{map(items, 1)}}`,
+			data:        items,
+			expectError: "can't use int(1) as function in arguments[1] to map",
+		},
+		"filter: not bool": {
+			str:         `{{filter(items, x => x.name)}}`,
+			data:        items,
+			expectError: "filter: function must return bool but returned string(foo)",
+		},
+		"map: too many parameters": {
+			str:         `{{map(items, (x, i, j) => x)}}`,
+			data:        items,
+			expectError: "map: too many parameters of function: expected maximum parameter number is 2 but specified 3 parameters",
+		},
+		"sort: not comparable": {
+			str:         `{{sort(items)}}`,
+			data:        items,
+			expectError: "sort: any[map[string]interface {}](",
+		},
+		"call function literal: invalid arguments": {
+			str:         `{{(x => x)(1, 2)}}`,
+			expectError: "expected function argument number is 1 but specified 2 arguments",
+		},
+	})
+}
+
+func TestEncodingFunctions(t *testing.T) {
+	runExecute(t, map[string]executeTestCase{
+		"base64Encode": {
+			str:    `{{base64Encode("user:pass")}}`,
+			expect: "dXNlcjpwYXNz",
+		},
+		"base64Encode bytes": {
+			str:    `{{base64Encode(bytes("user:pass"))}}`,
+			expect: "dXNlcjpwYXNz",
+		},
+		"base64Decode": {
+			str:    `{{string(base64Decode("dXNlcjpwYXNz"))}}`,
+			expect: "user:pass",
+		},
+		"base64URLEncode": {
+			str:    `{{base64URLEncode("??>")}}`,
+			expect: "Pz8-",
+		},
+		"base64URLDecode": {
+			str:    `{{string(base64URLDecode("Pz8-"))}}`,
+			expect: "??>",
+		},
+		"base64URLDecode with padding": {
+			str:    `{{string(base64URLDecode("YQ=="))}}`,
+			expect: "a",
+		},
+		"urlEncode": {
+			str:    `{{urlEncode("a b&c")}}`,
+			expect: "a+b%26c",
+		},
+		"urlDecode": {
+			str:    `{{urlDecode("a+b%26c")}}`,
+			expect: "a b&c",
+		},
+		"hexEncode": {
+			str:    `{{hexEncode("abc")}}`,
+			expect: "616263",
+		},
+		"hexDecode": {
+			str:    `{{hexDecode("616263")}}`,
+			expect: []byte("abc"),
+		},
+		"jsonEncode": {
+			str: `{{jsonEncode(v)}}`,
+			data: map[string]any{
+				"v": map[string]any{"id": 1, "tags": []string{"a"}},
+			},
+			expect: `{"id":1,"tags":["a"]}`,
+		},
+		"jsonDecode": {
+			str: `{{jsonDecode(v)}}`,
+			data: map[string]any{
+				"v": `{"id": 1, "score": 1.5, "tags": ["a"], "ok": true, "next": null}`,
+			},
+			expect: map[string]any{"id": int64(1), "score": 1.5, "tags": []any{"a"}, "ok": true, "next": nil},
+		},
+		"jsonDecode bytes": {
+			str: `{{jsonDecode(v)}}`,
+			data: map[string]any{
+				"v": []byte(`[1]`),
+			},
+			expect: []any{int64(1)},
+		},
+		"sha256": {
+			str:    `{{hexEncode(sha256("abc"))}}`,
+			expect: "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+		},
+		"hmacSHA256": {
+			str:    `{{hexEncode(hmacSHA256("key", "The quick brown fox jumps over the lazy dog"))}}`,
+			expect: "f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8",
+		},
+		"hmacSHA256 base64": {
+			str:    `{{base64Encode(hmacSHA256("key", "The quick brown fox jumps over the lazy dog"))}}`,
+			expect: "97yD9DBThCSxMpjmqm+xQ+9NWaFJRhdZl0edvC0aPNg=",
+		},
+		"base64Decode: invalid argument": {
+			str:         `{{base64Decode(1.5)}}`,
+			expectError: "can't use float(1.5) as string in arguments[0] to base64Decode",
+		},
+		"base64Decode: invalid data": {
+			str:         `{{base64Decode("!")}}`,
+			expectError: "base64Decode: illegal base64 data at input byte 0",
+		},
+		"hexDecode: invalid data": {
+			str:         `{{hexDecode("zz")}}`,
+			expectError: "hexDecode: encoding/hex: invalid byte",
+		},
+		"jsonDecode: invalid data": {
+			str:         `{{jsonDecode("[] []")}}`,
+			expectError: "jsonDecode: invalid character after top-level value",
+		},
+		"sha256: invalid argument": {
+			str:         `{{sha256(1)}}`,
+			expectError: "can't use int(1) as string or bytes in arguments[0] to sha256",
+		},
+	})
+}
+
+func TestRandomFunctions(t *testing.T) {
+	exec := func(t *testing.T, str string) any {
+		t.Helper()
+		v, err := Execute(context.Background(), str, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return v
+	}
+	t.Run("uuid", func(t *testing.T) {
+		v := exec(t, `{{uuid()}}`)
+		s, ok := v.(string)
+		if !ok {
+			t.Fatalf("expected string but got %T", v)
+		}
+		if !regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(s) {
+			t.Errorf("invalid UUID: %s", s)
+		}
+		if s == exec(t, `{{uuid()}}`) {
+			t.Error("UUIDs must be unique")
+		}
+	})
+	t.Run("randomInt", func(t *testing.T) {
+		for range 100 {
+			v := exec(t, `{{randomInt(-1, 2)}}`)
+			i, ok := v.(int64)
+			if !ok {
+				t.Fatalf("expected int64 but got %T", v)
+			}
+			if i < -1 || i >= 2 {
+				t.Fatalf("out of range: %d", i)
+			}
+		}
+	})
+	t.Run("randomString", func(t *testing.T) {
+		v := exec(t, `{{randomString(16)}}`)
+		s, ok := v.(string)
+		if !ok {
+			t.Fatalf("expected string but got %T", v)
+		}
+		if !regexp.MustCompile(`^[a-zA-Z0-9]{16}$`).MatchString(s) {
+			t.Errorf("invalid random string: %s", s)
+		}
+	})
+	runExecute(t, map[string]executeTestCase{
+		"uuid: too many arguments": {
+			str:         `{{uuid(1)}}`,
+			expectError: "expected function argument number is 0 but specified 1 arguments",
+		},
+		"randomInt: invalid range": {
+			str:         `{{randomInt(1, 1)}}`,
+			expectError: "randomInt: maximum 1 must be greater than minimum 1",
+		},
+		"randomInt: invalid argument": {
+			str:         `{{randomInt(0, "10")}}`,
+			expectError: "can't use string(10) as int in arguments[1] to randomInt",
+		},
+		"randomString: negative length": {
+			str:         `{{randomString(-1)}}`,
+			expectError: "randomString: length must not be negative but got -1",
+		},
+	})
+}
+
+func TestTimeFunctions(t *testing.T) {
+	tm := time.Date(2024, 2, 29, 12, 30, 0, 0, time.UTC)
+	t.Run("now", func(t *testing.T) {
+		before := time.Now()
+		v, err := Execute(context.Background(), `{{now()}}`, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		got, ok := v.(time.Time)
+		if !ok {
+			t.Fatalf("expected time.Time but got %T", v)
+		}
+		if got.Before(before) || got.After(time.Now()) {
+			t.Errorf("unexpected time: %s", got)
+		}
+	})
+	runExecute(t, map[string]executeTestCase{
+		"parseTime": {
+			str:    `{{parseTime("2024-02-29T12:30:00Z")}}`,
+			expect: tm,
+		},
+		"parseTime with named layout": {
+			str:    `{{parseTime("2024-02-29 12:30:00", "DateTime")}}`,
+			expect: tm,
+		},
+		"parseTime with layout": {
+			str:    `{{parseTime("29/02/2024 12:30", "02/01/2006 15:04")}}`,
+			expect: tm,
+		},
+		"formatTime": {
+			str: `{{formatTime(t)}}`,
+			data: map[string]any{
+				"t": tm,
+			},
+			expect: "2024-02-29T12:30:00Z",
+		},
+		"formatTime with named layout": {
+			str: `{{formatTime(t, "DateOnly")}}`,
+			data: map[string]any{
+				"t": tm,
+			},
+			expect: "2024-02-29",
+		},
+		"formatTime with string": {
+			str:    `{{formatTime("2024-02-29T12:30:00Z", "RFC1123")}}`,
+			expect: "Thu, 29 Feb 2024 12:30:00 UTC",
+		},
+		"addTime": {
+			str: `{{formatTime(addTime(t, "-1h30m"))}}`,
+			data: map[string]any{
+				"t": tm,
+			},
+			expect: "2024-02-29T11:00:00Z",
+		},
+		"addTime with duration": {
+			str: `{{addTime(t, duration("24h"))}}`,
+			data: map[string]any{
+				"t": tm,
+			},
+			expect: tm.Add(24 * time.Hour),
+		},
+		"addDate": {
+			str: `{{formatTime(addDate(t, 1, 0, 1), "DateOnly")}}`,
+			data: map[string]any{
+				"t": tm,
+			},
+			expect: "2025-03-02",
+		},
+		"compare with now": {
+			str:    `{{addTime(now(), "1h") > now()}}`,
+			expect: true,
+		},
+		"parseTime: invalid time": {
+			str:         `{{parseTime("2024-02-30T00:00:00Z")}}`,
+			expectError: `parseTime: parsing time "2024-02-30T00:00:00Z": day out of range`,
+		},
+		"parseTime: too many arguments": {
+			str:         `{{parseTime("", "RFC3339", "UTC")}}`,
+			expectError: "too many arguments to parseTime",
+		},
+		"formatTime: invalid argument": {
+			str:         `{{formatTime(1)}}`,
+			expectError: "can't use int(1) as time in arguments[0] to formatTime",
+		},
+		"addTime: invalid duration": {
+			str: `{{addTime(t, "1 hour")}}`,
+			data: map[string]any{
+				"t": tm,
+			},
+			expectError: "can't use string(1 hour) as duration in arguments[1] to addTime",
+		},
+		"addDate: invalid argument": {
+			str: `{{addDate(t, 1, 0, "1")}}`,
+			data: map[string]any{
+				"t": tm,
+			},
+			expectError: "can't use string(1) as int in arguments[3] to addDate",
+		},
+	})
+}
@@ -0,0 +1,108 @@
+package template
+
+import (
+	"fmt"
+	"time"
+)
+
+// timeLayouts are the named layouts which can be used in parseTime and formatTime.
+var timeLayouts = map[string]string{
+	"ANSIC":       time.ANSIC,
+	"UnixDate":    time.UnixDate,
+	"RubyDate":    time.RubyDate,
+	"RFC822":      time.RFC822,
+	"RFC822Z":     time.RFC822Z,
+	"RFC850":      time.RFC850,
+	"RFC1123":     time.RFC1123,
+	"RFC1123Z":    time.RFC1123Z,
+	"RFC3339":     time.RFC3339,
+	"RFC3339Nano": time.RFC3339Nano,
+	"Kitchen":     time.Kitchen,
+	"DateTime":    time.DateTime,
+	"DateOnly":    time.DateOnly,
+	"TimeOnly":    time.TimeOnly,
+}
+
+func now() any {
+	return time.Now()
+}
+
+// timeLayout returns the layout by the optional argument.
+// The default layout is RFC3339.
+func timeLayout(fn string, idx int, layout []any) (string, error) {
+	switch len(layout) {
+	case 0:
+		return time.RFC3339, nil
+	case 1:
+		l, err := arg(fn, idx, layout[0]).string()
+		if err != nil {
+			return "", err
+		}
+		if named, ok := timeLayouts[l]; ok {
+			return named, nil
+		}
+		return l, nil
+	default:
+		return "", fmt.Errorf("too many arguments to %s", fn)
+	}
+}
+
+func parseTime(s any, layout ...any) (any, error) {
+	str, err := arg("parseTime", 0, s).string()
+	if err != nil {
+		return nil, err
+	}
+	l, err := timeLayout("parseTime", 1, layout)
+	if err != nil {
+		return nil, err
+	}
+	t, err := time.Parse(l, str)
+	if err != nil {
+		return nil, fmt.Errorf("parseTime: %w", err)
+	}
+	return t, nil
+}
+
+func formatTime(t any, layout ...any) (any, error) {
+	tm, err := arg("formatTime", 0, t).time()
+	if err != nil {
+		return nil, err
+	}
+	l, err := timeLayout("formatTime", 1, layout)
+	if err != nil {
+		return nil, err
+	}
+	return tm.Format(l), nil
+}
+
+func addTime(t, d any) (any, error) {
+	tm, err := arg("addTime", 0, t).time()
+	if err != nil {
+		return nil, err
+	}
+	dur, err := arg("addTime", 1, d).duration()
+	if err != nil {
+		return nil, err
+	}
+	return tm.Add(dur), nil
+}
+
+func addDate(t, years, months, days any) (any, error) {
+	tm, err := arg("addDate", 0, t).time()
+	if err != nil {
+		return nil, err
+	}
+	y, err := arg("addDate", 1, years).int()
+	if err != nil {
+		return nil, err
+	}
+	m, err := arg("addDate", 2, months).int()
+	if err != nil {
+		return nil, err
+	}
+	d, err := arg("addDate", 3, days).int()
+	if err != nil {
+		return nil, err
+	}
+	return tm.AddDate(int(y), int(m), int(d)), nil
+}
@@ -0,0 +1,74 @@
+package template
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zoncoen/scenarigo/internal/queryutil"
+	"github.com/zoncoen/scenarigo/template/ast"
+)
+
+// lambda represents a function defined by a function literal like "x => x.id".
+type lambda struct {
+	params []string
+	data   any
+	// eval executes the body with the data that has the parameters.
+	eval func(data any) (any, error)
+}
+
+func (t *Template) executeFuncLit(ctx context.Context, e *ast.FuncLit, data any) (any, error) {
+	params := make([]string, len(e.Params))
+	for i, p := range e.Params {
+		params[i] = p.Name
+	}
+	return &lambda{
+		params: params,
+		data:   data,
+		eval: func(data any) (any, error) {
+			return t.executeExpr(ctx, e.Body, data)
+		},
+	}, nil
+}
+
+// Call calls the function with args.
+func (f *lambda) Call(args ...any) (any, error) {
+	if len(args) != len(f.params) {
+		return nil, fmt.Errorf("expected function argument number is %d but specified %d arguments", len(f.params), len(args))
+	}
+	s := &scope{
+		params: make(map[string]any, len(f.params)),
+		parent: f.data,
+	}
+	for i, p := range f.params {
+		s.params[p] = args[i]
+	}
+	return f.eval(s)
+}
+
+// apply calls the function with the leading arguments of args as many as the parameters.
+// It enables to omit the parameters which are not used such as the index of the element.
+func (f *lambda) apply(args ...any) (any, error) {
+	if len(f.params) > len(args) {
+		return nil, fmt.Errorf("too many parameters of function: expected maximum parameter number is %d but specified %d parameters", len(args), len(f.params))
+	}
+	return f.Call(args[:len(f.params)]...)
+}
+
+// scope is the data to execute the body of a function literal.
+// The parameters shadow the variables which have the same names.
+type scope struct {
+	params map[string]any
+	parent any
+}
+
+// ExtractByKey implements query.KeyExtractor interface.
+func (s *scope) ExtractByKey(key string) (any, bool) {
+	if v, ok := s.params[key]; ok {
+		return v, true
+	}
+	v, err := queryutil.New().Key(key).Extract(s.parent)
+	if err != nil {
+		return nil, false
+	}
+	return v, true
+}
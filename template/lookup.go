@@ -40,24 +40,79 @@ func extract(node ast.Node, data interface{}) (interface{}, error) {
 	}
 	v, err = q.Extract(data)
 	if err != nil {
+		if f, ferr := q.Extract(libraryFunctions); ferr == nil {
+			return f, nil
+		}
 		return nil, errNotDefined{err}
 	}
 	return v, nil
 }
 
+// lookupResult looks up the value from the result of the function call such as "f().x".
+func (t *Template) lookupResult(ctx context.Context, node ast.Node, call *ast.CallExpr, data interface{}) (interface{}, error) {
+	v, err := t.extractResult(ctx, node, call, data)
+	if err != nil {
+		return nil, err
+	}
+	return Execute(ctx, v, data)
+}
+
+func (t *Template) extractResult(ctx context.Context, node ast.Node, call *ast.CallExpr, data interface{}) (interface{}, error) {
+	v, err := t.executeFuncCall(ctx, call, data)
+	if err != nil {
+		return nil, err
+	}
+	q, err := buildQueryFrom(queryutil.New(), node, call)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create query from AST")
+	}
+	v, err = q.Extract(v)
+	if err != nil {
+		return nil, errNotDefined{err}
+	}
+	return v, nil
+}
+
+// queryRoot returns the root expression of the selectors and indexes.
+func queryRoot(node ast.Expr) ast.Expr {
+	switch n := node.(type) {
+	case *ast.SelectorExpr:
+		return queryRoot(n.X)
+	case *ast.IndexExpr:
+		return queryRoot(n.X)
+	}
+	return node
+}
+
 func buildQuery(q *query.Query, node ast.Node) (*query.Query, error) {
+	return buildQueryFrom(q, node, nil)
+}
+
+// buildQueryFrom builds the query to extract the value of node from the value of root.
+func buildQueryFrom(q *query.Query, node, root ast.Node) (*query.Query, error) {
+	if root != nil && node == root {
+		return q, nil
+	}
 	var err error
 	switch n := node.(type) {
 	case *ast.Ident:
 		return q.Key(n.Name), nil
 	case *ast.SelectorExpr:
-		q, err = buildQuery(q, n.X)
+		q, err = buildQueryFrom(q, n.X, root)
 		if err != nil {
 			return nil, err
 		}
 		return q.Key(n.Sel.Name), nil
 	case *ast.IndexExpr:
 		i, ok := n.Index.(*ast.BasicLit)
+		if ok && i.Kind == token.STRING {
+			// enable to access the keys which are not identifiers such as "#text"
+			q, err = buildQueryFrom(q, n.X, root)
+			if err != nil {
+				return nil, err
+			}
+			return q.Key(i.Value), nil
+		}
 		if !ok || i.Kind != token.INT {
 			return nil, errors.Errorf(`expected int but "%s"`, i.Kind.String())
 		}
@@ -65,7 +120,7 @@ func buildQuery(q *query.Query, node ast.Node) (*query.Query, error) {
 		if err != nil {
 			return nil, errors.Errorf(`expected int but "%s"`, i.Value)
 		}
-		q, err = buildQuery(q, n.X)
+		q, err = buildQueryFrom(q, n.X, root)
 		if err != nil {
 			return nil, err
 		}
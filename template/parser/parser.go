@@ -159,13 +159,45 @@ func (p *Parser) parseUnaryExpr() ast.Expr {
 	case token.LPAREN:
 		pos := p.pos
 		p.next()
+		x := p.parseExpr()
+		if p.tok == token.COMMA || x == nil {
+			// parameter list of a function literal
+			params := []ast.Expr{}
+			if x != nil {
+				params = append(params, x)
+			}
+			for p.tok == token.COMMA {
+				p.next()
+				params = append(params, p.parseExpr())
+			}
+			p.expect(token.RPAREN)
+			return p.parseFuncLit(params)
+		}
+		rparen := p.expect(token.RPAREN)
+		if p.tok == token.ARROW {
+			return p.parseFuncLit([]ast.Expr{x})
+		}
 		e = &ast.ParenExpr{
 			Lparen: pos,
-			X:      p.parseExpr(),
-			Rparen: p.expect(token.RPAREN),
+			X:      x,
+			Rparen: rparen,
+		}
+		// call of a parenthesized function literal like "(x => x + 1)(1)"
+		for p.tok == token.LPAREN {
+			lparen := p.pos
+			p.next()
+			e = &ast.CallExpr{
+				Fun:    e,
+				Lparen: lparen,
+				Args:   p.parseArgs(),
+				Rparen: p.expect(token.RPAREN),
+			}
 		}
 	case token.IDENT:
 		e = p.parseIdent()
+		if p.tok == token.ARROW {
+			return p.parseFuncLit([]ast.Expr{e})
+		}
 	L:
 		for {
 			switch p.tok {
@@ -247,6 +279,27 @@ func (p *Parser) parseParameter() ast.Expr {
 	return param
 }
 
+func (p *Parser) parseFuncLit(params []ast.Expr) ast.Expr {
+	idents := make([]*ast.Ident, 0, len(params))
+	for _, param := range params {
+		id, ok := param.(*ast.Ident)
+		if !ok {
+			pos := p.pos
+			if param != nil {
+				pos = param.Pos()
+			}
+			p.errorExpected(pos, "parameter name")
+			continue
+		}
+		idents = append(idents, id)
+	}
+	return &ast.FuncLit{
+		Params: idents,
+		Arrow:  p.expect(token.ARROW),
+		Body:   p.parseExpr(),
+	}
+}
+
 func (p *Parser) parseArgs() []ast.Expr {
 	args := []ast.Expr{}
 	if p.tok == token.RPAREN {
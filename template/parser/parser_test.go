@@ -733,6 +733,68 @@ func TestParser_Parse(t *testing.T) {
 					Rdbrace: 15,
 				},
 			},
+			"function literal": {
+				src: `{{filter(a, x => x.b)}}`,
+				expected: &ast.ParameterExpr{
+					Ldbrace: 1,
+					X: &ast.CallExpr{
+						Fun:    &ast.Ident{NamePos: 3, Name: "filter"},
+						Lparen: 9,
+						Args: []ast.Expr{
+							&ast.Ident{NamePos: 10, Name: "a"},
+							&ast.FuncLit{
+								Params: []*ast.Ident{
+									{NamePos: 13, Name: "x"},
+								},
+								Arrow: 15,
+								Body: &ast.SelectorExpr{
+									X:   &ast.Ident{NamePos: 18, Name: "x"},
+									Sel: &ast.Ident{NamePos: 20, Name: "b"},
+								},
+							},
+						},
+						Rparen: 21,
+					},
+					Rdbrace: 22,
+				},
+			},
+			"function literal with parameters": {
+				src: `{{(v, k) => k == "a" && v}}`,
+				expected: &ast.ParameterExpr{
+					Ldbrace: 1,
+					X: &ast.FuncLit{
+						Params: []*ast.Ident{
+							{NamePos: 4, Name: "v"},
+							{NamePos: 7, Name: "k"},
+						},
+						Arrow: 10,
+						Body: &ast.BinaryExpr{
+							X: &ast.BinaryExpr{
+								X:     &ast.Ident{NamePos: 13, Name: "k"},
+								OpPos: 15,
+								Op:    token.EQL,
+								Y:     &ast.BasicLit{ValuePos: 18, Kind: token.STRING, Value: "a"},
+							},
+							OpPos: 22,
+							Op:    token.LAND,
+							Y:     &ast.Ident{NamePos: 25, Name: "v"},
+						},
+					},
+					Rdbrace: 26,
+				},
+			},
+			"function literal without parameters": {
+				src: `{{() => 1}}`,
+				expected: &ast.ParameterExpr{
+					Ldbrace: 1,
+					X: &ast.FuncLit{
+						Params: []*ast.Ident{},
+						Arrow:  6,
+						Body:   &ast.BasicLit{ValuePos: 9, Kind: token.INT, Value: "1"},
+					},
+					Rdbrace: 10,
+				},
+			},
 			"expr with new-line-char": {
 				src: `
 {{foo(
@@ -822,6 +884,14 @@ func TestParser_Parse(t *testing.T) {
 				src: "{{a$}}",
 				pos: 4,
 			},
+			"invalid parameter": {
+				src: "{{(1) => 1}}",
+				pos: 4,
+			},
+			"=> not found": {
+				src: "{{(a, b)}}",
+				pos: 9,
+			},
 		}
 		for name, test := range tests {
 			test := test
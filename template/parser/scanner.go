@@ -286,8 +286,11 @@ func (s *scanner) scanToken() (int, token.Token, string) {
 		s.unread(next)
 	case '=':
 		next := s.read()
-		if next == '=' {
+		switch next {
+		case '=':
 			return s.pos - 2, token.EQL, "=="
+		case '>':
+			return s.pos - 2, token.ARROW, "=>"
 		}
 		s.unread(next)
 	case '!':
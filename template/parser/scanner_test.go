@@ -1014,6 +1014,36 @@ func TestScanner_Scan(t *testing.T) {
 					},
 				},
 			},
+			"=>": {
+				src: `{{x=>x}}`,
+				expected: []result{
+					{
+						pos: 1,
+						tok: token.LDBRACE,
+						lit: "{{",
+					},
+					{
+						pos: 3,
+						tok: token.IDENT,
+						lit: "x",
+					},
+					{
+						pos: 4,
+						tok: token.ARROW,
+						lit: "=>",
+					},
+					{
+						pos: 6,
+						tok: token.IDENT,
+						lit: "x",
+					},
+					{
+						pos: 7,
+						tok: token.RDBRACE,
+						lit: "}}",
+					},
+				},
+			},
 			"!": {
 				src: `{{!true}}`,
 				expected: []result{
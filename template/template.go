@@ -91,11 +91,13 @@ func (t *Template) executeExpr(ctx context.Context, expr ast.Expr, data interfac
 	case *ast.Ident:
 		return lookup(ctx, e, data)
 	case *ast.SelectorExpr:
-		return lookup(ctx, e, data)
+		return t.executeQueryExpr(ctx, e, data)
 	case *ast.IndexExpr:
-		return lookup(ctx, e, data)
+		return t.executeQueryExpr(ctx, e, data)
 	case *ast.CallExpr:
 		return t.executeFuncCall(ctx, e, data)
+	case *ast.FuncLit:
+		return t.executeFuncLit(ctx, e, data)
 	case *ast.LeftArrowExpr:
 		return t.executeLeftArrowExpr(ctx, e, data)
 	case *ast.DefinedExpr:
@@ -105,6 +107,13 @@ func (t *Template) executeExpr(ctx context.Context, expr ast.Expr, data interfac
 	}
 }
 
+func (t *Template) executeQueryExpr(ctx context.Context, e ast.Expr, data interface{}) (interface{}, error) {
+	if call, ok := queryRoot(e).(*ast.CallExpr); ok {
+		return t.lookupResult(ctx, e, call, data)
+	}
+	return lookup(ctx, e, data)
+}
+
 func (t *Template) executeBasicLit(lit *ast.BasicLit) (interface{}, error) {
 	switch lit.Kind {
 	case token.STRING:
@@ -343,7 +352,15 @@ func (t *Template) executeBinaryOperation(op token.Token, x, y val.Value, yExpr
 func (t *Template) executeCoalescingExpr(ctx context.Context, e *ast.BinaryExpr, data interface{}) (interface{}, error) {
 	switch e.X.(type) {
 	case *ast.Ident, *ast.SelectorExpr, *ast.IndexExpr:
-		extracted, err := extract(e.X, data)
+		var (
+			extracted interface{}
+			err       error
+		)
+		if call, ok := queryRoot(e.X).(*ast.CallExpr); ok {
+			extracted, err = t.extractResult(ctx, e.X, call, data)
+		} else {
+			extracted, err = extract(e.X, data)
+		}
 		if err != nil {
 			var notDefined errNotDefined
 			if errors.As(err, &notDefined) {
@@ -93,6 +93,15 @@ func TestTemplate_Execute(t *testing.T) {
 			},
 			expect: "ok",
 		},
+		"query from data by string index": {
+			str: `{{a["#b"][1]}}`,
+			data: map[string]map[string][]string{
+				"a": {
+					"#b": {"ng", "ok"},
+				},
+			},
+			expect: "ok",
+		},
 
 		"function call": {
 			str: `{{f("ok")}}`,
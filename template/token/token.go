@@ -43,6 +43,7 @@ const (
 	QUESTION  // ?
 	COLON     // :
 	LARROW    // <-
+	ARROW     // =>
 	CONCAT    // implicit concatenation
 	LINEBREAK // end of a larrow expression argument
 
@@ -120,6 +121,8 @@ func (t Token) String() string {
 		return ":"
 	case LARROW:
 		return "<-"
+	case ARROW:
+		return "=>"
 	case CONCAT:
 		return "implicitly concatenate"
 	case LINEBREAK:
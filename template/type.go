@@ -1,6 +1,10 @@
 package template
 
-import "github.com/zoncoen/scenarigo/template/val"
+import (
+	"fmt"
+
+	"github.com/zoncoen/scenarigo/template/val"
+)
 
 var typeFunctions typeFunctionExtractor
 
@@ -12,14 +16,31 @@ func (m typeFunctionExtractor) ExtractByKey(key string) (any, bool) {
 			return val.NewValue(in).Type().Name()
 		}, true
 	}
+	if key == "any" {
+		// any(collection, function) reports whether any element satisfies the function
+		return func(in any, f ...any) (any, error) {
+			switch len(f) {
+			case 0:
+				return convertType(val.GetType(key), in)
+			case 1:
+				return anyFunc(in, f[0])
+			default:
+				return nil, fmt.Errorf("too many arguments to any")
+			}
+		}, true
+	}
 	if t := val.GetType(key); t != nil {
 		return func(in any) (any, error) {
-			v, err := t.Convert(val.NewValue(in))
-			if err != nil {
-				return nil, err
-			}
-			return v.GoValue(), nil
+			return convertType(t, in)
 		}, true
 	}
 	return nil, false
 }
+
+func convertType(t val.Type, in any) (any, error) {
+	v, err := t.Convert(val.NewValue(in))
+	if err != nil {
+		return nil, err
+	}
+	return v.GoValue(), nil
+}
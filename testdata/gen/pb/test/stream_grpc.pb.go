@@ -0,0 +1,274 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.24.4
+// source: test/stream.proto
+
+package test
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	StreamTest_ServerStreamEcho_FullMethodName = "/scenarigo.testdata.test.StreamTest/ServerStreamEcho"
+	StreamTest_ClientStreamEcho_FullMethodName = "/scenarigo.testdata.test.StreamTest/ClientStreamEcho"
+	StreamTest_BidiStreamEcho_FullMethodName   = "/scenarigo.testdata.test.StreamTest/BidiStreamEcho"
+)
+
+// StreamTestClient is the client API for StreamTest service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type StreamTestClient interface {
+	ServerStreamEcho(ctx context.Context, in *EchoRequest, opts ...grpc.CallOption) (StreamTest_ServerStreamEchoClient, error)
+	ClientStreamEcho(ctx context.Context, opts ...grpc.CallOption) (StreamTest_ClientStreamEchoClient, error)
+	BidiStreamEcho(ctx context.Context, opts ...grpc.CallOption) (StreamTest_BidiStreamEchoClient, error)
+}
+
+type streamTestClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStreamTestClient(cc grpc.ClientConnInterface) StreamTestClient {
+	return &streamTestClient{cc}
+}
+
+func (c *streamTestClient) ServerStreamEcho(ctx context.Context, in *EchoRequest, opts ...grpc.CallOption) (StreamTest_ServerStreamEchoClient, error) {
+	stream, err := c.cc.NewStream(ctx, &StreamTest_ServiceDesc.Streams[0], StreamTest_ServerStreamEcho_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &streamTestServerStreamEchoClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StreamTest_ServerStreamEchoClient interface {
+	Recv() (*EchoResponse, error)
+	grpc.ClientStream
+}
+
+type streamTestServerStreamEchoClient struct {
+	grpc.ClientStream
+}
+
+func (x *streamTestServerStreamEchoClient) Recv() (*EchoResponse, error) {
+	m := new(EchoResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *streamTestClient) ClientStreamEcho(ctx context.Context, opts ...grpc.CallOption) (StreamTest_ClientStreamEchoClient, error) {
+	stream, err := c.cc.NewStream(ctx, &StreamTest_ServiceDesc.Streams[1], StreamTest_ClientStreamEcho_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &streamTestClientStreamEchoClient{stream}
+	return x, nil
+}
+
+type StreamTest_ClientStreamEchoClient interface {
+	Send(*EchoRequest) error
+	CloseAndRecv() (*EchoResponse, error)
+	grpc.ClientStream
+}
+
+type streamTestClientStreamEchoClient struct {
+	grpc.ClientStream
+}
+
+func (x *streamTestClientStreamEchoClient) Send(m *EchoRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *streamTestClientStreamEchoClient) CloseAndRecv() (*EchoResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(EchoResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *streamTestClient) BidiStreamEcho(ctx context.Context, opts ...grpc.CallOption) (StreamTest_BidiStreamEchoClient, error) {
+	stream, err := c.cc.NewStream(ctx, &StreamTest_ServiceDesc.Streams[2], StreamTest_BidiStreamEcho_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &streamTestBidiStreamEchoClient{stream}
+	return x, nil
+}
+
+type StreamTest_BidiStreamEchoClient interface {
+	Send(*EchoRequest) error
+	Recv() (*EchoResponse, error)
+	grpc.ClientStream
+}
+
+type streamTestBidiStreamEchoClient struct {
+	grpc.ClientStream
+}
+
+func (x *streamTestBidiStreamEchoClient) Send(m *EchoRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *streamTestBidiStreamEchoClient) Recv() (*EchoResponse, error) {
+	m := new(EchoResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// StreamTestServer is the server API for StreamTest service.
+// All implementations should embed UnimplementedStreamTestServer
+// for forward compatibility
+type StreamTestServer interface {
+	ServerStreamEcho(*EchoRequest, StreamTest_ServerStreamEchoServer) error
+	ClientStreamEcho(StreamTest_ClientStreamEchoServer) error
+	BidiStreamEcho(StreamTest_BidiStreamEchoServer) error
+}
+
+// UnimplementedStreamTestServer should be embedded to have forward compatible implementations.
+type UnimplementedStreamTestServer struct {
+}
+
+func (UnimplementedStreamTestServer) ServerStreamEcho(*EchoRequest, StreamTest_ServerStreamEchoServer) error {
+	return status.Errorf(codes.Unimplemented, "method ServerStreamEcho not implemented")
+}
+func (UnimplementedStreamTestServer) ClientStreamEcho(StreamTest_ClientStreamEchoServer) error {
+	return status.Errorf(codes.Unimplemented, "method ClientStreamEcho not implemented")
+}
+func (UnimplementedStreamTestServer) BidiStreamEcho(StreamTest_BidiStreamEchoServer) error {
+	return status.Errorf(codes.Unimplemented, "method BidiStreamEcho not implemented")
+}
+
+// UnsafeStreamTestServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StreamTestServer will
+// result in compilation errors.
+type UnsafeStreamTestServer interface {
+	mustEmbedUnimplementedStreamTestServer()
+}
+
+func RegisterStreamTestServer(s grpc.ServiceRegistrar, srv StreamTestServer) {
+	s.RegisterService(&StreamTest_ServiceDesc, srv)
+}
+
+func _StreamTest_ServerStreamEcho_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(EchoRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StreamTestServer).ServerStreamEcho(m, &streamTestServerStreamEchoServer{stream})
+}
+
+type StreamTest_ServerStreamEchoServer interface {
+	Send(*EchoResponse) error
+	grpc.ServerStream
+}
+
+type streamTestServerStreamEchoServer struct {
+	grpc.ServerStream
+}
+
+func (x *streamTestServerStreamEchoServer) Send(m *EchoResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _StreamTest_ClientStreamEcho_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(StreamTestServer).ClientStreamEcho(&streamTestClientStreamEchoServer{stream})
+}
+
+type StreamTest_ClientStreamEchoServer interface {
+	SendAndClose(*EchoResponse) error
+	Recv() (*EchoRequest, error)
+	grpc.ServerStream
+}
+
+type streamTestClientStreamEchoServer struct {
+	grpc.ServerStream
+}
+
+func (x *streamTestClientStreamEchoServer) SendAndClose(m *EchoResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *streamTestClientStreamEchoServer) Recv() (*EchoRequest, error) {
+	m := new(EchoRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _StreamTest_BidiStreamEcho_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(StreamTestServer).BidiStreamEcho(&streamTestBidiStreamEchoServer{stream})
+}
+
+type StreamTest_BidiStreamEchoServer interface {
+	Send(*EchoResponse) error
+	Recv() (*EchoRequest, error)
+	grpc.ServerStream
+}
+
+type streamTestBidiStreamEchoServer struct {
+	grpc.ServerStream
+}
+
+func (x *streamTestBidiStreamEchoServer) Send(m *EchoResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *streamTestBidiStreamEchoServer) Recv() (*EchoRequest, error) {
+	m := new(EchoRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// StreamTest_ServiceDesc is the grpc.ServiceDesc for StreamTest service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StreamTest_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "scenarigo.testdata.test.StreamTest",
+	HandlerType: (*StreamTestServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ServerStreamEcho",
+			Handler:       _StreamTest_ServerStreamEcho_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ClientStreamEcho",
+			Handler:       _StreamTest_ClientStreamEcho_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "BidiStreamEcho",
+			Handler:       _StreamTest_BidiStreamEcho_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "test/stream.proto",
+}
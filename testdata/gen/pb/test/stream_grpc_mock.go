@@ -0,0 +1,980 @@
+// Code generated by MockGen. DO NOT EDIT.
+
+// Package test is a generated GoMock package.
+package test
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	grpc "google.golang.org/grpc"
+	metadata "google.golang.org/grpc/metadata"
+)
+
+// MockStreamTestClient is a mock of StreamTestClient interface.
+type MockStreamTestClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockStreamTestClientMockRecorder
+}
+
+// MockStreamTestClientMockRecorder is the mock recorder for MockStreamTestClient.
+type MockStreamTestClientMockRecorder struct {
+	mock *MockStreamTestClient
+}
+
+// NewMockStreamTestClient creates a new mock instance.
+func NewMockStreamTestClient(ctrl *gomock.Controller) *MockStreamTestClient {
+	mock := &MockStreamTestClient{ctrl: ctrl}
+	mock.recorder = &MockStreamTestClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStreamTestClient) EXPECT() *MockStreamTestClientMockRecorder {
+	return m.recorder
+}
+
+// BidiStreamEcho mocks base method.
+func (m *MockStreamTestClient) BidiStreamEcho(ctx context.Context, opts ...grpc.CallOption) (StreamTest_BidiStreamEchoClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BidiStreamEcho", varargs...)
+	ret0, _ := ret[0].(StreamTest_BidiStreamEchoClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BidiStreamEcho indicates an expected call of BidiStreamEcho.
+func (mr *MockStreamTestClientMockRecorder) BidiStreamEcho(ctx interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BidiStreamEcho", reflect.TypeOf((*MockStreamTestClient)(nil).BidiStreamEcho), varargs...)
+}
+
+// ClientStreamEcho mocks base method.
+func (m *MockStreamTestClient) ClientStreamEcho(ctx context.Context, opts ...grpc.CallOption) (StreamTest_ClientStreamEchoClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ClientStreamEcho", varargs...)
+	ret0, _ := ret[0].(StreamTest_ClientStreamEchoClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClientStreamEcho indicates an expected call of ClientStreamEcho.
+func (mr *MockStreamTestClientMockRecorder) ClientStreamEcho(ctx interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClientStreamEcho", reflect.TypeOf((*MockStreamTestClient)(nil).ClientStreamEcho), varargs...)
+}
+
+// ServerStreamEcho mocks base method.
+func (m *MockStreamTestClient) ServerStreamEcho(ctx context.Context, in *EchoRequest, opts ...grpc.CallOption) (StreamTest_ServerStreamEchoClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ServerStreamEcho", varargs...)
+	ret0, _ := ret[0].(StreamTest_ServerStreamEchoClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ServerStreamEcho indicates an expected call of ServerStreamEcho.
+func (mr *MockStreamTestClientMockRecorder) ServerStreamEcho(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServerStreamEcho", reflect.TypeOf((*MockStreamTestClient)(nil).ServerStreamEcho), varargs...)
+}
+
+// MockStreamTest_ServerStreamEchoClient is a mock of StreamTest_ServerStreamEchoClient interface.
+type MockStreamTest_ServerStreamEchoClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockStreamTest_ServerStreamEchoClientMockRecorder
+}
+
+// MockStreamTest_ServerStreamEchoClientMockRecorder is the mock recorder for MockStreamTest_ServerStreamEchoClient.
+type MockStreamTest_ServerStreamEchoClientMockRecorder struct {
+	mock *MockStreamTest_ServerStreamEchoClient
+}
+
+// NewMockStreamTest_ServerStreamEchoClient creates a new mock instance.
+func NewMockStreamTest_ServerStreamEchoClient(ctrl *gomock.Controller) *MockStreamTest_ServerStreamEchoClient {
+	mock := &MockStreamTest_ServerStreamEchoClient{ctrl: ctrl}
+	mock.recorder = &MockStreamTest_ServerStreamEchoClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStreamTest_ServerStreamEchoClient) EXPECT() *MockStreamTest_ServerStreamEchoClientMockRecorder {
+	return m.recorder
+}
+
+// CloseSend mocks base method.
+func (m *MockStreamTest_ServerStreamEchoClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockStreamTest_ServerStreamEchoClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockStreamTest_ServerStreamEchoClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockStreamTest_ServerStreamEchoClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockStreamTest_ServerStreamEchoClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockStreamTest_ServerStreamEchoClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockStreamTest_ServerStreamEchoClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockStreamTest_ServerStreamEchoClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockStreamTest_ServerStreamEchoClient)(nil).Header))
+}
+
+// Recv mocks base method.
+func (m *MockStreamTest_ServerStreamEchoClient) Recv() (*EchoResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*EchoResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockStreamTest_ServerStreamEchoClientMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockStreamTest_ServerStreamEchoClient)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockStreamTest_ServerStreamEchoClient) RecvMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockStreamTest_ServerStreamEchoClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockStreamTest_ServerStreamEchoClient)(nil).RecvMsg), m)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockStreamTest_ServerStreamEchoClient) SendMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockStreamTest_ServerStreamEchoClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockStreamTest_ServerStreamEchoClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockStreamTest_ServerStreamEchoClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockStreamTest_ServerStreamEchoClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockStreamTest_ServerStreamEchoClient)(nil).Trailer))
+}
+
+// MockStreamTest_ClientStreamEchoClient is a mock of StreamTest_ClientStreamEchoClient interface.
+type MockStreamTest_ClientStreamEchoClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockStreamTest_ClientStreamEchoClientMockRecorder
+}
+
+// MockStreamTest_ClientStreamEchoClientMockRecorder is the mock recorder for MockStreamTest_ClientStreamEchoClient.
+type MockStreamTest_ClientStreamEchoClientMockRecorder struct {
+	mock *MockStreamTest_ClientStreamEchoClient
+}
+
+// NewMockStreamTest_ClientStreamEchoClient creates a new mock instance.
+func NewMockStreamTest_ClientStreamEchoClient(ctrl *gomock.Controller) *MockStreamTest_ClientStreamEchoClient {
+	mock := &MockStreamTest_ClientStreamEchoClient{ctrl: ctrl}
+	mock.recorder = &MockStreamTest_ClientStreamEchoClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStreamTest_ClientStreamEchoClient) EXPECT() *MockStreamTest_ClientStreamEchoClientMockRecorder {
+	return m.recorder
+}
+
+// CloseAndRecv mocks base method.
+func (m *MockStreamTest_ClientStreamEchoClient) CloseAndRecv() (*EchoResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseAndRecv")
+	ret0, _ := ret[0].(*EchoResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseAndRecv indicates an expected call of CloseAndRecv.
+func (mr *MockStreamTest_ClientStreamEchoClientMockRecorder) CloseAndRecv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseAndRecv", reflect.TypeOf((*MockStreamTest_ClientStreamEchoClient)(nil).CloseAndRecv))
+}
+
+// CloseSend mocks base method.
+func (m *MockStreamTest_ClientStreamEchoClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockStreamTest_ClientStreamEchoClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockStreamTest_ClientStreamEchoClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockStreamTest_ClientStreamEchoClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockStreamTest_ClientStreamEchoClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockStreamTest_ClientStreamEchoClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockStreamTest_ClientStreamEchoClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockStreamTest_ClientStreamEchoClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockStreamTest_ClientStreamEchoClient)(nil).Header))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockStreamTest_ClientStreamEchoClient) RecvMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockStreamTest_ClientStreamEchoClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockStreamTest_ClientStreamEchoClient)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockStreamTest_ClientStreamEchoClient) Send(arg0 *EchoRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockStreamTest_ClientStreamEchoClientMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockStreamTest_ClientStreamEchoClient)(nil).Send), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockStreamTest_ClientStreamEchoClient) SendMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockStreamTest_ClientStreamEchoClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockStreamTest_ClientStreamEchoClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockStreamTest_ClientStreamEchoClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockStreamTest_ClientStreamEchoClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockStreamTest_ClientStreamEchoClient)(nil).Trailer))
+}
+
+// MockStreamTest_BidiStreamEchoClient is a mock of StreamTest_BidiStreamEchoClient interface.
+type MockStreamTest_BidiStreamEchoClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockStreamTest_BidiStreamEchoClientMockRecorder
+}
+
+// MockStreamTest_BidiStreamEchoClientMockRecorder is the mock recorder for MockStreamTest_BidiStreamEchoClient.
+type MockStreamTest_BidiStreamEchoClientMockRecorder struct {
+	mock *MockStreamTest_BidiStreamEchoClient
+}
+
+// NewMockStreamTest_BidiStreamEchoClient creates a new mock instance.
+func NewMockStreamTest_BidiStreamEchoClient(ctrl *gomock.Controller) *MockStreamTest_BidiStreamEchoClient {
+	mock := &MockStreamTest_BidiStreamEchoClient{ctrl: ctrl}
+	mock.recorder = &MockStreamTest_BidiStreamEchoClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStreamTest_BidiStreamEchoClient) EXPECT() *MockStreamTest_BidiStreamEchoClientMockRecorder {
+	return m.recorder
+}
+
+// CloseSend mocks base method.
+func (m *MockStreamTest_BidiStreamEchoClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockStreamTest_BidiStreamEchoClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockStreamTest_BidiStreamEchoClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockStreamTest_BidiStreamEchoClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockStreamTest_BidiStreamEchoClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockStreamTest_BidiStreamEchoClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockStreamTest_BidiStreamEchoClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockStreamTest_BidiStreamEchoClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockStreamTest_BidiStreamEchoClient)(nil).Header))
+}
+
+// Recv mocks base method.
+func (m *MockStreamTest_BidiStreamEchoClient) Recv() (*EchoResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*EchoResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockStreamTest_BidiStreamEchoClientMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockStreamTest_BidiStreamEchoClient)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockStreamTest_BidiStreamEchoClient) RecvMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockStreamTest_BidiStreamEchoClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockStreamTest_BidiStreamEchoClient)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockStreamTest_BidiStreamEchoClient) Send(arg0 *EchoRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockStreamTest_BidiStreamEchoClientMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockStreamTest_BidiStreamEchoClient)(nil).Send), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockStreamTest_BidiStreamEchoClient) SendMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockStreamTest_BidiStreamEchoClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockStreamTest_BidiStreamEchoClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockStreamTest_BidiStreamEchoClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockStreamTest_BidiStreamEchoClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockStreamTest_BidiStreamEchoClient)(nil).Trailer))
+}
+
+// MockStreamTestServer is a mock of StreamTestServer interface.
+type MockStreamTestServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockStreamTestServerMockRecorder
+}
+
+// MockStreamTestServerMockRecorder is the mock recorder for MockStreamTestServer.
+type MockStreamTestServerMockRecorder struct {
+	mock *MockStreamTestServer
+}
+
+// NewMockStreamTestServer creates a new mock instance.
+func NewMockStreamTestServer(ctrl *gomock.Controller) *MockStreamTestServer {
+	mock := &MockStreamTestServer{ctrl: ctrl}
+	mock.recorder = &MockStreamTestServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStreamTestServer) EXPECT() *MockStreamTestServerMockRecorder {
+	return m.recorder
+}
+
+// BidiStreamEcho mocks base method.
+func (m *MockStreamTestServer) BidiStreamEcho(arg0 StreamTest_BidiStreamEchoServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BidiStreamEcho", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BidiStreamEcho indicates an expected call of BidiStreamEcho.
+func (mr *MockStreamTestServerMockRecorder) BidiStreamEcho(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BidiStreamEcho", reflect.TypeOf((*MockStreamTestServer)(nil).BidiStreamEcho), arg0)
+}
+
+// ClientStreamEcho mocks base method.
+func (m *MockStreamTestServer) ClientStreamEcho(arg0 StreamTest_ClientStreamEchoServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClientStreamEcho", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClientStreamEcho indicates an expected call of ClientStreamEcho.
+func (mr *MockStreamTestServerMockRecorder) ClientStreamEcho(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClientStreamEcho", reflect.TypeOf((*MockStreamTestServer)(nil).ClientStreamEcho), arg0)
+}
+
+// ServerStreamEcho mocks base method.
+func (m *MockStreamTestServer) ServerStreamEcho(arg0 *EchoRequest, arg1 StreamTest_ServerStreamEchoServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ServerStreamEcho", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ServerStreamEcho indicates an expected call of ServerStreamEcho.
+func (mr *MockStreamTestServerMockRecorder) ServerStreamEcho(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServerStreamEcho", reflect.TypeOf((*MockStreamTestServer)(nil).ServerStreamEcho), arg0, arg1)
+}
+
+// MockUnsafeStreamTestServer is a mock of UnsafeStreamTestServer interface.
+type MockUnsafeStreamTestServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockUnsafeStreamTestServerMockRecorder
+}
+
+// MockUnsafeStreamTestServerMockRecorder is the mock recorder for MockUnsafeStreamTestServer.
+type MockUnsafeStreamTestServerMockRecorder struct {
+	mock *MockUnsafeStreamTestServer
+}
+
+// NewMockUnsafeStreamTestServer creates a new mock instance.
+func NewMockUnsafeStreamTestServer(ctrl *gomock.Controller) *MockUnsafeStreamTestServer {
+	mock := &MockUnsafeStreamTestServer{ctrl: ctrl}
+	mock.recorder = &MockUnsafeStreamTestServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUnsafeStreamTestServer) EXPECT() *MockUnsafeStreamTestServerMockRecorder {
+	return m.recorder
+}
+
+// mustEmbedUnimplementedStreamTestServer mocks base method.
+func (m *MockUnsafeStreamTestServer) mustEmbedUnimplementedStreamTestServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedStreamTestServer")
+}
+
+// mustEmbedUnimplementedStreamTestServer indicates an expected call of mustEmbedUnimplementedStreamTestServer.
+func (mr *MockUnsafeStreamTestServerMockRecorder) mustEmbedUnimplementedStreamTestServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedStreamTestServer", reflect.TypeOf((*MockUnsafeStreamTestServer)(nil).mustEmbedUnimplementedStreamTestServer))
+}
+
+// MockStreamTest_ServerStreamEchoServer is a mock of StreamTest_ServerStreamEchoServer interface.
+type MockStreamTest_ServerStreamEchoServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockStreamTest_ServerStreamEchoServerMockRecorder
+}
+
+// MockStreamTest_ServerStreamEchoServerMockRecorder is the mock recorder for MockStreamTest_ServerStreamEchoServer.
+type MockStreamTest_ServerStreamEchoServerMockRecorder struct {
+	mock *MockStreamTest_ServerStreamEchoServer
+}
+
+// NewMockStreamTest_ServerStreamEchoServer creates a new mock instance.
+func NewMockStreamTest_ServerStreamEchoServer(ctrl *gomock.Controller) *MockStreamTest_ServerStreamEchoServer {
+	mock := &MockStreamTest_ServerStreamEchoServer{ctrl: ctrl}
+	mock.recorder = &MockStreamTest_ServerStreamEchoServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStreamTest_ServerStreamEchoServer) EXPECT() *MockStreamTest_ServerStreamEchoServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockStreamTest_ServerStreamEchoServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockStreamTest_ServerStreamEchoServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockStreamTest_ServerStreamEchoServer)(nil).Context))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockStreamTest_ServerStreamEchoServer) RecvMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockStreamTest_ServerStreamEchoServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockStreamTest_ServerStreamEchoServer)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockStreamTest_ServerStreamEchoServer) Send(arg0 *EchoResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockStreamTest_ServerStreamEchoServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockStreamTest_ServerStreamEchoServer)(nil).Send), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockStreamTest_ServerStreamEchoServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockStreamTest_ServerStreamEchoServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockStreamTest_ServerStreamEchoServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockStreamTest_ServerStreamEchoServer) SendMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockStreamTest_ServerStreamEchoServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockStreamTest_ServerStreamEchoServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockStreamTest_ServerStreamEchoServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockStreamTest_ServerStreamEchoServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockStreamTest_ServerStreamEchoServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockStreamTest_ServerStreamEchoServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockStreamTest_ServerStreamEchoServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockStreamTest_ServerStreamEchoServer)(nil).SetTrailer), arg0)
+}
+
+// MockStreamTest_ClientStreamEchoServer is a mock of StreamTest_ClientStreamEchoServer interface.
+type MockStreamTest_ClientStreamEchoServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockStreamTest_ClientStreamEchoServerMockRecorder
+}
+
+// MockStreamTest_ClientStreamEchoServerMockRecorder is the mock recorder for MockStreamTest_ClientStreamEchoServer.
+type MockStreamTest_ClientStreamEchoServerMockRecorder struct {
+	mock *MockStreamTest_ClientStreamEchoServer
+}
+
+// NewMockStreamTest_ClientStreamEchoServer creates a new mock instance.
+func NewMockStreamTest_ClientStreamEchoServer(ctrl *gomock.Controller) *MockStreamTest_ClientStreamEchoServer {
+	mock := &MockStreamTest_ClientStreamEchoServer{ctrl: ctrl}
+	mock.recorder = &MockStreamTest_ClientStreamEchoServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStreamTest_ClientStreamEchoServer) EXPECT() *MockStreamTest_ClientStreamEchoServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockStreamTest_ClientStreamEchoServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockStreamTest_ClientStreamEchoServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockStreamTest_ClientStreamEchoServer)(nil).Context))
+}
+
+// Recv mocks base method.
+func (m *MockStreamTest_ClientStreamEchoServer) Recv() (*EchoRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*EchoRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockStreamTest_ClientStreamEchoServerMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockStreamTest_ClientStreamEchoServer)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockStreamTest_ClientStreamEchoServer) RecvMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockStreamTest_ClientStreamEchoServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockStreamTest_ClientStreamEchoServer)(nil).RecvMsg), m)
+}
+
+// SendAndClose mocks base method.
+func (m *MockStreamTest_ClientStreamEchoServer) SendAndClose(arg0 *EchoResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendAndClose", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendAndClose indicates an expected call of SendAndClose.
+func (mr *MockStreamTest_ClientStreamEchoServerMockRecorder) SendAndClose(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendAndClose", reflect.TypeOf((*MockStreamTest_ClientStreamEchoServer)(nil).SendAndClose), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockStreamTest_ClientStreamEchoServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockStreamTest_ClientStreamEchoServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockStreamTest_ClientStreamEchoServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockStreamTest_ClientStreamEchoServer) SendMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockStreamTest_ClientStreamEchoServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockStreamTest_ClientStreamEchoServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockStreamTest_ClientStreamEchoServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockStreamTest_ClientStreamEchoServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockStreamTest_ClientStreamEchoServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockStreamTest_ClientStreamEchoServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockStreamTest_ClientStreamEchoServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockStreamTest_ClientStreamEchoServer)(nil).SetTrailer), arg0)
+}
+
+// MockStreamTest_BidiStreamEchoServer is a mock of StreamTest_BidiStreamEchoServer interface.
+type MockStreamTest_BidiStreamEchoServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockStreamTest_BidiStreamEchoServerMockRecorder
+}
+
+// MockStreamTest_BidiStreamEchoServerMockRecorder is the mock recorder for MockStreamTest_BidiStreamEchoServer.
+type MockStreamTest_BidiStreamEchoServerMockRecorder struct {
+	mock *MockStreamTest_BidiStreamEchoServer
+}
+
+// NewMockStreamTest_BidiStreamEchoServer creates a new mock instance.
+func NewMockStreamTest_BidiStreamEchoServer(ctrl *gomock.Controller) *MockStreamTest_BidiStreamEchoServer {
+	mock := &MockStreamTest_BidiStreamEchoServer{ctrl: ctrl}
+	mock.recorder = &MockStreamTest_BidiStreamEchoServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStreamTest_BidiStreamEchoServer) EXPECT() *MockStreamTest_BidiStreamEchoServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockStreamTest_BidiStreamEchoServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockStreamTest_BidiStreamEchoServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockStreamTest_BidiStreamEchoServer)(nil).Context))
+}
+
+// Recv mocks base method.
+func (m *MockStreamTest_BidiStreamEchoServer) Recv() (*EchoRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*EchoRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockStreamTest_BidiStreamEchoServerMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockStreamTest_BidiStreamEchoServer)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockStreamTest_BidiStreamEchoServer) RecvMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockStreamTest_BidiStreamEchoServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockStreamTest_BidiStreamEchoServer)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockStreamTest_BidiStreamEchoServer) Send(arg0 *EchoResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockStreamTest_BidiStreamEchoServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockStreamTest_BidiStreamEchoServer)(nil).Send), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockStreamTest_BidiStreamEchoServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockStreamTest_BidiStreamEchoServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockStreamTest_BidiStreamEchoServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockStreamTest_BidiStreamEchoServer) SendMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockStreamTest_BidiStreamEchoServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockStreamTest_BidiStreamEchoServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockStreamTest_BidiStreamEchoServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockStreamTest_BidiStreamEchoServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockStreamTest_BidiStreamEchoServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockStreamTest_BidiStreamEchoServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockStreamTest_BidiStreamEchoServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockStreamTest_BidiStreamEchoServer)(nil).SetTrailer), arg0)
+}
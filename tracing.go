@@ -0,0 +1,159 @@
+package scenarigo
+
+import (
+	gocontext "context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/zoncoen/scenarigo/context"
+	"github.com/zoncoen/scenarigo/internal/filepathutil"
+	"github.com/zoncoen/scenarigo/internal/tracing"
+	"github.com/zoncoen/scenarigo/schema"
+)
+
+const defaultTracingServiceName = "scenarigo"
+
+// protocols of the OTLP exporter
+const (
+	otlpProtocolGRPC         = "grpc"
+	otlpProtocolHTTPProtobuf = "http/protobuf"
+)
+
+// types of the spans for scenario execution
+const (
+	spanTypeFile     = "file"
+	spanTypeScenario = "scenario"
+	spanTypeStep     = "step"
+	spanTypeAttempt  = "attempt"
+)
+
+// startSpan starts a span for the test of ctx.Reporter().
+// The returned function ends the span with the test result.
+func startSpan(ctx *context.Context, typ, name string, attrs ...attribute.KeyValue) (*context.Context, func()) {
+	attrs = append(attrs, attribute.String("scenarigo.test", ctx.Reporter().Name()))
+	reqCtx, span := tracing.Start(ctx.RequestContext(), spanName(typ, name), spanAttributes(typ, attrs...)...)
+	r := ctx.Reporter()
+	return ctx.WithRequestContext(reqCtx), func() {
+		tracing.End(span, r)
+	}
+}
+
+func spanName(typ, name string) string {
+	if name == "" {
+		return typ
+	}
+	return name
+}
+
+func spanAttributes(typ string, attrs ...attribute.KeyValue) []trace.SpanStartOption {
+	return []trace.SpanStartOption{
+		trace.WithAttributes(append(attrs, attribute.String("scenarigo.type", typ))...),
+	}
+}
+
+// tracerProvider is a tracer provider which exports the spans as configured.
+type tracerProvider struct {
+	*sdktrace.TracerProvider
+	closers []io.Closer
+}
+
+// newTracerProvider creates a new tracer provider by cfg.
+// It returns nil if no exporter is configured.
+// The relative file path is resolved from root.
+func newTracerProvider(ctx gocontext.Context, cfg schema.TracingConfig, root string) (*tracerProvider, error) {
+	p := &tracerProvider{}
+	var exporters []sdktrace.SpanExporter
+	if cfg.OTLP.Endpoint != "" {
+		exp, err := newOTLPExporter(ctx, cfg.OTLP)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+		}
+		exporters = append(exporters, exp)
+	}
+	if cfg.File != "" {
+		f, err := os.Create(filepathutil.From(root, cfg.File))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create file exporter: %w", err)
+		}
+		p.closers = append(p.closers, f)
+		exp, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			f.Close()
+			return nil, fmt.Errorf("failed to create file exporter: %w", err)
+		}
+		exporters = append(exporters, exp)
+	}
+	if len(exporters) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+
+	name := cfg.ServiceName
+	if name == "" {
+		name = defaultTracingServiceName
+	}
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewSchemaless(attribute.String("service.name", name)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resource: %w", err)
+	}
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+	}
+	for _, exp := range exporters {
+		opts = append(opts, sdktrace.WithBatcher(exp))
+	}
+	p.TracerProvider = sdktrace.NewTracerProvider(opts...)
+	return p, nil
+}
+
+func newOTLPExporter(ctx gocontext.Context, cfg schema.OTLPTracingConfig) (sdktrace.SpanExporter, error) {
+	switch strings.ToLower(cfg.Protocol) {
+	case "", otlpProtocolGRPC:
+		opts := []otlptracegrpc.Option{
+			otlptracegrpc.WithEndpoint(cfg.Endpoint),
+		}
+		if cfg.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		if len(cfg.Headers) > 0 {
+			opts = append(opts, otlptracegrpc.WithHeaders(cfg.Headers))
+		}
+		return otlptracegrpc.New(ctx, opts...)
+	case otlpProtocolHTTPProtobuf:
+		opts := []otlptracehttp.Option{
+			otlptracehttp.WithEndpoint(cfg.Endpoint),
+		}
+		if cfg.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		if len(cfg.Headers) > 0 {
+			opts = append(opts, otlptracehttp.WithHeaders(cfg.Headers))
+		}
+		return otlptracehttp.New(ctx, opts...)
+	default:
+		return nil, fmt.Errorf("unknown protocol %q: must be %q or %q", cfg.Protocol, otlpProtocolGRPC, otlpProtocolHTTPProtobuf)
+	}
+}
+
+// Shutdown flushes the remaining spans and stops the exporters.
+func (p *tracerProvider) Shutdown(ctx gocontext.Context) error {
+	err := p.TracerProvider.Shutdown(ctx)
+	for _, c := range p.closers {
+		if cerr := c.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
@@ -0,0 +1,79 @@
+package scenarigo
+
+import (
+	gocontext "context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/zoncoen/scenarigo/internal/tracing"
+	"github.com/zoncoen/scenarigo/schema"
+)
+
+func TestNewTracerProvider(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		p, err := newTracerProvider(gocontext.Background(), schema.TracingConfig{}, t.TempDir())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p != nil {
+			t.Fatal("provider must be nil")
+		}
+	})
+	t.Run("file", func(t *testing.T) {
+		dir := t.TempDir()
+		p, err := newTracerProvider(gocontext.Background(), schema.TracingConfig{
+			ServiceName: "test",
+			File:        "traces.json",
+		}, dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, span := tracing.Start(tracing.WithTracerProvider(gocontext.Background(), p), "test span")
+		span.End()
+		if err := p.Shutdown(gocontext.Background()); err != nil {
+			t.Fatal(err)
+		}
+		b, err := os.ReadFile(filepath.Join(dir, "traces.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, s := range []string{`"Name":"test span"`, `"Value":"test"`} {
+			if !strings.Contains(string(b), s) {
+				t.Errorf("%q not found in %s", s, b)
+			}
+		}
+	})
+	t.Run("otlp", func(t *testing.T) {
+		for _, protocol := range []string{"", otlpProtocolGRPC, otlpProtocolHTTPProtobuf} {
+			p, err := newTracerProvider(gocontext.Background(), schema.TracingConfig{
+				OTLP: schema.OTLPTracingConfig{
+					Endpoint: "localhost:4317",
+					Protocol: protocol,
+					Insecure: true,
+				},
+			}, t.TempDir())
+			if err != nil {
+				t.Fatalf("%q: %s", protocol, err)
+			}
+			if p == nil {
+				t.Fatalf("%q: provider must not be nil", protocol)
+			}
+			ctx, cancel := gocontext.WithCancel(gocontext.Background())
+			cancel()
+			_ = p.Shutdown(ctx)
+		}
+	})
+	t.Run("unknown protocol", func(t *testing.T) {
+		_, err := newTracerProvider(gocontext.Background(), schema.TracingConfig{
+			OTLP: schema.OTLPTracingConfig{
+				Endpoint: "localhost:4317",
+				Protocol: "thrift",
+			},
+		}, t.TempDir())
+		if err == nil {
+			t.Fatal("no error")
+		}
+	})
+}